@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/henrocdotnet/active-campaigner/campaigner"
+	"github.com/henrocdotnet/active-campaigner/campaigner/csvio"
+)
+
+// Handles `contact import [-mapping file] [-rejects file] [-dry-run] <file.csv>`.
+func contactImport(ctx context.Context, c *campaigner.Campaigner, args []string) {
+	var (
+		flags   = flag.NewFlagSet("contact import", flag.ExitOnError)
+		mapping = flags.String("mapping", "", "JSON file mapping CSV headers to contact fields")
+		rejects = flags.String("rejects", "", "CSV file that rejected rows are written to")
+		dryRun  = flags.Bool("dry-run", false, "validate the file without importing anything")
+	)
+
+	handleError(flags.Parse(args))
+	if flags.NArg() != 1 {
+		printUsage()
+		os.Exit(-1)
+	}
+
+	in, err := os.Open(flags.Arg(0))
+	handleError(err)
+	defer in.Close()
+
+	i := csvio.Importer{Client: c, DryRun: *dryRun}
+
+	if len(*mapping) > 0 {
+		i.Mapping, err = csvio.LoadMapping(*mapping)
+		handleError(err)
+	}
+
+	if len(*rejects) > 0 {
+		out, err := os.Create(*rejects)
+		handleError(err)
+		defer out.Close()
+		i.Rejects = out
+	}
+
+	r, err := i.Import(ctx, in)
+	handleError(err)
+
+	for _, y := range r.Rejected {
+		fmt.Printf("\tRow %d: %s\n", y.Row, strings.Join(y.Reasons, "; "))
+	}
+	fmt.Println("")
+	fmt.Printf("Rows: %d, Accepted: %d, Rejected: %d\n", r.Rows, r.Accepted, len(r.Rejected))
+	if *dryRun {
+		fmt.Printf("Dry run, nothing was imported.\n")
+	}
+	for _, id := range r.BatchIDs {
+		fmt.Printf("Batch: %s\n", id)
+	}
+}
+
+// Handles `contact export [-fields "Title,Title"] [-o file.csv]`.
+func contactExport(ctx context.Context, c *campaigner.Campaigner, args []string) {
+	var (
+		flags            = flag.NewFlagSet("contact export", flag.ExitOnError)
+		fields           = flags.String("fields", "", "comma separated custom field titles to export")
+		output           = flags.String("o", "", "CSV file to write (defaults to STDOUT)")
+		out    io.Writer = os.Stdout
+	)
+
+	handleError(flags.Parse(args))
+
+	e := csvio.Exporter{Client: c}
+	for _, f := range strings.Split(*fields, ",") {
+		if f = strings.TrimSpace(f); len(f) > 0 {
+			e.Fields = append(e.Fields, f)
+		}
+	}
+
+	if len(*output) > 0 {
+		f, err := os.Create(*output)
+		handleError(err)
+		defer f.Close()
+		out = f
+	}
+
+	n, err := e.Export(ctx, out)
+	handleError(err)
+
+	fmt.Fprintf(os.Stderr, "Exported %d contacts.\n", n)
+}
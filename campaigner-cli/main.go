@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/kr/pretty"
 	"log"
+	"log/slog"
 	"os"
 	"regexp"
 	"strconv"
@@ -21,6 +23,7 @@ var (
 type envConfig struct {
 	APIToken string `envconfig:"api_token"`
 	BaseURL  string `envconfig:"base_url"`
+	Debug    bool   `envconfig:"debug"`
 }
 
 func init() {
@@ -35,7 +38,15 @@ func init() {
 func main() {
 	args := os.Args
 
-	c := campaigner.Campaigner{APIToken: config.APIToken, BaseURL: config.BaseURL}
+	ctx := context.Background()
+	c := campaigner.New(config.APIToken, config.BaseURL)
+
+	// Debug mode logs every request (with bodies and curl commands) to STDERR.
+	if config.Debug {
+		c.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		c.LogBodies = true
+		c.DumpCurl = true
+	}
 
 	if len(args) < 3 {
 		printUsage()
@@ -57,7 +68,7 @@ func main() {
 				os.Exit(-1)
 			}
 
-			err = c.ContactDelete(id)
+			err = c.Contacts.Delete(ctx, id)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -65,7 +76,7 @@ func main() {
 
 		case "find":
 			email := args[3]
-			r, err := c.ContactFind(email)
+			r, err := c.Contacts.Find(ctx, email)
 			handleError(err)
 			fmt.Printf("% #v\n", pretty.Formatter(r))
 
@@ -95,7 +106,7 @@ func main() {
 				os.Exit(-1)
 			}
 
-			r, err := c.ContactList(limit, offset)
+			r, err := c.Contacts.List(ctx, limit, offset)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -116,14 +127,14 @@ func main() {
 				os.Exit(-1)
 			}
 
-			r, err := c.ContactRead(id)
+			r, err := c.Contacts.Read(ctx, id)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
 			}
 			fmt.Printf("% #v\n", pretty.Formatter(r))
 
-			fields, err := c.FieldList()
+			fields, err := c.Fields.List(ctx)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -132,7 +143,7 @@ func main() {
 				var n string
 				for _, b := range fields.Fields {
 					//log.Printf("%d %d %s\n", b.ID, fieldValue.FieldID.Int64(), b.Title)
-					if b.ID == fieldValue.FieldID.Int64() {
+					if b.ID == fieldValue.FieldID {
 						n = b.Title
 					}
 
@@ -148,17 +159,23 @@ func main() {
 				os.Exit(-1)
 			}
 
-			r, err := c.ContactTagReadByContactID(id)
+			r, err := c.Contacts.Tags(ctx, id)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
 			}
 			fmt.Printf("% #v\n", pretty.Formatter(r))
+
+		case "import":
+			contactImport(ctx, c, args[3:])
+
+		case "export":
+			contactExport(ctx, c, args[3:])
 		}
 	case "field":
 		switch args[2] {
 		case "list":
-			r, err := c.FieldList()
+			r, err := c.Fields.List(ctx)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -171,7 +188,7 @@ func main() {
 	case "list":
 		switch args[2] {
 		case "list":
-			r, err := c.ListList()
+			r, err := c.Lists.List(ctx)
 			if err != nil {
 				handleError(err)
 
@@ -186,7 +203,7 @@ func main() {
 				handleError(err)
 			}
 
-			r, err := c.ListRead(id)
+			r, err := c.Lists.Read(ctx, id)
 			if err != nil {
 				handleError(err)
 			}
@@ -199,14 +216,14 @@ func main() {
 			id, err := strconv.ParseInt(args[3], 10, 64)
 			handleError(err)
 
-			r, err := c.OrganizationRead(id)
+			r, err := c.Organizations.Read(ctx, id)
 			handleError(err)
 
 			fmt.Printf("% #v\n", pretty.Formatter(r))
 		case "find":
 			name := args[3]
 
-			r, err := c.OrganizationFind(name)
+			r, err := c.Organizations.Find(ctx, name)
 			handleError(err)
 
 			fmt.Printf("% #v\n", pretty.Formatter(r))
@@ -218,7 +235,7 @@ func main() {
 				os.Exit(-1)
 			}
 
-			err = c.OrganizationDelete(int64(id))
+			err = c.Organizations.Delete(ctx, int64(id))
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -250,7 +267,7 @@ func main() {
 				os.Exit(-1)
 			}
 
-			r, err := c.OrganizationList(limit, offset)
+			r, err := c.Organizations.List(ctx, limit, offset)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -258,7 +275,7 @@ func main() {
 
 			fmt.Printf("Listing Organizations:\n\n")
 			for _, y := range r.Organizations {
-				fmt.Printf("\t%d: %s (%s)\n", y.ID, y.Name, y.ContactCount)
+				fmt.Printf("\t%d: %s (%d)\n", y.ID, y.Name, y.ContactCount)
 			}
 			fmt.Println("")
 			fmt.Printf("Limit: %d, Offset: %d, Total: %d\n\n", limit, offset, r.Meta.Total)
@@ -268,7 +285,7 @@ func main() {
 	case "tag":
 		switch args[2] {
 		case "list":
-			r, err := c.TagList()
+			r, err := c.Tags.List(ctx)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -286,7 +303,7 @@ func main() {
 				os.Exit(-1)
 			}
 
-			err = c.TagDelete(int64(id))
+			err = c.Tags.Delete(ctx, int64(id))
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -306,7 +323,7 @@ func main() {
 				},
 			}
 
-			r, err := c.TagList()
+			r, err := c.Tags.List(ctx)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -320,7 +337,7 @@ func main() {
 				os.Exit(-1)
 			}
 
-			fields, err := c.FieldList()
+			fields, err := c.Fields.List(ctx)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(-1)
@@ -334,7 +351,7 @@ func main() {
 				os.Exit(-1)
 			}
 
-			lists, err := c.ListList()
+			lists, err := c.Lists.List(ctx)
 			if err != nil {
 				handleError(err)
 			}
@@ -349,7 +366,7 @@ func main() {
 			if err != nil {
 				handleError(err)
 			}
-			r, err := c.TagRead(id)
+			r, err := c.Tags.Read(ctx, id)
 			if err != nil {
 				handleError(err)
 			}
@@ -369,7 +386,9 @@ func printUsage() {
 Usage:
 	cli <contact|tag|org> 
 
-	contact <list|read>
+	contact <export|import|list|read>
+		export [-fields "Title,Title"] [-o file.csv]: Export contacts (with selected custom fields) to CSV.
+		import [-mapping file.json] [-rejects file.csv] [-dry-run] <file.csv>: Import contacts from CSV.
 		     list: List contacts.
 		read <id>: Read contact.
 	
@@ -381,7 +400,7 @@ Usage:
 
 `
 
-	fmt.Printf(tmpl)
+	fmt.Print(tmpl)
 }
 
 func handleError(e error) {
@@ -0,0 +1,93 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Automation is an automation held by the server.  Automations can only be read.
+type Automation struct {
+	ID   int64
+	Name string
+}
+
+// ContactAutomation records a contact entering an automation.
+type ContactAutomation struct {
+	ID           int64
+	ContactID    int64
+	AutomationID int64
+	Added        time.Time
+}
+
+// AddAutomation adds an automation and returns its ID.
+func (s *Server) AddAutomation(a Automation) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a.ID = s.nextID("automations")
+	s.automations[a.ID] = &a
+
+	return a.ID
+}
+
+// AddContactAutomation adds a contact to an automation and returns the ID of the link.  The automation doesn't have to
+// exist (automations can be deleted while contacts are still in them).
+func (s *Server) AddContactAutomation(contactID int64, automationID int64) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ca := &ContactAutomation{ID: s.nextID("contactAutomations"), ContactID: contactID, AutomationID: automationID, Added: time.Now()}
+	s.contactAutomations[ca.ID] = ca
+
+	return ca.ID
+}
+
+// Returns the automations a contact entered.
+func (s *Server) contactAutomationsByContact(contactID int64) []map[string]interface{} {
+	l := []map[string]interface{}{}
+
+	for _, id := range sortedIDs(s.ids["contactAutomations"], func(id int64) bool { ca, ok := s.contactAutomations[id]; return ok && ca.ContactID == contactID }) {
+		ca := s.contactAutomations[id]
+		l = append(l, map[string]interface{}{
+			"contact":           strconv.FormatInt(ca.ContactID, 10),
+			"seriesid":          strconv.FormatInt(ca.AutomationID, 10),
+			"startid":           "0",
+			"status":            "1",
+			"adddate":           timestamp(ca.Added),
+			"remdate":           nil,
+			"timespan":          nil,
+			"lastblock":         "0",
+			"lastdate":          timestamp(ca.Added),
+			"completedElements": "0",
+			"totalElements":     "1",
+			"completed":         0,
+			"completeValue":     0,
+			"links": map[string]interface{}{
+				"automation":   s.link("contactAutomations/%d/automation", ca.ID),
+				"contact":      s.link("contactAutomations/%d/contact", ca.ID),
+				"contactGoals": s.link("contactAutomations/%d/contactGoals", ca.ID),
+			},
+			"id":         strconv.FormatInt(ca.ID, 10),
+			"automation": strconv.FormatInt(ca.AutomationID, 10),
+		})
+	}
+
+	return l
+}
+
+// Handles /api/3/automations.
+func (s *Server) handleAutomations(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	a, ok := s.automations[id]
+	if !hasID || r.Method != http.MethodGet || !ok {
+		writeNotFound(w, "Automation", id)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"automation": map[string]interface{}{
+		"name":   a.Name,
+		"status": "1",
+		"links":  map[string]interface{}{"contactAutomations": s.link("automations/%d/contactAutomations", a.ID)},
+		"id":     strconv.FormatInt(a.ID, 10),
+	}})
+}
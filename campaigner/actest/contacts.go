@@ -0,0 +1,377 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Contact is a contact held by the server.
+type Contact struct {
+	ID             int64
+	EmailAddress   string
+	FirstName      string
+	LastName       string
+	PhoneNumber    string
+	OrganizationID int64
+	BouncedHard    int
+	BouncedSoft    int
+	Bounced        time.Time
+	Created        time.Time
+	Updated        time.Time
+}
+
+// AddContact adds a contact and returns its ID.
+func (s *Server) AddContact(c Contact) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addContact(c).ID
+}
+
+// Contact returns a contact by ID.
+func (s *Server) Contact(id int64) (Contact, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.contacts[id]
+	if !ok {
+		return Contact{}, false
+	}
+
+	return *c, true
+}
+
+// Adds a contact.  Must be called with the lock held.
+func (s *Server) addContact(c Contact) *Contact {
+	c.ID = s.nextID("contacts")
+	if c.Created.IsZero() {
+		c.Created = time.Now()
+	}
+	c.Updated = c.Created
+
+	s.contacts[c.ID] = &c
+
+	return &c
+}
+
+// Returns a contact by email (case insensitive).
+func (s *Server) contactByEmail(email string) *Contact {
+	for _, c := range s.contacts {
+		if strings.EqualFold(c.EmailAddress, email) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Returns the JSON representation of a contact.
+func (s *Server) renderContact(c *Contact) map[string]interface{} {
+	var local, domain string
+	if x := strings.LastIndex(c.EmailAddress, "@"); x >= 0 {
+		local, domain = c.EmailAddress[:x], c.EmailAddress[x+1:]
+	}
+
+	id := strconv.FormatInt(c.ID, 10)
+	var bounced interface{}
+	if !c.Bounced.IsZero() {
+		bounced = c.Bounced.Format("2006-01-02")
+	}
+	links := map[string]interface{}{}
+	for _, l := range []string{"bounceLogs", "contactAutomations", "contactData", "contactGoals", "contactLists", "contactLogs", "contactTags", "contactDeals", "deals", "fieldValues", "geoIps", "notes", "organization", "plusAppend", "trackingLogs", "scoreValues"} {
+		links[l] = s.link("contacts/%d/%s", c.ID, l)
+	}
+
+	return map[string]interface{}{
+		"cdate":                 timestamp(c.Created),
+		"email":                 c.EmailAddress,
+		"phone":                 c.PhoneNumber,
+		"firstName":             c.FirstName,
+		"lastName":              c.LastName,
+		"orgid":                 strconv.FormatInt(c.OrganizationID, 10),
+		"segmentio_id":          "",
+		"bounced_hard":          strconv.Itoa(c.BouncedHard),
+		"bounced_soft":          strconv.Itoa(c.BouncedSoft),
+		"bounced_date":          bounced,
+		"ip":                    "0",
+		"ua":                    nil,
+		"hash":                  "",
+		"socialdata_lastcheck":  nil,
+		"email_local":           local,
+		"email_domain":          domain,
+		"sentcnt":               "0",
+		"rating_tstamp":         nil,
+		"gravatar":              "0",
+		"deleted":               "0",
+		"anonymized":            "0",
+		"adate":                 nil,
+		"udate":                 timestamp(c.Updated),
+		"edate":                 nil,
+		"deleted_at":            nil,
+		"created_utc_timestamp": utcTimestamp(c.Created),
+		"updated_utc_timestamp": utcTimestamp(c.Updated),
+		"links":                 links,
+		"id":                    id,
+		"organization":          nil,
+	}
+}
+
+// Handles /api/3/contacts.
+func (s *Server) handleContacts(w http.ResponseWriter, r *http.Request, id int64, hasID bool, sub string) {
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			s.contactList(w, r)
+		case http.MethodPost:
+			s.contactCreate(w, r)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	c, ok := s.contacts[id]
+	if !ok {
+		writeNotFound(w, "Subscriber", id)
+		return
+	}
+
+	switch {
+	case sub == "" && r.Method == http.MethodGet:
+		s.contactRead(w, c)
+	case sub == "" && r.Method == http.MethodPut:
+		s.contactUpdate(w, r, c)
+	case sub == "" && r.Method == http.MethodDelete:
+		s.contactDelete(w, c)
+	case sub == "contactTags" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"contactTags": s.contactTagsByContact(c.ID)})
+	case sub == "contactLists" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"contactLists": s.contactListsByContact(c.ID)})
+	case sub == "fieldValues" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"fieldValues": s.fieldValuesByContact(c.ID)})
+	case sub == "scoreValues" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"scoreValues": s.scoreValuesBy(c.ID, 0)})
+	case sub == "bounceLogs" && r.Method == http.MethodGet:
+		l := s.bounceLogsByContact(c.ID)
+		limit, offset := page(r)
+		start, end := window(len(l), limit, offset)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"bounceLogs": l[start:end], "meta": map[string]interface{}{"total": strconv.Itoa(len(l))}})
+	case sub == "trackingLogs" && r.Method == http.MethodGet:
+		l := s.trackingLogsByContact(c.ID)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"trackingLogs": l, "meta": map[string]interface{}{"total": strconv.Itoa(len(l))}})
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// Lists contacts, optionally filtered by email or segment.
+func (s *Server) contactList(w http.ResponseWriter, r *http.Request) {
+	var (
+		q     = r.URL.Query()
+		email = q.Get("filters[email]")
+		seg   *Segment
+		l     []map[string]interface{}
+	)
+	if len(email) == 0 {
+		email = q.Get("email")
+	}
+
+	segmentID, _ := strconv.ParseInt(q.Get("segmentid"), 10, 64)
+	if segmentID > 0 {
+		var ok bool
+		if seg, ok = s.segments[segmentID]; !ok {
+			writeNotFound(w, "Segment", segmentID)
+			return
+		}
+	}
+
+	for _, id := range sortedIDs(s.ids["contacts"], func(id int64) bool { _, ok := s.contacts[id]; return ok }) {
+		c := s.contacts[id]
+		if len(email) > 0 && !strings.EqualFold(c.EmailAddress, email) {
+			continue
+		}
+		if seg != nil && !seg.contains(c) {
+			continue
+		}
+		l = append(l, s.renderContact(c))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	// The score values of the listed contacts are sideloaded.
+	scoreValues := []map[string]interface{}{}
+	for _, c := range l[start:end] {
+		id, _ := strconv.ParseInt(c["id"].(string), 10, 64)
+		scoreValues = append(scoreValues, s.scoreValuesBy(id, 0)...)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"scoreValues": scoreValues,
+		"contacts":    append([]map[string]interface{}{}, l[start:end]...),
+		"meta": map[string]interface{}{
+			"total": strconv.Itoa(len(l)),
+			// Filters given in the query are echoed back as strings.
+			"page_input": map[string]interface{}{
+				"segmentid": strconv.FormatInt(segmentID, 10), "formid": 0, "listid": 0, "tagid": 0, "limit": limit, "offset": offset, "search": nil,
+				"sort": nil, "seriesid": 0, "waitid": 0, "status": -1, "forceQuery": 0, "cacheid": "",
+			},
+		},
+	})
+}
+
+// The contact fields accepted by create, update and sync.
+type contactRequest struct {
+	EmailAddress   *string `json:"email"`
+	FirstName      *string `json:"firstName"`
+	LastName       *string `json:"lastName"`
+	PhoneNumber    *string `json:"phone"`
+	OrganizationID *flexID `json:"orgid"`
+}
+
+// Copies the fields set in a request to a contact.
+func (req contactRequest) apply(c *Contact) {
+	if req.EmailAddress != nil {
+		c.EmailAddress = *req.EmailAddress
+	}
+	if req.FirstName != nil {
+		c.FirstName = *req.FirstName
+	}
+	if req.LastName != nil {
+		c.LastName = *req.LastName
+	}
+	if req.PhoneNumber != nil {
+		c.PhoneNumber = *req.PhoneNumber
+	}
+	if req.OrganizationID != nil {
+		c.OrganizationID = int64(*req.OrganizationID)
+	}
+	c.Updated = time.Now()
+}
+
+// Returns the error title for an invalid contact email, if any.
+func (s *Server) contactEmailError(email string, self *Contact) (title string, code string) {
+	switch {
+	case len(strings.TrimSpace(email)) == 0:
+		return "Email address is required", "field_missing"
+	case !strings.Contains(email, "@"):
+		return "Email address is not valid", "invalid"
+	}
+
+	if other := s.contactByEmail(email); other != nil && other != self {
+		return "Email address already exists in the system", "duplicate"
+	}
+
+	return "", ""
+}
+
+// Creates a contact.
+func (s *Server) contactCreate(w http.ResponseWriter, r *http.Request) {
+	var req contactRequest
+	if err := decode(r, "contact", &req); err != nil {
+		writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+		return
+	}
+
+	var email string
+	if req.EmailAddress != nil {
+		email = *req.EmailAddress
+	}
+	if title, code := s.contactEmailError(email, nil); len(title) > 0 {
+		writeUnprocessable(w, title, code, "/data/attributes/email")
+		return
+	}
+
+	c := s.addContact(Contact{})
+	req.apply(c)
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"fieldValues": []interface{}{}, "contact": s.renderContact(c)})
+}
+
+// Reads a contact along with its related data.
+func (s *Server) contactRead(w http.ResponseWriter, c *Contact) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"contactAutomations": s.contactAutomationsByContact(c.ID),
+		"contactData":        []interface{}{},
+		"contactGoals":       []interface{}{},
+		"contactLists":       s.contactListsByContact(c.ID),
+		"deals":              []interface{}{},
+		"fieldValues":        s.fieldValuesByContact(c.ID),
+		"geoAddresses":       []interface{}{},
+		"geoIps":             []interface{}{},
+		"contact":            s.renderContact(c),
+	})
+}
+
+// Updates a contact.
+func (s *Server) contactUpdate(w http.ResponseWriter, r *http.Request, c *Contact) {
+	var req contactRequest
+	if err := decode(r, "contact", &req); err != nil {
+		writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+		return
+	}
+
+	if req.EmailAddress != nil {
+		if title, code := s.contactEmailError(*req.EmailAddress, c); len(title) > 0 {
+			writeUnprocessable(w, title, code, "/data/attributes/email")
+			return
+		}
+	}
+
+	req.apply(c)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"contact": s.renderContact(c)})
+}
+
+// Deletes a contact along with its tags, list memberships and field values.
+func (s *Server) contactDelete(w http.ResponseWriter, c *Contact) {
+	delete(s.contacts, c.ID)
+
+	for id, ct := range s.contactTags {
+		if ct.ContactID == c.ID {
+			delete(s.contactTags, id)
+		}
+	}
+	for id, cl := range s.contactLists {
+		if cl.ContactID == c.ID {
+			delete(s.contactLists, id)
+		}
+	}
+	for id, fv := range s.fieldValues {
+		if fv.ContactID == c.ID {
+			delete(s.fieldValues, id)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// Creates or updates a contact by email.  Returns 201 for a new contact and 200 for an existing one.
+func (s *Server) contactSync(w http.ResponseWriter, r *http.Request) {
+	var req contactRequest
+	if err := decode(r, "contact", &req); err != nil {
+		writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+		return
+	}
+
+	var email string
+	if req.EmailAddress != nil {
+		email = *req.EmailAddress
+	}
+	if title, code := s.contactEmailError(email, s.contactByEmail(email)); len(title) > 0 {
+		writeUnprocessable(w, title, code, "/data/attributes/email")
+		return
+	}
+
+	status := http.StatusOK
+	c := s.contactByEmail(email)
+	if c == nil {
+		c = s.addContact(Contact{})
+		status = http.StatusCreated
+	}
+	req.apply(c)
+
+	writeJSON(w, status, map[string]interface{}{"fieldValues": []interface{}{}, "contact": s.renderContact(c)})
+}
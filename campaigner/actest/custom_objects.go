@@ -0,0 +1,592 @@
+package actest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomObjectSchema is a custom object schema held by the server.  IDs are UUIDs.
+type CustomObjectSchema struct {
+	ID            string
+	Slug          string
+	Singular      string
+	Plural        string
+	Description   string
+	Fields        []CustomObjectField
+	Relationships []CustomObjectRelationship
+	Created       time.Time
+	Updated       time.Time
+}
+
+// CustomObjectField is a field of a custom object schema.  Options are the values of dropdown and multiselect fields.
+type CustomObjectField struct {
+	ID       string
+	Type     string
+	Singular string
+	Plural   string
+	Required bool
+	Options  []string
+}
+
+// CustomObjectRelationship is a relationship of a custom object schema.  Records related to contacts use the
+// "primary-contact" relationship in the "contacts" namespace.
+type CustomObjectRelationship struct {
+	ID        string
+	Singular  string
+	Plural    string
+	Namespace string
+	HasMany   bool
+}
+
+// CustomObjectRecord is a custom object record held by the server.
+type CustomObjectRecord struct {
+	ID            string
+	ExternalID    string
+	SchemaID      string
+	Fields        map[string]interface{}
+	Relationships map[string][]int64
+	Created       time.Time
+	Updated       time.Time
+}
+
+// The field types accepted by the server.
+var customObjectFieldTypes = map[string]bool{
+	"text": true, "textarea": true, "number": true, "currency": true, "date": true, "datetime": true, "dropdown": true,
+	"multiselect": true,
+}
+
+// AddCustomObjectSchema adds a schema and returns its ID.
+func (s *Server) AddCustomObjectSchema(schema CustomObjectSchema) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addCustomObjectSchema(schema).ID
+}
+
+// CustomObjectRecord returns a copy of the record with the given external ID.
+func (s *Server) CustomObjectRecord(schemaID string, externalID string) (CustomObjectRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.customObjectRecords {
+		if r.SchemaID == schemaID && r.ExternalID == externalID {
+			return *r, true
+		}
+	}
+
+	return CustomObjectRecord{}, false
+}
+
+// Adds a schema.  Must be called with the lock held.
+func (s *Server) addCustomObjectSchema(schema CustomObjectSchema) *CustomObjectSchema {
+	n := s.nextID("customObjectSchemas")
+	schema.ID = uuid("5c000000", n)
+	if schema.Created.IsZero() {
+		schema.Created = time.Now()
+	}
+	schema.Updated = schema.Created
+
+	s.customObjectSchemas[n] = &schema
+
+	return &schema
+}
+
+// Returns a UUID for the nth object of a kind (the prefix).
+func uuid(prefix string, n int64) string {
+	return fmt.Sprintf("%s-0000-4000-8000-%012d", prefix, n)
+}
+
+// Returns the sequence number of a UUID returned by uuid.
+func parseUUID(prefix string, id string) (int64, bool) {
+	if !strings.HasPrefix(id, prefix+"-0000-4000-8000-") {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(strings.TrimPrefix(id, prefix+"-0000-4000-8000-"), 10, 64)
+
+	return n, err == nil
+}
+
+// Returns the JSON representation of a schema.
+func (s *Server) renderCustomObjectSchema(schema *CustomObjectSchema) map[string]interface{} {
+	fields := []interface{}{}
+	for _, f := range schema.Fields {
+		m := map[string]interface{}{
+			"id":         f.ID,
+			"labels":     map[string]interface{}{"singular": f.Singular, "plural": f.Plural},
+			"type":       f.Type,
+			"isRequired": f.Required,
+		}
+		if len(f.Options) > 0 {
+			var options []interface{}
+			for x, o := range f.Options {
+				options = append(options, map[string]interface{}{"id": strconv.Itoa(x + 1), "value": o})
+			}
+			m["options"] = options
+		}
+		fields = append(fields, m)
+	}
+
+	relationships := []interface{}{}
+	for _, y := range schema.Relationships {
+		relationships = append(relationships, map[string]interface{}{
+			"id":        y.ID,
+			"labels":    map[string]interface{}{"singular": y.Singular, "plural": y.Plural},
+			"namespace": y.Namespace,
+			"hasMany":   y.HasMany,
+		})
+	}
+
+	return map[string]interface{}{
+		"id":               schema.ID,
+		"slug":             schema.Slug,
+		"visibility":       "private",
+		"labels":           map[string]interface{}{"singular": schema.Singular, "plural": schema.Plural},
+		"description":      schema.Description,
+		"fields":           fields,
+		"relationships":    relationships,
+		"createdTimestamp": timestamp(schema.Created),
+		"updatedTimestamp": timestamp(schema.Updated),
+	}
+}
+
+// Returns the JSON representation of a record.  Fields are sent in schema order and related IDs as strings.
+func (s *Server) renderCustomObjectRecord(schema *CustomObjectSchema, r *CustomObjectRecord) map[string]interface{} {
+	fields := []interface{}{}
+	for _, f := range schema.Fields {
+		if v, ok := r.Fields[f.ID]; ok {
+			fields = append(fields, map[string]interface{}{"id": f.ID, "value": v})
+		}
+	}
+
+	relationships := map[string]interface{}{}
+	for id, l := range r.Relationships {
+		var ids []string
+		for _, x := range l {
+			ids = append(ids, strconv.FormatInt(x, 10))
+		}
+		relationships[id] = ids
+	}
+
+	return map[string]interface{}{
+		"id":               r.ID,
+		"externalId":       r.ExternalID,
+		"schemaId":         r.SchemaID,
+		"fields":           fields,
+		"relationships":    relationships,
+		"createdTimestamp": timestamp(r.Created),
+		"updatedTimestamp": timestamp(r.Updated),
+	}
+}
+
+// The schema fields sent by clients.
+type customObjectSchemaRequest struct {
+	Slug   string `json:"slug"`
+	Labels struct {
+		Singular string `json:"singular"`
+		Plural   string `json:"plural"`
+	} `json:"labels"`
+	Description *string `json:"description"`
+	Fields      []struct {
+		ID     string `json:"id"`
+		Type   string `json:"type"`
+		Labels struct {
+			Singular string `json:"singular"`
+			Plural   string `json:"plural"`
+		} `json:"labels"`
+		IsRequired bool `json:"isRequired"`
+		Options    []struct {
+			Value string `json:"value"`
+		} `json:"options"`
+	} `json:"fields"`
+	Relationships []struct {
+		ID     string `json:"id"`
+		Labels struct {
+			Singular string `json:"singular"`
+			Plural   string `json:"plural"`
+		} `json:"labels"`
+		Namespace string `json:"namespace"`
+		HasMany   bool   `json:"hasMany"`
+	} `json:"relationships"`
+}
+
+// Applies a schema request to a schema.  Fields and relationships are added or updated, never removed.  Returns the
+// error title and pointer if the result is invalid, leaving the schema unchanged.
+func (s *Server) applyCustomObjectSchema(schema *CustomObjectSchema, req customObjectSchemaRequest) (string, string) {
+	updated := *schema
+	updated.Fields = append([]CustomObjectField{}, schema.Fields...)
+	updated.Relationships = append([]CustomObjectRelationship{}, schema.Relationships...)
+
+	if len(req.Slug) > 0 {
+		updated.Slug = req.Slug
+	}
+	if len(req.Labels.Singular) > 0 {
+		updated.Singular = req.Labels.Singular
+	}
+	if len(req.Labels.Plural) > 0 {
+		updated.Plural = req.Labels.Plural
+	}
+	if req.Description != nil {
+		updated.Description = *req.Description
+	}
+
+	for _, y := range req.Fields {
+		if len(y.ID) == 0 {
+			return "Field ID is required", "/data/attributes/fields/id"
+		}
+
+		f := CustomObjectField{ID: y.ID, Type: y.Type, Singular: y.Labels.Singular, Plural: y.Labels.Plural, Required: y.IsRequired}
+		for _, o := range y.Options {
+			f.Options = append(f.Options, o.Value)
+		}
+
+		var found bool
+		for x := range updated.Fields {
+			if updated.Fields[x].ID == f.ID {
+				if len(f.Type) > 0 && f.Type != updated.Fields[x].Type {
+					return fmt.Sprintf("Field %s type can not be changed", f.ID), "/data/attributes/fields/type"
+				}
+				f.Type = updated.Fields[x].Type
+				updated.Fields[x] = f
+				found = true
+			}
+		}
+		if !found {
+			if !customObjectFieldTypes[f.Type] {
+				return fmt.Sprintf("Field %s has an invalid type", f.ID), "/data/attributes/fields/type"
+			}
+			updated.Fields = append(updated.Fields, f)
+		}
+	}
+
+	for _, y := range req.Relationships {
+		rel := CustomObjectRelationship{ID: y.ID, Singular: y.Labels.Singular, Plural: y.Labels.Plural, Namespace: y.Namespace, HasMany: y.HasMany}
+
+		var found bool
+		for x := range updated.Relationships {
+			if updated.Relationships[x].ID == rel.ID {
+				updated.Relationships[x] = rel
+				found = true
+			}
+		}
+		if !found {
+			updated.Relationships = append(updated.Relationships, rel)
+		}
+	}
+
+	switch {
+	case len(strings.TrimSpace(updated.Slug)) == 0:
+		return "Schema slug is required", "/data/attributes/slug"
+	case len(updated.Singular) == 0 || len(updated.Plural) == 0:
+		return "Schema labels are required", "/data/attributes/labels"
+	}
+	for _, y := range s.customObjectSchemas {
+		if y.ID != schema.ID && strings.EqualFold(y.Slug, updated.Slug) {
+			return "Schema slug already exists", "/data/attributes/slug"
+		}
+	}
+
+	*schema = updated
+
+	return "", ""
+}
+
+// Handles /api/3/customObjects/schemas and /api/3/customObjects/records.
+func (s *Server) handleCustomObjects(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "schemas":
+		s.customObjectSchemaList(w, r)
+	case len(parts) == 2 && parts[0] == "schemas":
+		s.customObjectSchema(w, r, parts[1])
+	case len(parts) >= 2 && len(parts) <= 4 && parts[0] == "records":
+		s.customObjectRecordRoutes(w, r, parts[1:])
+	default:
+		writeNotFound(w, "customObjects", 0)
+	}
+}
+
+// Returns a schema by ID.
+func (s *Server) findCustomObjectSchema(id string) (*CustomObjectSchema, bool) {
+	n, ok := parseUUID("5c000000", id)
+	if !ok {
+		return nil, false
+	}
+
+	schema, ok := s.customObjectSchemas[n]
+
+	return schema, ok
+}
+
+// Lists and creates schemas.
+func (s *Server) customObjectSchemaList(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		var l []map[string]interface{}
+		for _, n := range sortedIDs(s.ids["customObjectSchemas"], func(n int64) bool { _, ok := s.customObjectSchemas[n]; return ok }) {
+			l = append(l, s.renderCustomObjectSchema(s.customObjectSchemas[n]))
+		}
+
+		limit, offset := page(r)
+		start, end := window(len(l), limit, offset)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"schemas": append([]map[string]interface{}{}, l[start:end]...),
+			"meta":    map[string]interface{}{"total": len(l)},
+		})
+
+	case http.MethodPost:
+		var req customObjectSchemaRequest
+		if err := decode(r, "schema", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		var schema CustomObjectSchema
+		if title, pointer := s.applyCustomObjectSchema(&schema, req); len(title) > 0 {
+			writeUnprocessable(w, title, "invalid", pointer)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"schema": s.renderCustomObjectSchema(s.addCustomObjectSchema(schema))})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// Reads, updates and deletes a schema.
+func (s *Server) customObjectSchema(w http.ResponseWriter, r *http.Request, id string) {
+	schema, ok := s.findCustomObjectSchema(id)
+	if !ok {
+		writeNotFound(w, "Schema", 0)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"schema": s.renderCustomObjectSchema(schema)})
+
+	case http.MethodPut:
+		var req customObjectSchemaRequest
+		if err := decode(r, "schema", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		if title, pointer := s.applyCustomObjectSchema(schema, req); len(title) > 0 {
+			writeUnprocessable(w, title, "invalid", pointer)
+			return
+		}
+		schema.Updated = time.Now()
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"schema": s.renderCustomObjectSchema(schema)})
+
+	case http.MethodDelete:
+		n, _ := parseUUID("5c000000", id)
+		delete(s.customObjectSchemas, n)
+		for x, y := range s.customObjectRecords {
+			if y.SchemaID == id {
+				delete(s.customObjectRecords, x)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// Handles the records of a schema: /{schemaId}, /{schemaId}/{id} and /{schemaId}/external/{externalId}.
+func (s *Server) customObjectRecordRoutes(w http.ResponseWriter, r *http.Request, parts []string) {
+	schema, ok := s.findCustomObjectSchema(parts[0])
+	if !ok {
+		writeNotFound(w, "Schema", 0)
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			var l []map[string]interface{}
+			for _, n := range sortedIDs(s.ids["customObjectRecords"], func(n int64) bool { y, ok := s.customObjectRecords[n]; return ok && y.SchemaID == schema.ID }) {
+				l = append(l, s.renderCustomObjectRecord(schema, s.customObjectRecords[n]))
+			}
+
+			limit, offset := page(r)
+			start, end := window(len(l), limit, offset)
+
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"records": append([]map[string]interface{}{}, l[start:end]...),
+				"meta":    map[string]interface{}{"total": len(l)},
+			})
+
+		case http.MethodPost:
+			s.customObjectRecordUpsert(w, r, schema)
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	var match func(y *CustomObjectRecord) bool
+	switch {
+	case len(parts) == 2:
+		match = func(y *CustomObjectRecord) bool { return y.ID == parts[1] }
+	case len(parts) == 3 && parts[1] == "external":
+		match = func(y *CustomObjectRecord) bool { return y.ExternalID == parts[2] }
+	default:
+		writeNotFound(w, "Record", 0)
+		return
+	}
+
+	for n, y := range s.customObjectRecords {
+		if y.SchemaID != schema.ID || !match(y) {
+			continue
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"record": s.renderCustomObjectRecord(schema, y)})
+		case http.MethodDelete:
+			delete(s.customObjectRecords, n)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	writeNotFound(w, "Record", 0)
+}
+
+// Creates a record, or updates the record with the same ID or external ID.
+func (s *Server) customObjectRecordUpsert(w http.ResponseWriter, r *http.Request, schema *CustomObjectSchema) {
+	var req struct {
+		ID         string `json:"id"`
+		ExternalID string `json:"externalId"`
+		Fields     []struct {
+			ID    string      `json:"id"`
+			Value interface{} `json:"value"`
+		} `json:"fields"`
+		Relationships map[string][]flexID `json:"relationships"`
+	}
+	if err := decode(r, "record", &req); err != nil {
+		writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+		return
+	}
+
+	// Find the existing record.
+	var existing *CustomObjectRecord
+	for _, y := range s.customObjectRecords {
+		if y.SchemaID == schema.ID && (len(req.ID) > 0 && y.ID == req.ID || len(req.ExternalID) > 0 && y.ExternalID == req.ExternalID) {
+			existing = y
+		}
+	}
+	if existing == nil && len(req.ID) > 0 {
+		writeNotFound(w, "Record", 0)
+		return
+	}
+
+	record := CustomObjectRecord{SchemaID: schema.ID, ExternalID: req.ExternalID, Fields: map[string]interface{}{}, Relationships: map[string][]int64{}}
+	if existing != nil {
+		record = *existing
+		record.Fields = map[string]interface{}{}
+		for k, v := range existing.Fields {
+			record.Fields[k] = v
+		}
+	}
+
+	// Check and apply the values.
+	for _, y := range req.Fields {
+		var field *CustomObjectField
+		for x := range schema.Fields {
+			if schema.Fields[x].ID == y.ID {
+				field = &schema.Fields[x]
+			}
+		}
+		if field == nil {
+			writeUnprocessable(w, fmt.Sprintf("Field %s does not exist", y.ID), "invalid", "/data/attributes/fields/"+y.ID)
+			return
+		}
+		if title := customObjectValueError(field, y.Value); len(title) > 0 {
+			writeUnprocessable(w, title, "invalid", "/data/attributes/fields/"+y.ID)
+			return
+		}
+		record.Fields[y.ID] = y.Value
+	}
+	for _, f := range schema.Fields {
+		if _, ok := record.Fields[f.ID]; f.Required && !ok {
+			writeUnprocessable(w, fmt.Sprintf("Field %s is required", f.ID), "field_missing", "/data/attributes/fields/"+f.ID)
+			return
+		}
+	}
+
+	// Check and apply the relationships.
+	if len(req.Relationships) > 0 {
+		record.Relationships = map[string][]int64{}
+	}
+	for id, l := range req.Relationships {
+		var rel *CustomObjectRelationship
+		for x := range schema.Relationships {
+			if schema.Relationships[x].ID == id {
+				rel = &schema.Relationships[x]
+			}
+		}
+		if rel == nil {
+			writeUnprocessable(w, fmt.Sprintf("Relationship %s does not exist", id), "invalid", "/data/attributes/relationships/"+id)
+			return
+		}
+
+		for _, x := range l {
+			if _, ok := s.contacts[int64(x)]; rel.Namespace == "contacts" && !ok {
+				writeUnprocessable(w, fmt.Sprintf("Contact %d does not exist", x), "invalid", "/data/attributes/relationships/"+id)
+				return
+			}
+			record.Relationships[id] = append(record.Relationships[id], int64(x))
+		}
+	}
+
+	record.Updated = time.Now()
+	if existing != nil {
+		*existing = record
+		writeJSON(w, http.StatusOK, map[string]interface{}{"record": s.renderCustomObjectRecord(schema, existing)})
+		return
+	}
+
+	n := s.nextID("customObjectRecords")
+	record.ID = uuid("7e000000", n)
+	record.Created = record.Updated
+	s.customObjectRecords[n] = &record
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"record": s.renderCustomObjectRecord(schema, &record)})
+}
+
+// Returns the error title for a value that doesn't fit a field, if any.
+func customObjectValueError(f *CustomObjectField, v interface{}) string {
+	switch f.Type {
+	case "number", "currency":
+		switch x := v.(type) {
+		case float64:
+			return ""
+		case string:
+			if _, err := strconv.ParseFloat(x, 64); err == nil {
+				return ""
+			}
+		}
+		return fmt.Sprintf("Field %s must be a number", f.ID)
+
+	case "dropdown":
+		for _, o := range f.Options {
+			if o == v {
+				return ""
+			}
+		}
+		return fmt.Sprintf("Field %s must be one of its options", f.ID)
+	}
+
+	return ""
+}
@@ -0,0 +1,602 @@
+package actest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Connection is an e-commerce connection (a store) held by the server.
+type Connection struct {
+	ID         int64
+	Service    string
+	ExternalID string
+	Name       string
+	Created    time.Time
+	Updated    time.Time
+}
+
+// EcomCustomer is an e-commerce customer held by the server.
+type EcomCustomer struct {
+	ID               int64
+	ConnectionID     int64
+	ExternalID       string
+	EmailAddress     string
+	AcceptsMarketing bool
+}
+
+// EcomOrder is an e-commerce order held by the server.  Orders without an ExternalID are abandoned carts.  Amounts are
+// in cents.
+type EcomOrder struct {
+	ID                 int64
+	ConnectionID       int64
+	CustomerID         int64
+	ExternalID         string
+	ExternalCheckoutID string
+	Source             int64
+	EmailAddress       string
+	Currency           string
+	TotalPrice         int64
+	ShippingAmount     int64
+	TaxAmount          int64
+	DiscountAmount     int64
+	OrderNumber        string
+	ExternalCreated    string
+	ExternalUpdated    string
+	Abandoned          string
+	Products           []EcomOrderProduct
+	Created            time.Time
+	Updated            time.Time
+}
+
+// EcomOrderProduct is a line item of an order.  Price is the unit price in cents.
+type EcomOrderProduct struct {
+	ID         int64
+	ExternalID string
+	Name       string
+	Price      int64
+	Quantity   int64
+	Category   string
+	SKU        string
+}
+
+// AddConnection adds a connection and returns its ID.
+func (s *Server) AddConnection(c Connection) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.ID = s.nextID("connections")
+	if c.Created.IsZero() {
+		c.Created = time.Now()
+	}
+	c.Updated = c.Created
+	s.connections[c.ID] = &c
+
+	return c.ID
+}
+
+// AddEcomCustomer adds a customer and returns its ID.
+func (s *Server) AddEcomCustomer(c EcomCustomer) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.ID = s.nextID("ecomCustomers")
+	s.ecomCustomers[c.ID] = &c
+
+	return c.ID
+}
+
+// Returns the JSON representation of a connection.
+func (s *Server) renderConnection(c *Connection) map[string]interface{} {
+	return map[string]interface{}{
+		"service":    c.Service,
+		"externalid": c.ExternalID,
+		"name":       c.Name,
+		"logoUrl":    "",
+		"linkUrl":    "",
+		"status":     "1",
+		"syncStatus": "0",
+		"cdate":      timestamp(c.Created),
+		"udate":      timestamp(c.Updated),
+		"links":      map[string]interface{}{"customers": s.link("connections/%d/customers", c.ID)},
+		"id":         strconv.FormatInt(c.ID, 10),
+	}
+}
+
+// Returns the JSON representation of a customer.  The totals are calculated from completed orders.
+func (s *Server) renderEcomCustomer(c *EcomCustomer) map[string]interface{} {
+	var revenue, orders, products int64
+	for _, o := range s.ecomOrders {
+		if o.CustomerID != c.ID || len(o.ExternalID) == 0 {
+			continue
+		}
+		revenue += o.TotalPrice
+		orders++
+		for _, p := range o.Products {
+			products += p.Quantity
+		}
+	}
+
+	var avg int64
+	if orders > 0 {
+		avg = revenue / orders
+	}
+
+	return map[string]interface{}{
+		"connectionid":       strconv.FormatInt(c.ConnectionID, 10),
+		"externalid":         c.ExternalID,
+		"email":              c.EmailAddress,
+		"acceptsMarketing":   flag(c.AcceptsMarketing),
+		"totalRevenue":       strconv.FormatInt(revenue, 10),
+		"totalOrders":        strconv.FormatInt(orders, 10),
+		"totalProducts":      strconv.FormatInt(products, 10),
+		"avgRevenuePerOrder": strconv.FormatInt(avg, 10),
+		"links":              map[string]interface{}{"connection": s.link("ecomCustomers/%d/connection", c.ID), "orders": s.link("ecomCustomers/%d/orders", c.ID)},
+		"id":                 strconv.FormatInt(c.ID, 10),
+	}
+}
+
+// Returns the JSON representation of an order line item.
+func renderEcomOrderProduct(p EcomOrderProduct) map[string]interface{} {
+	return map[string]interface{}{
+		"externalid": p.ExternalID,
+		"name":       p.Name,
+		"price":      strconv.FormatInt(p.Price, 10),
+		"quantity":   strconv.FormatInt(p.Quantity, 10),
+		"category":   p.Category,
+		"sku":        p.SKU,
+		"id":         strconv.FormatInt(p.ID, 10),
+	}
+}
+
+// Returns the JSON representation of an order.  Missing dates are sent as null.
+func (s *Server) renderEcomOrder(o *EcomOrder) map[string]interface{} {
+	products := []interface{}{}
+	for _, p := range o.Products {
+		products = append(products, renderEcomOrderProduct(p))
+	}
+
+	date := func(v string) interface{} {
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	}
+
+	return map[string]interface{}{
+		"externalid":          o.ExternalID,
+		"externalcheckoutid":  o.ExternalCheckoutID,
+		"source":              strconv.FormatInt(o.Source, 10),
+		"email":               o.EmailAddress,
+		"connectionid":        strconv.FormatInt(o.ConnectionID, 10),
+		"customerid":          strconv.FormatInt(o.CustomerID, 10),
+		"orderNumber":         o.OrderNumber,
+		"currency":            o.Currency,
+		"totalPrice":          strconv.FormatInt(o.TotalPrice, 10),
+		"shippingAmount":      strconv.FormatInt(o.ShippingAmount, 10),
+		"taxAmount":           strconv.FormatInt(o.TaxAmount, 10),
+		"discountAmount":      strconv.FormatInt(o.DiscountAmount, 10),
+		"externalCreatedDate": date(o.ExternalCreated),
+		"externalUpdatedDate": date(o.ExternalUpdated),
+		"abandonedDate":       date(o.Abandoned),
+		"orderProducts":       products,
+		"cdate":               timestamp(o.Created),
+		"udate":               timestamp(o.Updated),
+		"links":               map[string]interface{}{"connection": s.link("ecomOrders/%d/connection", o.ID), "customer": s.link("ecomOrders/%d/customer", o.ID), "orderProducts": s.link("ecomOrders/%d/orderProducts", o.ID)},
+		"id":                  strconv.FormatInt(o.ID, 10),
+	}
+}
+
+// Writes a paged list of the rendered items matching the filters[*] query parameters, e.g. filters[externalid].
+func writeFilteredList(w http.ResponseWriter, r *http.Request, key string, items []map[string]interface{}) {
+	var l []map[string]interface{}
+
+	for _, m := range items {
+		match := true
+		for k, v := range r.URL.Query() {
+			if strings.HasPrefix(k, "filters[") && fmt.Sprint(m[strings.TrimSuffix(strings.TrimPrefix(k, "filters["), "]")]) != v[0] {
+				match = false
+			}
+		}
+		if match {
+			l = append(l, m)
+		}
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		key:    append([]map[string]interface{}{}, l[start:end]...),
+		"meta": map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
+
+// Handles /api/3/connections.
+func (s *Server) handleConnections(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	var req struct {
+		Service    *string `json:"service"`
+		ExternalID *string `json:"externalid"`
+		Name       *string `json:"name"`
+	}
+
+	// Applies the request to a connection and returns an error title, if any.
+	apply := func(c *Connection) string {
+		if req.Service != nil {
+			c.Service = *req.Service
+		}
+		if req.ExternalID != nil {
+			c.ExternalID = *req.ExternalID
+		}
+		if req.Name != nil {
+			c.Name = *req.Name
+		}
+
+		switch {
+		case len(c.Service) == 0 || len(c.ExternalID) == 0 || len(c.Name) == 0:
+			return "Service, external ID and name are required"
+		}
+		for _, y := range s.connections {
+			if y.ID != c.ID && y.Service == c.Service && y.ExternalID == c.ExternalID {
+				return "Connection already exists"
+			}
+		}
+
+		return ""
+	}
+
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			var l []map[string]interface{}
+			for _, id := range sortedIDs(s.ids["connections"], func(id int64) bool { _, ok := s.connections[id]; return ok }) {
+				l = append(l, s.renderConnection(s.connections[id]))
+			}
+			writeFilteredList(w, r, "connections", l)
+
+		case http.MethodPost:
+			if err := decode(r, "connection", &req); err != nil {
+				writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+				return
+			}
+
+			var c Connection
+			if title := apply(&c); len(title) > 0 {
+				writeUnprocessable(w, title, "invalid", "/data/attributes")
+				return
+			}
+			c.ID = s.nextID("connections")
+			c.Created = time.Now()
+			c.Updated = c.Created
+			s.connections[c.ID] = &c
+
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"connection": s.renderConnection(&c)})
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	c, ok := s.connections[id]
+	if !ok {
+		writeNotFound(w, "Connection", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"connection": s.renderConnection(c)})
+
+	case http.MethodPut:
+		if err := decode(r, "connection", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		updated := *c
+		if title := apply(&updated); len(title) > 0 {
+			writeUnprocessable(w, title, "invalid", "/data/attributes")
+			return
+		}
+		updated.Updated = time.Now()
+		*c = updated
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"connection": s.renderConnection(c)})
+
+	case http.MethodDelete:
+		delete(s.connections, id)
+		for x, y := range s.ecomCustomers {
+			if y.ConnectionID == id {
+				delete(s.ecomCustomers, x)
+			}
+		}
+		for x, y := range s.ecomOrders {
+			if y.ConnectionID == id {
+				delete(s.ecomOrders, x)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// Handles /api/3/ecomCustomers.
+func (s *Server) handleEcomCustomers(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	var req struct {
+		ConnectionID     *flexID `json:"connectionid"`
+		ExternalID       *string `json:"externalid"`
+		Email            *string `json:"email"`
+		AcceptsMarketing *flexID `json:"acceptsMarketing"`
+	}
+
+	// Applies the request to a customer and returns an error title, if any.
+	apply := func(c *EcomCustomer) string {
+		if req.ConnectionID != nil {
+			c.ConnectionID = int64(*req.ConnectionID)
+		}
+		if req.ExternalID != nil {
+			c.ExternalID = *req.ExternalID
+		}
+		if req.Email != nil {
+			c.EmailAddress = *req.Email
+		}
+		if req.AcceptsMarketing != nil {
+			c.AcceptsMarketing = *req.AcceptsMarketing != 0
+		}
+
+		if _, ok := s.connections[c.ConnectionID]; !ok {
+			return "Connection does not exist"
+		}
+		if len(c.ExternalID) == 0 || len(c.EmailAddress) == 0 {
+			return "External ID and email are required"
+		}
+		for _, y := range s.ecomCustomers {
+			if y.ID != c.ID && y.ConnectionID == c.ConnectionID && y.ExternalID == c.ExternalID {
+				return "Customer already exists"
+			}
+		}
+
+		return ""
+	}
+
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			var l []map[string]interface{}
+			for _, id := range sortedIDs(s.ids["ecomCustomers"], func(id int64) bool { _, ok := s.ecomCustomers[id]; return ok }) {
+				l = append(l, s.renderEcomCustomer(s.ecomCustomers[id]))
+			}
+			writeFilteredList(w, r, "ecomCustomers", l)
+
+		case http.MethodPost:
+			if err := decode(r, "ecomCustomer", &req); err != nil {
+				writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+				return
+			}
+
+			var c EcomCustomer
+			if title := apply(&c); len(title) > 0 {
+				writeUnprocessable(w, title, "invalid", "/data/attributes")
+				return
+			}
+			c.ID = s.nextID("ecomCustomers")
+			s.ecomCustomers[c.ID] = &c
+
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"ecomCustomer": s.renderEcomCustomer(&c)})
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	c, ok := s.ecomCustomers[id]
+	if !ok {
+		writeNotFound(w, "EcomCustomer", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ecomCustomer": s.renderEcomCustomer(c)})
+
+	case http.MethodPut:
+		if err := decode(r, "ecomCustomer", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		updated := *c
+		if title := apply(&updated); len(title) > 0 {
+			writeUnprocessable(w, title, "invalid", "/data/attributes")
+			return
+		}
+		*c = updated
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ecomCustomer": s.renderEcomCustomer(c)})
+
+	case http.MethodDelete:
+		delete(s.ecomCustomers, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// Handles /api/3/ecomOrders and /api/3/ecomOrders/{id}/orderProducts.
+func (s *Server) handleEcomOrders(w http.ResponseWriter, r *http.Request, id int64, hasID bool, sub string) {
+	var req struct {
+		ExternalID         *string `json:"externalid"`
+		ExternalCheckoutID *string `json:"externalcheckoutid"`
+		Source             *flexID `json:"source"`
+		Email              *string `json:"email"`
+		ConnectionID       *flexID `json:"connectionid"`
+		CustomerID         *flexID `json:"customerid"`
+		OrderNumber        *string `json:"orderNumber"`
+		Currency           *string `json:"currency"`
+		TotalPrice         *flexID `json:"totalPrice"`
+		ShippingAmount     *flexID `json:"shippingAmount"`
+		TaxAmount          *flexID `json:"taxAmount"`
+		DiscountAmount     *flexID `json:"discountAmount"`
+		ExternalCreated    *string `json:"externalCreatedDate"`
+		ExternalUpdated    *string `json:"externalUpdatedDate"`
+		Abandoned          *string `json:"abandonedDate"`
+		Products           []struct {
+			ExternalID string `json:"externalid"`
+			Name       string `json:"name"`
+			Price      flexID `json:"price"`
+			Quantity   flexID `json:"quantity"`
+			Category   string `json:"category"`
+			SKU        string `json:"sku"`
+		} `json:"orderProducts"`
+	}
+
+	// Applies the request to an order and returns an error title, if any.
+	apply := func(o *EcomOrder) string {
+		for _, y := range []struct {
+			from *string
+			to   *string
+		}{
+			{req.ExternalID, &o.ExternalID}, {req.ExternalCheckoutID, &o.ExternalCheckoutID}, {req.Email, &o.EmailAddress},
+			{req.OrderNumber, &o.OrderNumber}, {req.Currency, &o.Currency}, {req.ExternalCreated, &o.ExternalCreated},
+			{req.ExternalUpdated, &o.ExternalUpdated}, {req.Abandoned, &o.Abandoned},
+		} {
+			if y.from != nil {
+				*y.to = *y.from
+			}
+		}
+		for _, y := range []struct {
+			from *flexID
+			to   *int64
+		}{
+			{req.Source, &o.Source}, {req.ConnectionID, &o.ConnectionID}, {req.CustomerID, &o.CustomerID},
+			{req.TotalPrice, &o.TotalPrice}, {req.ShippingAmount, &o.ShippingAmount}, {req.TaxAmount, &o.TaxAmount},
+			{req.DiscountAmount, &o.DiscountAmount},
+		} {
+			if y.from != nil {
+				*y.to = int64(*y.from)
+			}
+		}
+		if len(req.Products) > 0 {
+			o.Products = nil
+			for _, p := range req.Products {
+				if len(p.Name) == 0 || p.Quantity <= 0 || p.Price < 0 {
+					return "Order products need a name, a price and a quantity"
+				}
+				o.Products = append(o.Products, EcomOrderProduct{
+					ID: s.nextID("ecomOrderProducts"), ExternalID: p.ExternalID, Name: p.Name, Price: int64(p.Price),
+					Quantity: int64(p.Quantity), Category: p.Category, SKU: p.SKU,
+				})
+			}
+		}
+
+		if _, ok := s.connections[o.ConnectionID]; !ok {
+			return "Connection does not exist"
+		}
+		if c, ok := s.ecomCustomers[o.CustomerID]; !ok || c.ConnectionID != o.ConnectionID {
+			return "Customer does not exist"
+		}
+		switch {
+		case len(o.EmailAddress) == 0:
+			return "Email is required"
+		case len(o.Currency) != 3:
+			return "Currency must be a three letter code"
+		case o.TotalPrice < 0:
+			return "Total price must not be negative"
+		case len(o.ExternalID) == 0 && (len(o.ExternalCheckoutID) == 0 || len(o.Abandoned) == 0):
+			return "Orders need an external ID, abandoned carts an external checkout ID and an abandoned date"
+		case len(o.ExternalCreated) == 0:
+			return "External created date is required"
+		}
+		for _, y := range s.ecomOrders {
+			if y.ID != o.ID && y.ConnectionID == o.ConnectionID && len(o.ExternalID) > 0 && y.ExternalID == o.ExternalID {
+				return "Order already exists"
+			}
+		}
+
+		return ""
+	}
+
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			var l []map[string]interface{}
+			for _, id := range sortedIDs(s.ids["ecomOrders"], func(id int64) bool { _, ok := s.ecomOrders[id]; return ok }) {
+				l = append(l, s.renderEcomOrder(s.ecomOrders[id]))
+			}
+			writeFilteredList(w, r, "ecomOrders", l)
+
+		case http.MethodPost:
+			if err := decode(r, "ecomOrder", &req); err != nil {
+				writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+				return
+			}
+
+			var o EcomOrder
+			if title := apply(&o); len(title) > 0 {
+				writeUnprocessable(w, title, "invalid", "/data/attributes")
+				return
+			}
+			o.ID = s.nextID("ecomOrders")
+			o.Created = time.Now()
+			o.Updated = o.Created
+			s.ecomOrders[o.ID] = &o
+
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"ecomOrder": s.renderEcomOrder(&o)})
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	o, ok := s.ecomOrders[id]
+	if !ok {
+		writeNotFound(w, "EcomOrder", id)
+		return
+	}
+
+	switch {
+	case sub == "orderProducts" && r.Method == http.MethodGet:
+		l := []interface{}{}
+		for _, p := range o.Products {
+			l = append(l, renderEcomOrderProduct(p))
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ecomOrderProducts": l, "meta": map[string]interface{}{"total": strconv.Itoa(len(l))}})
+
+	case len(sub) > 0:
+		writeNotFound(w, sub, 0)
+
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ecomOrder": s.renderEcomOrder(o)})
+
+	case r.Method == http.MethodPut:
+		if err := decode(r, "ecomOrder", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		updated := *o
+		if title := apply(&updated); len(title) > 0 {
+			writeUnprocessable(w, title, "invalid", "/data/attributes")
+			return
+		}
+		updated.Updated = time.Now()
+		*o = updated
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ecomOrder": s.renderEcomOrder(o)})
+
+	case r.Method == http.MethodDelete:
+		delete(s.ecomOrders, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
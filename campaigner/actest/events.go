@@ -0,0 +1,179 @@
+package actest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TRACKING_ACCOUNT_ID and TRACKING_KEY are the event tracking credentials accepted by a new server.
+const (
+	TRACKING_ACCOUNT_ID = "1000"
+	TRACKING_KEY        = "actest-event-key"
+)
+
+// TrackedEvent is an event received on the event tracking endpoint.
+type TrackedEvent struct {
+	Name  string
+	Email string
+	Data  string
+	Time  time.Time
+}
+
+// EnableEventTracking enables or disables event tracking.  It is disabled on a new server.
+func (s *Server) EnableEventTracking(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.eventTracking = enabled
+}
+
+// Events returns the events received on the event tracking endpoint, in the order they were received.
+func (s *Server) Events() []TrackedEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]TrackedEvent{}, s.events...)
+}
+
+// Returns whether an event name is registered.  Must be called with the lock held.
+func (s *Server) hasEventName(name string) bool {
+	for _, n := range s.eventNames {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Handles the event tracking endpoint.  Like trackcmp.net it answers 200 with a success flag, unknown event names are
+// registered on first use.
+func (s *Server) trackEvent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Writes the response.
+	result := func(success bool, message string) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"success": map[bool]int{true: 1, false: 0}[success], "message": message})
+	}
+
+	if err := r.ParseForm(); err != nil {
+		result(false, "Invalid request")
+		return
+	}
+
+	var visit struct {
+		Email string `json:"email"`
+	}
+	_ = json.Unmarshal([]byte(r.PostForm.Get("visit")), &visit)
+
+	e := TrackedEvent{Name: r.PostForm.Get("event"), Email: visit.Email, Data: r.PostForm.Get("eventdata"), Time: time.Now()}
+
+	switch {
+	case r.PostForm.Get("actid") != s.TrackingAccountID || r.PostForm.Get("key") != s.TrackingKey:
+		result(false, "Invalid key")
+	case !s.eventTracking:
+		result(false, "Event tracking is disabled")
+	case len(e.Name) == 0:
+		result(false, "No event name provided")
+	case len(e.Email) == 0:
+		result(false, "No visitor email provided")
+	default:
+		if !s.hasEventName(e.Name) {
+			s.eventNames = append(s.eventNames, e.Name)
+		}
+		s.events = append(s.events, e)
+		result(true, "Event spawned")
+	}
+}
+
+// Handles /eventTracking.
+func (s *Server) handleEventTracking(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		// Nothing to change.
+
+	case http.MethodPut:
+		var req struct {
+			Enabled *bool `json:"enabled"`
+		}
+		if err := decode(r, "eventTracking", &req); err != nil || req.Enabled == nil {
+			writeUnprocessable(w, "Enabled is required", "field_missing", "/data/attributes/enabled")
+			return
+		}
+		s.eventTracking = *req.Enabled
+
+	default:
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"eventTracking": map[string]interface{}{"enabled": s.eventTracking}})
+}
+
+// Handles /eventTrackingEvents, events are addressed by name.
+func (s *Server) handleEventTrackingEvents(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			l := []map[string]interface{}{}
+			for _, n := range s.eventNames {
+				l = append(l, map[string]interface{}{"name": n})
+			}
+
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"eventTrackingEvents": l,
+				"meta":                map[string]interface{}{"total": strconv.Itoa(len(l))},
+			})
+
+		case http.MethodPost:
+			var req struct {
+				Name string `json:"name"`
+			}
+			if err := decode(r, "eventTrackingEvent", &req); err != nil || len(strings.TrimSpace(req.Name)) == 0 {
+				writeUnprocessable(w, "Event name is required", "field_missing", "/data/attributes/name")
+				return
+			}
+			if s.hasEventName(req.Name) {
+				writeUnprocessable(w, "Event name already exists", "duplicate", "/data/attributes/name")
+				return
+			}
+
+			s.eventNames = append(s.eventNames, req.Name)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"eventTrackingEvent": map[string]interface{}{"name": req.Name}})
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	// The path is unescaped already.
+	name := parts[0]
+	if len(parts) > 1 || !s.hasEventName(name) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "No Result found for EventTrackingEvent with name " + name})
+		return
+	}
+
+	if r.Method != http.MethodDelete {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	for x, n := range s.eventNames {
+		if n == name {
+			s.eventNames = append(s.eventNames[:x], s.eventNames[x+1:]...)
+			break
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
@@ -0,0 +1,249 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is a custom contact field held by the server.
+type Field struct {
+	ID           int64
+	Title        string
+	Type         string
+	Description  string
+	PersTag      string
+	DefaultValue string
+	Created      time.Time
+}
+
+// FieldValue is a contact's value for a custom field.
+type FieldValue struct {
+	ID        int64
+	ContactID int64
+	FieldID   int64
+	Value     string
+	Created   time.Time
+	Updated   time.Time
+}
+
+// AddField adds a custom field and returns its ID.  The type defaults to "text".
+func (s *Server) AddField(f Field) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f.ID = s.nextID("fields")
+	if len(f.Type) == 0 {
+		f.Type = "text"
+	}
+	if len(f.PersTag) == 0 {
+		f.PersTag = strings.ToUpper(strings.Replace(f.Title, " ", "_", -1))
+	}
+	if f.Created.IsZero() {
+		f.Created = time.Now()
+	}
+	s.fields[f.ID] = &f
+
+	return f.ID
+}
+
+// AddFieldValue sets a contact's value for a custom field and returns the ID of the value.
+func (s *Server) AddFieldValue(contactID int64, fieldID int64, value string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fv, _ := s.setFieldValue(contactID, fieldID, value)
+
+	return fv.ID
+}
+
+// FieldValue returns a contact's value for a custom field.
+func (s *Server) FieldValue(contactID int64, fieldID int64) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, fv := range s.fieldValues {
+		if fv.ContactID == contactID && fv.FieldID == fieldID {
+			return fv.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Sets a field value.  Returns the value and whether it was created.  Must be called with the lock held.
+func (s *Server) setFieldValue(contactID int64, fieldID int64, value string) (*FieldValue, bool) {
+	for _, fv := range s.fieldValues {
+		if fv.ContactID == contactID && fv.FieldID == fieldID {
+			fv.Value = value
+			fv.Updated = time.Now()
+			return fv, false
+		}
+	}
+
+	now := time.Now()
+	fv := &FieldValue{ID: s.nextID("fieldValues"), ContactID: contactID, FieldID: fieldID, Value: value, Created: now, Updated: now}
+	s.fieldValues[fv.ID] = fv
+
+	return fv, true
+}
+
+// Returns the JSON representation of a field.
+func (s *Server) renderField(f *Field) map[string]interface{} {
+	return map[string]interface{}{
+		"title":        f.Title,
+		"descript":     f.Description,
+		"type":         f.Type,
+		"isrequired":   "0",
+		"perstag":      f.PersTag,
+		"defval":       f.DefaultValue,
+		"show_in_list": "0",
+		"rows":         "0",
+		"cols":         "0",
+		"visible":      "1",
+		"service":      "",
+		"ordernum":     strconv.FormatInt(f.ID, 10),
+		"cdate":        timestamp(f.Created),
+		"udate":        timestamp(f.Created),
+		"options":      []interface{}{},
+		"relations":    []string{strconv.FormatInt(f.ID, 10)},
+		"links": map[string]interface{}{
+			"options":   s.link("fields/%d/options", f.ID),
+			"relations": s.link("fields/%d/relations", f.ID),
+		},
+		"id": strconv.FormatInt(f.ID, 10),
+	}
+}
+
+// Returns the JSON representation of a field relationship.  Every field is related to all lists (relid 0).
+func (s *Server) renderFieldRel(f *Field) map[string]interface{} {
+	return map[string]interface{}{
+		"field":  strconv.FormatInt(f.ID, 10),
+		"relid":  "0",
+		"dorder": "0",
+		"cdate":  timestamp(f.Created),
+		"links":  []interface{}{},
+		"id":     strconv.FormatInt(f.ID, 10),
+	}
+}
+
+// Returns the JSON representation of a field value.
+func (s *Server) renderFieldValue(fv *FieldValue) map[string]interface{} {
+	return map[string]interface{}{
+		"contact": strconv.FormatInt(fv.ContactID, 10),
+		"field":   strconv.FormatInt(fv.FieldID, 10),
+		"value":   fv.Value,
+		"cdate":   timestamp(fv.Created),
+		"udate":   timestamp(fv.Updated),
+		"links": map[string]interface{}{
+			"owner": s.link("fieldValues/%d/owner", fv.ID),
+			"field": s.link("fieldValues/%d/field", fv.ID),
+		},
+		"id":    strconv.FormatInt(fv.ID, 10),
+		"owner": strconv.FormatInt(fv.ContactID, 10),
+	}
+}
+
+// Returns the field values of a contact.
+func (s *Server) fieldValuesByContact(contactID int64) []map[string]interface{} {
+	l := []map[string]interface{}{}
+
+	for _, id := range sortedIDs(s.ids["fieldValues"], func(id int64) bool { fv, ok := s.fieldValues[id]; return ok && fv.ContactID == contactID }) {
+		l = append(l, s.renderFieldValue(s.fieldValues[id]))
+	}
+
+	return l
+}
+
+// Handles /api/3/fields.
+func (s *Server) handleFields(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if hasID {
+		f, ok := s.fields[id]
+		if !ok {
+			writeNotFound(w, "Field", id)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"fieldOptions": []interface{}{},
+			"fieldRels":    []interface{}{s.renderFieldRel(f)},
+			"field":        s.renderField(f),
+		})
+		return
+	}
+
+	var fields, rels []map[string]interface{}
+	for _, id := range sortedIDs(s.ids["fields"], func(id int64) bool { _, ok := s.fields[id]; return ok }) {
+		fields = append(fields, s.renderField(s.fields[id]))
+		rels = append(rels, s.renderFieldRel(s.fields[id]))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(fields), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"fieldOptions": []interface{}{},
+		"fieldRels":    append([]map[string]interface{}{}, rels[start:end]...),
+		"fields":       append([]map[string]interface{}{}, fields[start:end]...),
+		"meta":         map[string]interface{}{"total": strconv.Itoa(len(fields))},
+	})
+}
+
+// Handles /api/3/fieldValues.  Setting a value returns 201 for a new value and 200 for an update.
+func (s *Server) handleFieldValues(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	switch {
+	case !hasID && r.Method == http.MethodPost:
+		var req struct {
+			ContactID flexID `json:"contact"`
+			FieldID   flexID `json:"field"`
+			Value     string `json:"value"`
+		}
+		if err := decode(r, "fieldValue", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		c, ok := s.contacts[int64(req.ContactID)]
+		if !ok {
+			writeUnprocessable(w, "Contact does not exist", "related_missing", "/data/attributes/contact")
+			return
+		}
+		if _, ok := s.fields[int64(req.FieldID)]; !ok {
+			writeUnprocessable(w, "Field does not exist", "related_missing", "/data/attributes/field")
+			return
+		}
+
+		fv, created := s.setFieldValue(c.ID, int64(req.FieldID), req.Value)
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+
+		writeJSON(w, status, map[string]interface{}{"contacts": []interface{}{s.renderContact(c)}, "fieldValue": s.renderFieldValue(fv)})
+
+	case hasID && r.Method == http.MethodGet:
+		fv, ok := s.fieldValues[id]
+		if !ok {
+			writeNotFound(w, "FieldValue", id)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"fieldValue": s.renderFieldValue(fv)})
+
+	case hasID && r.Method == http.MethodDelete:
+		if _, ok := s.fieldValues[id]; !ok {
+			writeNotFound(w, "FieldValue", id)
+			return
+		}
+		delete(s.fieldValues, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
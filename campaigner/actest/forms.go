@@ -0,0 +1,210 @@
+package actest
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Form is a form held by the server.  Contacts submitted through a form are subscribed to its lists, or left
+// unconfirmed (status 0) until they confirm if the form uses double opt-in.  Key is the u input of the form's embed
+// code, submissions with another u are rejected.  It is generated if empty.
+type Form struct {
+	ID          int64
+	Name        string
+	ListIDs     []int64
+	DoubleOptIn bool
+	Key         string
+	Created     time.Time
+}
+
+// The embed code of a form, like the one the form builder generates.
+const formEmbed = `<form method="POST" action="%s" id="_form_%d_" class="_form _form_%d _inline-form" novalidate>
+  <input type="hidden" name="u" value="%s" />
+  <input type="hidden" name="f" value="%d" />
+  <input type="hidden" name="s" />
+  <input type="hidden" name="c" value="0" />
+  <input type="hidden" name="m" value="0" />
+  <input type="hidden" name="act" value="sub" />
+  <input type="hidden" name="v" value="2" />
+  <input type="text" name="email" placeholder="Type your email" required/>
+  <button id="_form_%d_submit" type="submit">Submit</button>
+</form>`
+
+// The custom field inputs of a submitted form, e.g. field[12].
+var formField = regexp.MustCompile(`^field\[(\d+)\]$`)
+
+// AddForm adds a form and returns its ID.
+func (s *Server) AddForm(f Form) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f.ID = s.nextID("forms")
+	if len(f.Key) == 0 {
+		f.Key = fmt.Sprintf("%X", 0xAC0000+f.ID)
+	}
+	if f.Created.IsZero() {
+		f.Created = time.Now()
+	}
+
+	s.forms[f.ID] = &f
+
+	return f.ID
+}
+
+// Returns the JSON representation of a form.
+func (s *Server) renderForm(f *Form) map[string]interface{} {
+	actions := []interface{}{}
+	for _, id := range f.ListIDs {
+		actions = append(actions, map[string]interface{}{"type": "subscribe-to-list", "list": strconv.FormatInt(id, 10), "email": ""})
+	}
+
+	optIn := "0"
+	if f.DoubleOptIn {
+		optIn = strconv.FormatInt(f.ID, 10)
+	}
+
+	return map[string]interface{}{
+		"id":         strconv.FormatInt(f.ID, 10),
+		"name":       f.Name,
+		"action":     "",
+		"actiondata": map[string]interface{}{"actions": actions},
+		"url":        "",
+		"embed":      fmt.Sprintf(formEmbed, html.EscapeString(s.URL+"/proc.php"), f.ID, f.ID, html.EscapeString(f.Key), f.ID, f.ID),
+		"optin_id":   optIn,
+		"cdate":      timestamp(f.Created),
+		"udate":      timestamp(f.Created),
+		"links":      map[string]interface{}{"address": s.link("forms/%d/address", f.ID)},
+	}
+}
+
+// Handles /api/3/forms.  Forms are read only.
+func (s *Server) handleForms(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if hasID {
+		f, ok := s.forms[id]
+		if !ok {
+			writeNotFound(w, "Form", id)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"form": s.renderForm(f)})
+		return
+	}
+
+	var l []map[string]interface{}
+	for _, id := range sortedIDs(s.ids["forms"], func(id int64) bool { _, ok := s.forms[id]; return ok }) {
+		l = append(l, s.renderForm(s.forms[id]))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"forms": append([]map[string]interface{}{}, l[start:end]...),
+		"meta":  map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
+
+// Handles form submissions (proc.php).  Like the real form handler it answers 200 with a script that calls
+// _show_thank_you or _show_error.
+func (s *Server) submitForm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Writes the response.
+	result := func(success bool, message string) {
+		callback := "_show_error"
+		if success {
+			callback = "_show_thank_you"
+		}
+
+		w.Header().Set("Content-Type", "application/javascript")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "%s(%s, %s, '');", callback, jsString(r.PostForm.Get("f")), jsString(message))
+	}
+
+	if err := r.ParseForm(); err != nil {
+		result(false, "Invalid request")
+		return
+	}
+
+	id, _ := strconv.ParseInt(r.PostForm.Get("f"), 10, 64)
+	f, ok := s.forms[id]
+	if !ok || r.PostForm.Get("u") != f.Key || r.PostForm.Get("act") != "sub" {
+		result(false, "This form does not exist")
+		return
+	}
+
+	email := r.PostForm.Get("email")
+	c := s.contactByEmail(email)
+	if title, _ := s.contactEmailError(email, c); len(title) > 0 {
+		result(false, title)
+		return
+	}
+	if c == nil {
+		c = s.addContact(Contact{EmailAddress: email})
+	}
+
+	// Fields left empty in the form don't clear the contact's values.
+	for k, v := range map[string]*string{"firstname": &c.FirstName, "lastname": &c.LastName, "phone": &c.PhoneNumber} {
+		if value := r.PostForm.Get(k); len(value) > 0 {
+			*v = value
+		}
+	}
+	for k := range r.PostForm {
+		if m := formField.FindStringSubmatch(k); m != nil {
+			fieldID, _ := strconv.ParseInt(m[1], 10, 64)
+			if _, ok := s.fields[fieldID]; ok {
+				s.setFieldValue(c.ID, fieldID, r.PostForm.Get(k))
+			}
+		}
+	}
+	c.Updated = time.Now()
+
+	status := 1
+	if f.DoubleOptIn {
+		status = 0
+	}
+	for _, listID := range f.ListIDs {
+		var cl *ContactList
+		for _, x := range s.contactLists {
+			if x.ContactID == c.ID && x.ListID == listID {
+				cl = x
+			}
+		}
+		if cl == nil {
+			cl = &ContactList{ID: s.nextID("contactLists"), ContactID: c.ID, ListID: listID, Subscribed: time.Now()}
+			s.contactLists[cl.ID] = cl
+		}
+		if cl.Status != 1 {
+			cl.Status = status
+		}
+		cl.FormID = f.ID
+	}
+
+	if f.DoubleOptIn {
+		result(true, "Thank you for subscribing, please check your email to confirm")
+		return
+	}
+
+	result(true, "Thank you for subscribing")
+}
+
+// Returns a single quoted JavaScript string literal.
+func jsString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`).Replace(s) + "'"
+}
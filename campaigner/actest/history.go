@@ -0,0 +1,205 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BounceLog is a bounce held by the server.  Code is the SMTP status code, 5.x.x codes are hard bounces.
+type BounceLog struct {
+	ID         int64
+	ContactID  int64
+	CampaignID int64
+	MessageID  int64
+	Code       string
+	Error      string
+	Time       time.Time
+}
+
+// EmailActivity is a campaign email sent to, opened or clicked by a contact.  Type is "send", "open" or "click", Link
+// is the clicked URL.
+type EmailActivity struct {
+	ID         int64
+	ContactID  int64
+	CampaignID int64
+	MessageID  int64
+	Type       string
+	Link       string
+	Time       time.Time
+}
+
+// AddBounceLog adds a bounce and counts it on the contact.  Returns the ID of the bounce.
+func (s *Server) AddBounceLog(b BounceLog) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b.ID = s.nextID("bounceLogs")
+	if b.Time.IsZero() {
+		b.Time = time.Now()
+	}
+	s.bounceLogs[b.ID] = &b
+
+	if c, ok := s.contacts[b.ContactID]; ok {
+		if strings.HasPrefix(b.Code, "5") {
+			c.BouncedHard++
+		} else {
+			c.BouncedSoft++
+		}
+		c.Bounced = b.Time
+	}
+
+	return b.ID
+}
+
+// AddEmailActivity adds an email activity and returns its ID.
+func (s *Server) AddEmailActivity(a EmailActivity) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a.ID = s.nextID("emailActivities")
+	if a.Time.IsZero() {
+		a.Time = time.Now()
+	}
+	s.emailActivities[a.ID] = &a
+
+	return a.ID
+}
+
+// Returns the JSON representation of a bounce log.
+func (s *Server) renderBounceLog(b *BounceLog) map[string]interface{} {
+	var email string
+	if c, ok := s.contacts[b.ContactID]; ok {
+		email = c.EmailAddress
+	}
+
+	return map[string]interface{}{
+		"id":       strconv.FormatInt(b.ID, 10),
+		"contact":  strconv.FormatInt(b.ContactID, 10),
+		"campaign": strconv.FormatInt(b.CampaignID, 10),
+		"message":  strconv.FormatInt(b.MessageID, 10),
+		"email":    email,
+		"code":     b.Code,
+		"error":    b.Error,
+		"tstamp":   timestamp(b.Time),
+		"links":    map[string]interface{}{"contact": s.link("contacts/%d", b.ContactID)},
+	}
+}
+
+// Returns the JSON representation of an email activity.
+func (s *Server) renderEmailActivity(a *EmailActivity) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           strconv.FormatInt(a.ID, 10),
+		"subscriberid": strconv.FormatInt(a.ContactID, 10),
+		"campaignid":   strconv.FormatInt(a.CampaignID, 10),
+		"messageid":    strconv.FormatInt(a.MessageID, 10),
+		"type":         a.Type,
+		"link":         a.Link,
+		"tstamp":       timestamp(a.Time),
+		"links":        map[string]interface{}{"contact": s.link("contacts/%d", a.ContactID)},
+	}
+}
+
+// Returns the bounce logs of a contact.  Must be called with the lock held.
+func (s *Server) bounceLogsByContact(contactID int64) []map[string]interface{} {
+	l := []map[string]interface{}{}
+
+	for _, id := range sortedIDs(s.ids["bounceLogs"], func(id int64) bool { b, ok := s.bounceLogs[id]; return ok && b.ContactID == contactID }) {
+		l = append(l, s.renderBounceLog(s.bounceLogs[id]))
+	}
+
+	return l
+}
+
+// Handles /api/3/emailActivities, optionally filtered by contact (filters[subscriberid]).  Activities are ordered by
+// ID, not by time.
+func (s *Server) handleEmailActivities(w http.ResponseWriter, r *http.Request, hasID bool) {
+	if hasID || r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	contactID, _ := strconv.ParseInt(r.URL.Query().Get("filters[subscriberid]"), 10, 64)
+
+	var l []map[string]interface{}
+	for _, id := range sortedIDs(s.ids["emailActivities"], func(id int64) bool { _, ok := s.emailActivities[id]; return ok }) {
+		a := s.emailActivities[id]
+		if contactID > 0 && a.ContactID != contactID {
+			continue
+		}
+		l = append(l, s.renderEmailActivity(a))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"emailActivities": append([]map[string]interface{}{}, l[start:end]...),
+		"meta":            map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
+
+// Handles /api/3/activities.  The feed of a contact is built from its bounces, email activities, tracked page visits
+// and list subscriptions, grouped by kind rather than ordered by time, and paged with limit and offset.
+func (s *Server) handleActivities(w http.ResponseWriter, r *http.Request, hasID bool) {
+	if hasID || r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	contactID, _ := strconv.ParseInt(r.URL.Query().Get("contact"), 10, 64)
+	if _, ok := s.contacts[contactID]; !ok {
+		writeNotFound(w, "Subscriber", contactID)
+		return
+	}
+
+	var (
+		l   = []map[string]interface{}{}
+		add = func(kind string, referenceID int64, description string, t time.Time) {
+			l = append(l, map[string]interface{}{
+				"contact":        strconv.FormatInt(contactID, 10),
+				"reference_type": kind,
+				"reference_id":   strconv.FormatInt(referenceID, 10),
+				"description":    description,
+				"tstamp":         timestamp(t),
+			})
+		}
+	)
+
+	for _, id := range sortedIDs(s.ids["bounceLogs"], func(id int64) bool { b, ok := s.bounceLogs[id]; return ok && b.ContactID == contactID }) {
+		b := s.bounceLogs[id]
+		add("bounce", b.CampaignID, b.Error, b.Time)
+	}
+	for _, id := range sortedIDs(s.ids["emailActivities"], func(id int64) bool { a, ok := s.emailActivities[id]; return ok && a.ContactID == contactID }) {
+		a := s.emailActivities[id]
+		add(a.Type, a.CampaignID, a.Link, a.Time)
+	}
+	for _, id := range sortedIDs(s.ids["trackingLogs"], func(id int64) bool { t, ok := s.trackingLogs[id]; return ok && t.ContactID == contactID }) {
+		t := s.trackingLogs[id]
+		add("page_visit", t.ID, t.URL, t.Time)
+	}
+	for _, id := range sortedIDs(s.ids["contactLists"], func(id int64) bool { cl, ok := s.contactLists[id]; return ok && cl.ContactID == contactID }) {
+		cl := s.contactLists[id]
+
+		var name string
+		if list, ok := s.lists[cl.ListID]; ok {
+			name = list.Name
+		}
+
+		switch cl.Status {
+		case 1:
+			add("subscribe", cl.ListID, name, cl.Subscribed)
+		case 2:
+			add("unsubscribe", cl.ListID, name, cl.Subscribed)
+		}
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"activities": l[start:end],
+		"meta":       map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
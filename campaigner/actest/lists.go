@@ -0,0 +1,234 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// List is a contact list held by the server.
+type List struct {
+	ID       int64
+	Name     string
+	StringID string
+	Created  time.Time
+}
+
+// ContactList is a contact's list membership.  Status is 1 for subscribed and 2 for unsubscribed (0 for unconfirmed
+// while a double opt-in is pending).  FormID is the form the contact subscribed through, if any.
+type ContactList struct {
+	ID         int64
+	ContactID  int64
+	ListID     int64
+	Status     int
+	FormID     int64
+	Subscribed time.Time
+}
+
+// AddList adds a list and returns its ID.
+func (s *Server) AddList(l List) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l.ID = s.nextID("lists")
+	if len(l.StringID) == 0 {
+		l.StringID = strings.ToLower(strings.Replace(l.Name, " ", "-", -1))
+	}
+	if l.Created.IsZero() {
+		l.Created = time.Now()
+	}
+	s.lists[l.ID] = &l
+
+	return l.ID
+}
+
+// ContactLists returns the list memberships of a contact.
+func (s *Server) ContactLists(contactID int64) []ContactList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var l []ContactList
+	for _, id := range sortedIDs(s.ids["contactLists"], func(id int64) bool { cl, ok := s.contactLists[id]; return ok && cl.ContactID == contactID }) {
+		l = append(l, *s.contactLists[id])
+	}
+
+	return l
+}
+
+// Returns the JSON representation of a list.
+func (s *Server) renderList(l *List) map[string]interface{} {
+	m := map[string]interface{}{
+		"id":          strconv.FormatInt(l.ID, 10),
+		"name":        l.Name,
+		"stringid":    l.StringID,
+		"cdate":       timestamp(l.Created),
+		"udate":       nil,
+		"deletestamp": nil,
+		"private":     "0",
+		"user":        "1",
+		"userid":      "1",
+		"links": map[string]interface{}{
+			"addressLists":     s.link("lists/%d/addressLists", l.ID),
+			"contactGoalLists": s.link("lists/%d/contactGoalLists", l.ID),
+			"user":             s.link("lists/%d/user", l.ID),
+		},
+	}
+
+	for _, k := range []string{"analytics_source", "analytics_ua", "fulladdress", "get_unsubscribe_reason", "optinmessageid", "optinoptout", "optoutconf", "p_embed_image", "p_use_analytics_link", "p_use_analytics_read", "p_use_captcha", "p_use_facebook", "p_use_tracking", "p_use_twitter", "require_name", "send_last_broadcast", "sender_addr1", "sender_addr2", "sender_city", "sender_country", "sender_name", "sender_phone", "sender_reminder", "sender_state", "sender_url", "sender_zip", "to_name", "twitter_token", "twitter_token_secret"} {
+		m[k] = ""
+	}
+
+	return m
+}
+
+// Returns the JSON representation of a list membership.
+func (s *Server) renderContactList(cl *ContactList) map[string]interface{} {
+	links := map[string]interface{}{}
+	for _, l := range []string{"automation", "list", "contact", "form", "autosyncLog", "campaign", "unsubscribeAutomation", "message"} {
+		links[l] = s.link("contactLists/%d/%s", cl.ID, l)
+	}
+
+	var first, last string
+	if c, ok := s.contacts[cl.ContactID]; ok {
+		first, last = c.FirstName, c.LastName
+	}
+
+	var form interface{}
+	if cl.FormID > 0 {
+		form = strconv.FormatInt(cl.FormID, 10)
+	}
+
+	return map[string]interface{}{
+		"contact":               strconv.FormatInt(cl.ContactID, 10),
+		"list":                  strconv.FormatInt(cl.ListID, 10),
+		"form":                  form,
+		"seriesid":              "0",
+		"sdate":                 timestamp(cl.Subscribed),
+		"udate":                 nil,
+		"status":                strconv.Itoa(cl.Status),
+		"responder":             "1",
+		"sync":                  "0",
+		"unsubreason":           nil,
+		"campaign":              nil,
+		"message":               nil,
+		"first_name":            first,
+		"last_name":             last,
+		"ip4Sub":                "0",
+		"sourceid":              "0",
+		"autosyncLog":           nil,
+		"ip4_last":              "0",
+		"ip4Unsub":              "0",
+		"unsubscribeAutomation": nil,
+		"links":                 links,
+		"id":                    strconv.FormatInt(cl.ID, 10),
+		"automation":            nil,
+	}
+}
+
+// Returns the list memberships of a contact.
+func (s *Server) contactListsByContact(contactID int64) []map[string]interface{} {
+	l := []map[string]interface{}{}
+
+	for _, id := range sortedIDs(s.ids["contactLists"], func(id int64) bool { cl, ok := s.contactLists[id]; return ok && cl.ContactID == contactID }) {
+		l = append(l, s.renderContactList(s.contactLists[id]))
+	}
+
+	return l
+}
+
+// Handles /api/3/lists.
+func (s *Server) handleLists(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if hasID {
+		l, ok := s.lists[id]
+		if !ok {
+			writeNotFound(w, "List", id)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"list": s.renderList(l)})
+		return
+	}
+
+	var l []map[string]interface{}
+	for _, id := range sortedIDs(s.ids["lists"], func(id int64) bool { _, ok := s.lists[id]; return ok }) {
+		l = append(l, s.renderList(s.lists[id]))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"lists": append([]map[string]interface{}{}, l[start:end]...),
+		"meta":  map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
+
+// Handles /api/3/contactLists.  Subscribing returns 201 for a new membership and 200 for an existing one.  The status
+// is returned as a number here, unlike everywhere else.
+func (s *Server) handleContactLists(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var req struct {
+		ListID    flexID      `json:"list"`
+		ContactID flexID      `json:"contact"`
+		Status    interface{} `json:"status"`
+	}
+	if err := decode(r, "contactList", &req); err != nil {
+		writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+		return
+	}
+
+	c, ok := s.contacts[int64(req.ContactID)]
+	if !ok {
+		writeUnprocessable(w, "Contact does not exist", "related_missing", "/data/attributes/contact")
+		return
+	}
+	if _, ok := s.lists[int64(req.ListID)]; !ok {
+		writeUnprocessable(w, "List does not exist", "related_missing", "/data/attributes/list")
+		return
+	}
+
+	status := 1
+	switch v := req.Status.(type) {
+	case bool:
+		if !v {
+			status = 2
+		}
+	case float64:
+		status = int(v)
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			status = n
+		}
+	}
+
+	var (
+		cl      *ContactList
+		created = http.StatusOK
+	)
+	for _, x := range s.contactLists {
+		if x.ContactID == c.ID && x.ListID == int64(req.ListID) {
+			cl = x
+		}
+	}
+	if cl == nil {
+		cl = &ContactList{ID: s.nextID("contactLists"), ContactID: c.ID, ListID: int64(req.ListID), Subscribed: time.Now()}
+		s.contactLists[cl.ID] = cl
+		created = http.StatusCreated
+	}
+	cl.Status = status
+
+	m := s.renderContactList(cl)
+	m["status"] = cl.Status
+
+	writeJSON(w, created, map[string]interface{}{"contacts": []interface{}{s.renderContact(c)}, "contactList": m})
+}
@@ -0,0 +1,185 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Organization is an organization (account) held by the server.
+type Organization struct {
+	ID      int64
+	Name    string
+	Created time.Time
+	Updated time.Time
+}
+
+// AddOrganization adds an organization and returns its ID.
+func (s *Server) AddOrganization(o Organization) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addOrganization(o).ID
+}
+
+// Adds an organization.  Must be called with the lock held.
+func (s *Server) addOrganization(o Organization) *Organization {
+	o.ID = s.nextID("organizations")
+	if o.Created.IsZero() {
+		o.Created = time.Now()
+	}
+	o.Updated = o.Created
+
+	s.organizations[o.ID] = &o
+
+	return &o
+}
+
+// Returns the JSON representation of an organization.
+func (s *Server) renderOrganization(o *Organization) map[string]interface{} {
+	var contacts int
+	for _, c := range s.contacts {
+		if c.OrganizationID == o.ID {
+			contacts++
+		}
+	}
+
+	links := map[string]interface{}{}
+	for _, l := range []string{"contacts", "deals", "notes", "accountCustomFieldData", "accountContacts"} {
+		links[l] = s.link("organizations/%d/%s", o.ID, l)
+	}
+
+	return map[string]interface{}{
+		"name":         o.Name,
+		"links":        links,
+		"id":           strconv.FormatInt(o.ID, 10),
+		"contactCount": strconv.Itoa(contacts),
+		"dealCount":    "0",
+	}
+}
+
+// Returns the error title for an invalid organization name, if any.
+func (s *Server) organizationNameError(name string, self *Organization) (title string, code string) {
+	if len(strings.TrimSpace(name)) == 0 {
+		return "Organization name is required", "field_missing"
+	}
+
+	for _, o := range s.organizations {
+		if o != self && strings.EqualFold(o.Name, name) {
+			return "Organization name already exists", "duplicate"
+		}
+	}
+
+	return "", ""
+}
+
+// Handles /api/3/organizations.
+func (s *Server) handleOrganizations(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	var req struct {
+		Name *string `json:"name"`
+	}
+
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			s.organizationList(w, r)
+
+		case http.MethodPost:
+			if err := decode(r, "organization", &req); err != nil {
+				writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+				return
+			}
+
+			var name string
+			if req.Name != nil {
+				name = *req.Name
+			}
+			if title, code := s.organizationNameError(name, nil); len(title) > 0 {
+				writeUnprocessable(w, title, code, "/data/attributes/name")
+				return
+			}
+
+			o := s.addOrganization(Organization{Name: name})
+			m := s.renderOrganization(o)
+			delete(m, "contactCount")
+			delete(m, "dealCount")
+
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"organization": m})
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	o, ok := s.organizations[id]
+	if !ok {
+		writeNotFound(w, "Organization", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"organization": s.renderOrganization(o)})
+
+	case http.MethodPut:
+		if err := decode(r, "organization", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		if req.Name != nil {
+			if title, code := s.organizationNameError(*req.Name, o); len(title) > 0 {
+				writeUnprocessable(w, title, code, "/data/attributes/name")
+				return
+			}
+			o.Name = *req.Name
+		}
+		o.Updated = time.Now()
+
+		m := s.renderOrganization(o)
+		delete(m, "contactCount")
+		delete(m, "dealCount")
+		m["created_timestamp"] = utcTimestamp(o.Created)
+		m["updated_timestamp"] = utcTimestamp(o.Updated)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"organization": m})
+
+	case http.MethodDelete:
+		delete(s.organizations, id)
+		for _, c := range s.contacts {
+			if c.OrganizationID == id {
+				c.OrganizationID = 0
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// Lists organizations, optionally filtered by exact name.
+func (s *Server) organizationList(w http.ResponseWriter, r *http.Request) {
+	var (
+		name = r.URL.Query().Get("filters[name]")
+		l    []map[string]interface{}
+	)
+
+	for _, id := range sortedIDs(s.ids["organizations"], func(id int64) bool { _, ok := s.organizations[id]; return ok }) {
+		o := s.organizations[id]
+		if len(name) > 0 && !strings.EqualFold(o.Name, name) {
+			continue
+		}
+		l = append(l, s.renderOrganization(o))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"organizations": append([]map[string]interface{}{}, l[start:end]...),
+		"meta":          map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
@@ -0,0 +1,170 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Score is a score held by the server.  RelType is "contact" or "deal", inactive scores are listed with status 0.
+type Score struct {
+	ID          int64
+	Name        string
+	Description string
+	RelType     string
+	Inactive    bool
+	Created     time.Time
+}
+
+// ScoreValue is the value of a score for a contact or a deal.  Deals aren't held by the server, any deal ID is
+// accepted.
+type ScoreValue struct {
+	ID        int64
+	ScoreID   int64
+	ContactID int64
+	DealID    int64
+	Value     int64
+	Created   time.Time
+	Updated   time.Time
+}
+
+// AddScore adds a score and returns its ID.  The type defaults to "contact".
+func (s *Server) AddScore(sc Score) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc.ID = s.nextID("scores")
+	if len(sc.RelType) == 0 {
+		sc.RelType = "contact"
+	}
+	if sc.Created.IsZero() {
+		sc.Created = time.Now()
+	}
+
+	s.scores[sc.ID] = &sc
+
+	return sc.ID
+}
+
+// AddScoreValue sets the value of a score for a contact or a deal and returns the ID of the value.
+func (s *Server) AddScoreValue(v ScoreValue) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sv := s.scoreValue(v.ScoreID, v.ContactID, v.DealID)
+	sv.Value = v.Value
+
+	return sv.ID
+}
+
+// Returns the value of a score for a contact or a deal, adding a zero value if there is none.  Must be called with the
+// lock held.
+func (s *Server) scoreValue(scoreID int64, contactID int64, dealID int64) *ScoreValue {
+	for _, v := range s.scoreValues {
+		if v.ScoreID == scoreID && v.ContactID == contactID && v.DealID == dealID {
+			return v
+		}
+	}
+
+	now := time.Now()
+	v := &ScoreValue{ID: s.nextID("scoreValues"), ScoreID: scoreID, ContactID: contactID, DealID: dealID, Created: now, Updated: now}
+	s.scoreValues[v.ID] = v
+
+	return v
+}
+
+// Returns the JSON representation of a score.
+func (s *Server) renderScore(sc *Score) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       strconv.FormatInt(sc.ID, 10),
+		"name":     sc.Name,
+		"descript": sc.Description,
+		"reltype":  sc.RelType,
+		"status":   flag(!sc.Inactive),
+		"cdate":    timestamp(sc.Created),
+		"mdate":    timestamp(sc.Created),
+		"links":    map[string]interface{}{"scoreValues": s.link("scores/%d/scoreValues", sc.ID)},
+	}
+}
+
+// Returns the JSON representation of a score value.  Contact and deal IDs are null when not set.
+func (s *Server) renderScoreValue(v *ScoreValue) map[string]interface{} {
+	var contact, deal interface{}
+	if v.ContactID > 0 {
+		contact = strconv.FormatInt(v.ContactID, 10)
+	}
+	if v.DealID > 0 {
+		deal = strconv.FormatInt(v.DealID, 10)
+	}
+
+	return map[string]interface{}{
+		"id":         strconv.FormatInt(v.ID, 10),
+		"score":      strconv.FormatInt(v.ScoreID, 10),
+		"contact":    contact,
+		"deal":       deal,
+		"scoreValue": strconv.FormatInt(v.Value, 10),
+		"cdate":      timestamp(v.Created),
+		"mdate":      timestamp(v.Updated),
+		"links":      map[string]interface{}{"score": s.link("scoreValues/%d/score", v.ID)},
+	}
+}
+
+// Returns the score values of a contact or a deal.  Must be called with the lock held.
+func (s *Server) scoreValuesBy(contactID int64, dealID int64) []map[string]interface{} {
+	l := []map[string]interface{}{}
+
+	for _, id := range sortedIDs(s.ids["scoreValues"], func(id int64) bool {
+		v, ok := s.scoreValues[id]
+		return ok && (contactID > 0 && v.ContactID == contactID || dealID > 0 && v.DealID == dealID)
+	}) {
+		l = append(l, s.renderScoreValue(s.scoreValues[id]))
+	}
+
+	return l
+}
+
+// Handles /api/3/scores.  Scores are read only.
+func (s *Server) handleScores(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if hasID {
+		sc, ok := s.scores[id]
+		if !ok {
+			writeNotFound(w, "Score", id)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"score": s.renderScore(sc)})
+		return
+	}
+
+	var l []map[string]interface{}
+	for _, id := range sortedIDs(s.ids["scores"], func(id int64) bool { _, ok := s.scores[id]; return ok }) {
+		l = append(l, s.renderScore(s.scores[id]))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"scores": append([]map[string]interface{}{}, l[start:end]...),
+		"meta":   map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
+
+// Handles /api/3/deals.  Only the score values of deals are supported.
+func (s *Server) handleDeals(w http.ResponseWriter, r *http.Request, id int64, hasID bool, sub string) {
+	if !hasID || sub != "scoreValues" {
+		writeNotFound(w, "Deal", id)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"scoreValues": s.scoreValuesBy(0, id)})
+}
@@ -0,0 +1,89 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Segment is a segment held by the server.  Match stands in for the conditions built by marketers, a contact is in
+// the segment if Match returns true (no contact is if Match is nil).
+type Segment struct {
+	ID      int64
+	Name    string
+	Logic   string
+	Hidden  bool
+	Match   func(c Contact) bool
+	Created time.Time
+}
+
+// AddSegment adds a segment and returns its ID.
+func (s *Server) AddSegment(seg Segment) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seg.ID = s.nextID("segments")
+	if len(seg.Logic) == 0 {
+		seg.Logic = "and"
+	}
+	if seg.Created.IsZero() {
+		seg.Created = time.Now()
+	}
+
+	s.segments[seg.ID] = &seg
+
+	return seg.ID
+}
+
+// Returns whether a contact is in a segment.
+func (seg *Segment) contains(c *Contact) bool {
+	return seg.Match != nil && seg.Match(*c)
+}
+
+// Returns the JSON representation of a segment.
+func (s *Server) renderSegment(seg *Segment) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                strconv.FormatInt(seg.ID, 10),
+		"name":              seg.Name,
+		"logic":             seg.Logic,
+		"hidden":            flag(seg.Hidden),
+		"seriesid":          "0",
+		"created_by":        "1",
+		"updated_by":        "1",
+		"created_timestamp": timestamp(seg.Created),
+		"updated_timestamp": timestamp(seg.Created),
+		"links":             map[string]interface{}{},
+	}
+}
+
+// Handles /api/3/segments.  Segments are read only.
+func (s *Server) handleSegments(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if hasID {
+		seg, ok := s.segments[id]
+		if !ok {
+			writeNotFound(w, "Segment", id)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"segment": s.renderSegment(seg)})
+		return
+	}
+
+	var l []map[string]interface{}
+	for _, id := range sortedIDs(s.ids["segments"], func(id int64) bool { _, ok := s.segments[id]; return ok }) {
+		l = append(l, s.renderSegment(s.segments[id]))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"segments": append([]map[string]interface{}{}, l[start:end]...),
+		"meta":     map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
@@ -0,0 +1,363 @@
+// Package actest provides an in-memory fake of the ActiveCampaign v3 API for offline tests.
+//
+// The fake covers the endpoints wrapped by the campaigner package (contacts, contact sync, tags, contactTags, lists,
+// contactLists, fields, fieldValues, organizations, users, groups, custom object schemas and records, e-commerce
+// connections, customers and orders, event tracking settings and event names, site tracking settings, whitelisted
+// domains, contact tracking logs, segments, forms, bounce logs, email activities, activity feeds, scores, score values
+// and automations) and the event tracking and form submission (proc.php) endpoints.  It reproduces the quirks of the
+// real API: IDs are sent as strings (and sometimes as numbers), validation errors are returned as 422 error lists and
+// linking an existing tag to a contact returns 200 instead of 201.
+//
+//	s := actest.NewServer()
+//	defer s.Close()
+//	c := campaigner.Campaigner{APIToken: s.APIToken, BaseURL: s.URL}
+package actest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TOKEN is the API token accepted by a new server.
+const TOKEN = "actest-token"
+
+// Server is an in-memory fake of the ActiveCampaign v3 API.  It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// APIToken is the token requests must send in the Api-Token header.
+	APIToken string
+
+	// EventURL is the event tracking endpoint (trackcmp.net/event), TrackingAccountID and TrackingKey are the
+	// credentials it accepts.
+	EventURL          string
+	TrackingAccountID string
+	TrackingKey       string
+
+	mu            sync.Mutex
+	ids           map[string]int64
+	contacts      map[int64]*Contact
+	tags          map[int64]*Tag
+	contactTags   map[int64]*ContactTag
+	lists         map[int64]*List
+	contactLists  map[int64]*ContactList
+	fields        map[int64]*Field
+	fieldValues   map[int64]*FieldValue
+	organizations map[int64]*Organization
+	users         map[int64]*User
+	groups        map[int64]*Group
+
+	customObjectSchemas map[int64]*CustomObjectSchema
+	customObjectRecords map[int64]*CustomObjectRecord
+	connections         map[int64]*Connection
+	ecomCustomers       map[int64]*EcomCustomer
+	ecomOrders          map[int64]*EcomOrder
+
+	eventTracking bool
+	eventNames    []string
+	events        []TrackedEvent
+
+	siteTracking        bool
+	siteTrackingDomains []string
+	trackingLogs        map[int64]*TrackingLog
+	segments            map[int64]*Segment
+	forms               map[int64]*Form
+	bounceLogs          map[int64]*BounceLog
+	emailActivities     map[int64]*EmailActivity
+	scores              map[int64]*Score
+	scoreValues         map[int64]*ScoreValue
+	automations         map[int64]*Automation
+	contactAutomations  map[int64]*ContactAutomation
+}
+
+// NewServer starts a new, empty server.
+func NewServer() *Server {
+	s := &Server{
+		APIToken:      TOKEN,
+		ids:           map[string]int64{},
+		contacts:      map[int64]*Contact{},
+		tags:          map[int64]*Tag{},
+		contactTags:   map[int64]*ContactTag{},
+		lists:         map[int64]*List{},
+		contactLists:  map[int64]*ContactList{},
+		fields:        map[int64]*Field{},
+		fieldValues:   map[int64]*FieldValue{},
+		organizations: map[int64]*Organization{},
+		users:         map[int64]*User{},
+		groups:        map[int64]*Group{},
+
+		customObjectSchemas: map[int64]*CustomObjectSchema{},
+		customObjectRecords: map[int64]*CustomObjectRecord{},
+		connections:         map[int64]*Connection{},
+		ecomCustomers:       map[int64]*EcomCustomer{},
+		ecomOrders:          map[int64]*EcomOrder{},
+		trackingLogs:        map[int64]*TrackingLog{},
+		segments:            map[int64]*Segment{},
+		forms:               map[int64]*Form{},
+		bounceLogs:          map[int64]*BounceLog{},
+		emailActivities:     map[int64]*EmailActivity{},
+		scores:              map[int64]*Score{},
+		scoreValues:         map[int64]*ScoreValue{},
+		automations:         map[int64]*Automation{},
+		contactAutomations:  map[int64]*ContactAutomation{},
+
+		TrackingAccountID: TRACKING_ACCOUNT_ID,
+		TrackingKey:       TRACKING_KEY,
+	}
+
+	// Every account has an admin (the owner of the API token).
+	admins := s.addGroup(Group{Title: "Admin", Description: "Administrators", Permissions: allPermissions()})
+	s.addUser(User{Username: "admin", FirstName: "Admin", LastName: "User", EmailAddress: "admin@example.com", GroupID: admins.ID})
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.EventURL = s.URL + "/event"
+
+	return s
+}
+
+// Returns the next ID for a resource type.  Must be called with the lock held.
+func (s *Server) nextID(resource string) int64 {
+	s.ids[resource]++
+	return s.ids[resource]
+}
+
+// Returns a link to a resource.
+func (s *Server) link(format string, a ...interface{}) string {
+	return s.URL + "/api/3/" + fmt.Sprintf(format, a...)
+}
+
+// Routes a request to its handler.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	// Events and forms are sent without the API token.
+	switch r.URL.Path {
+	case "/event":
+		s.trackEvent(w, r)
+		return
+	case "/proc.php":
+		s.submitForm(w, r)
+		return
+	}
+
+	if r.Header.Get("Api-Token") != s.APIToken {
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{"message": "You are not authorized to access this resource"})
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, "/api/3/") {
+		writeNotFound(w, "page", 0)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		parts    = strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/3/"), "/"), "/")
+		resource = parts[0]
+		id       int64
+		hasID    = len(parts) > 1
+		sub      string
+	)
+
+	// Routes with names instead of IDs.
+	switch resource {
+	case "eventTrackingEvents":
+		s.handleEventTrackingEvents(w, r, parts[1:])
+		return
+	case "siteTrackingDomains":
+		s.handleSiteTrackingDomains(w, r, parts[1:])
+		return
+	}
+	if hasID {
+		switch {
+		case resource == "contact" && len(parts) == 2 && parts[1] == "sync" && r.Method == http.MethodPost:
+			s.contactSync(w, r)
+			return
+		case resource == "users" && (parts[1] == "me" || len(parts) == 3 && (parts[1] == "email" || parts[1] == "username")):
+			s.userLookup(w, r, parts[1:])
+			return
+		case resource == "customObjects":
+			s.handleCustomObjects(w, r, parts[1:])
+			return
+		}
+	}
+
+	if hasID {
+		var err error
+		if id, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+			writeNotFound(w, resource, 0)
+			return
+		}
+	}
+	if len(parts) > 2 {
+		sub = parts[2]
+	}
+
+	switch resource {
+	case "contacts":
+		s.handleContacts(w, r, id, hasID, sub)
+	case "tags":
+		s.handleTags(w, r, id, hasID)
+	case "contactTags":
+		s.handleContactTags(w, r, id, hasID)
+	case "lists":
+		s.handleLists(w, r, id, hasID)
+	case "contactLists":
+		s.handleContactLists(w, r)
+	case "fields":
+		s.handleFields(w, r, id, hasID)
+	case "fieldValues":
+		s.handleFieldValues(w, r, id, hasID)
+	case "organizations":
+		s.handleOrganizations(w, r, id, hasID)
+	case "users":
+		s.handleUsers(w, r, id, hasID)
+	case "groups":
+		s.handleGroups(w, r, id, hasID)
+	case "connections":
+		s.handleConnections(w, r, id, hasID)
+	case "ecomCustomers":
+		s.handleEcomCustomers(w, r, id, hasID)
+	case "ecomOrders":
+		s.handleEcomOrders(w, r, id, hasID, sub)
+	case "eventTracking":
+		s.handleEventTracking(w, r)
+	case "siteTracking":
+		s.handleSiteTracking(w, r)
+	case "segments":
+		s.handleSegments(w, r, id, hasID)
+	case "forms":
+		s.handleForms(w, r, id, hasID)
+	case "emailActivities":
+		s.handleEmailActivities(w, r, hasID)
+	case "activities":
+		s.handleActivities(w, r, hasID)
+	case "scores":
+		s.handleScores(w, r, id, hasID)
+	case "deals":
+		s.handleDeals(w, r, id, hasID, sub)
+	case "automations":
+		s.handleAutomations(w, r, id, hasID)
+	default:
+		writeNotFound(w, resource, id)
+	}
+}
+
+// Writes a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Writes a 404 response the way the API does.
+func writeNotFound(w http.ResponseWriter, resource string, id int64) {
+	writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": fmt.Sprintf("No Result found for %s with id %d", resource, id)})
+}
+
+// Writes a 422 response holding a single error.
+func writeUnprocessable(w http.ResponseWriter, title string, code string, pointer string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"errors": []interface{}{
+			map[string]interface{}{"title": title, "detail": "", "code": code, "source": map[string]interface{}{"pointer": pointer}},
+		},
+	})
+}
+
+// Writes a 405 response.
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"message": "Method not allowed"})
+}
+
+// Decodes the object held under key in a request body, e.g. {"contact": {...}}.
+func decode(r *http.Request, key string, v interface{}) error {
+	var m map[string]json.RawMessage
+
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		return err
+	}
+
+	raw, ok := m[key]
+	if !ok {
+		return fmt.Errorf("missing `%s`", key)
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+// Returns the limit and offset query parameters (the API defaults to 20 and 0).
+func page(r *http.Request) (limit int, offset int) {
+	limit, offset = 20, 0
+
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 {
+		offset = v
+	}
+
+	return limit, offset
+}
+
+// Returns the [offset, offset+limit) window of n items.
+func window(n int, limit int, offset int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+
+	return offset, end
+}
+
+// Returns the IDs of a map in ascending order.
+func sortedIDs(n int64, has func(int64) bool) []int64 {
+	var l []int64
+
+	for x := int64(1); x <= n; x++ {
+		if has(x) {
+			l = append(l, x)
+		}
+	}
+
+	return l
+}
+
+// Accepts IDs sent as numbers or strings.
+type flexID int64
+
+// UnmarshalJSON loads a flexID.
+func (f *flexID) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*f = flexID(n)
+	return nil
+}
+
+// Returns a timestamp in the format used by most date fields.
+func timestamp(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05-07:00")
+}
+
+// Returns a timestamp in the format used by the *_utc_timestamp fields.
+func utcTimestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,94 @@
+package actest
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+// Sends a request to the server and decodes the JSON response.
+func send(t *testing.T, s *Server, method string, path string, body string) (int, map[string]interface{}) {
+	req, err := http.NewRequest(method, s.URL+path, bytes.NewBufferString(body))
+	require.Nil(t, err)
+	req.Header.Set("Api-Token", s.APIToken)
+
+	r, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer r.Body.Close()
+
+	var m map[string]interface{}
+	require.Nil(t, json.NewDecoder(r.Body).Decode(&m))
+
+	return r.StatusCode, m
+}
+
+func TestServer_Authentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	r, err := http.Get(s.URL + "/api/3/contacts")
+	require.Nil(t, err)
+	r.Body.Close()
+	assert.Equal(t, http.StatusForbidden, r.StatusCode)
+}
+
+func TestServer_ContactCreate(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	status, m := send(t, s, http.MethodPost, "/api/3/contacts", `{"contact":{"email":"a@b.c","firstName":"A","orgid":0}}`)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "1", m["contact"].(map[string]interface{})["id"])
+	assert.Equal(t, "0", m["contact"].(map[string]interface{})["orgid"])
+
+	status, m = send(t, s, http.MethodPost, "/api/3/contacts", `{"contact":{"email":"A@B.C"}}`)
+	require.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "duplicate", m["errors"].([]interface{})[0].(map[string]interface{})["code"])
+
+	status, _ = send(t, s, http.MethodGet, "/api/3/contacts/2", "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, m = send(t, s, http.MethodGet, "/api/3/contacts/?filters%5Bemail%5D=a%40b.c", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "1", m["meta"].(map[string]interface{})["total"])
+}
+
+func TestServer_ContactTagCreate(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	contact := s.AddContact(Contact{EmailAddress: "a@b.c"})
+	tag := s.AddTag(Tag{Name: "Customer"})
+
+	// New links return numbers and the contact, existing links return strings.
+	status, m := send(t, s, http.MethodPost, "/api/3/contactTags", `{"contactTag":{"contact":1,"tag":"1"}}`)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, float64(contact), m["contactTag"].(map[string]interface{})["contact"])
+	assert.Len(t, m["contacts"], 1)
+
+	status, m = send(t, s, http.MethodPost, "/api/3/contactTags", `{"contactTag":{"contact":1,"tag":1}}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "1", m["contactTag"].(map[string]interface{})["tag"])
+	assert.Nil(t, m["contacts"])
+
+	assert.Equal(t, []int64{tag}, s.ContactTags(contact))
+}
+
+func TestServer_OrganizationCreate(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	status, m := send(t, s, http.MethodPost, "/api/3/organizations", `{"organization":{"name":""}}`)
+	require.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "field_missing", m["errors"].([]interface{})[0].(map[string]interface{})["code"])
+
+	status, _ = send(t, s, http.MethodPost, "/api/3/organizations", `{"organization":{"name":"Acme"}}`)
+	require.Equal(t, http.StatusCreated, status)
+
+	status, m = send(t, s, http.MethodGet, "/api/3/organizations?filters%5Bname%5D=acme", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Len(t, m["organizations"], 1)
+}
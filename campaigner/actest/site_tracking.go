@@ -0,0 +1,181 @@
+package actest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrackingLog is a tracked page visit held by the server.
+type TrackingLog struct {
+	ID        int64
+	ContactID int64
+	URL       string
+	Time      time.Time
+}
+
+// EnableSiteTracking enables or disables site tracking.  It is disabled on a new server.
+func (s *Server) EnableSiteTracking(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.siteTracking = enabled
+}
+
+// TrackVisit records a page visit of a contact the way the tracking script does: only while site tracking is enabled
+// and only on whitelisted domains (and their subdomains).  Returns the ID of the log, 0 if the visit wasn't tracked.
+func (s *Server) TrackVisit(contactID int64, pageURL string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, err := url.Parse(pageURL)
+	if err != nil || !s.siteTracking || !s.whitelisted(u.Hostname()) {
+		return 0
+	}
+
+	l := &TrackingLog{ID: s.nextID("trackingLogs"), ContactID: contactID, URL: pageURL, Time: time.Now()}
+	s.trackingLogs[l.ID] = l
+
+	return l.ID
+}
+
+// Returns whether a host is on a whitelisted domain.  Must be called with the lock held.
+func (s *Server) whitelisted(host string) bool {
+	host = strings.ToLower(host)
+
+	for _, d := range s.siteTrackingDomains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the index of a whitelisted domain, -1 if it isn't whitelisted.  Must be called with the lock held.
+func (s *Server) siteTrackingDomain(name string) int {
+	for x, d := range s.siteTrackingDomains {
+		if d == name {
+			return x
+		}
+	}
+
+	return -1
+}
+
+// Returns the JSON representation of a site tracking domain.
+func (s *Server) renderSiteTrackingDomain(name string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "links": map[string]interface{}{}}
+}
+
+// Returns the JSON representation of a tracking log.
+func (s *Server) renderTrackingLog(l *TrackingLog) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      strconv.FormatInt(l.ID, 10),
+		"contact": strconv.FormatInt(l.ContactID, 10),
+		"type":    "page",
+		"value":   l.URL,
+		"hash":    "",
+		"tstamp":  timestamp(l.Time),
+		"links":   map[string]interface{}{"contact": s.link("contacts/%d", l.ContactID)},
+	}
+}
+
+// Returns the tracking logs of a contact.  Must be called with the lock held.
+func (s *Server) trackingLogsByContact(contactID int64) []map[string]interface{} {
+	l := []map[string]interface{}{}
+
+	for _, id := range sortedIDs(s.ids["trackingLogs"], func(id int64) bool { t, ok := s.trackingLogs[id]; return ok && t.ContactID == contactID }) {
+		l = append(l, s.renderTrackingLog(s.trackingLogs[id]))
+	}
+
+	return l
+}
+
+// Handles /api/3/siteTracking.
+func (s *Server) handleSiteTracking(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		// Nothing to change.
+
+	case http.MethodPut:
+		var req struct {
+			Enabled *bool `json:"enabled"`
+		}
+		if err := decode(r, "siteTracking", &req); err != nil || req.Enabled == nil {
+			writeUnprocessable(w, "Enabled is required", "field_missing", "/data/attributes/enabled")
+			return
+		}
+		s.siteTracking = *req.Enabled
+
+	default:
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"siteTracking": map[string]interface{}{"enabled": s.siteTracking}})
+}
+
+// Handles /api/3/siteTrackingDomains, domains are addressed by name.
+func (s *Server) handleSiteTrackingDomains(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			var l []map[string]interface{}
+			for _, d := range s.siteTrackingDomains {
+				l = append(l, s.renderSiteTrackingDomain(d))
+			}
+
+			limit, offset := page(r)
+			start, end := window(len(l), limit, offset)
+
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"siteTrackingDomains": append([]map[string]interface{}{}, l[start:end]...),
+				"meta":                map[string]interface{}{"total": strconv.Itoa(len(l))},
+			})
+
+		case http.MethodPost:
+			var req struct {
+				Name string `json:"name"`
+			}
+			if err := decode(r, "siteTrackingDomain", &req); err != nil {
+				writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+				return
+			}
+
+			name := strings.ToLower(strings.TrimSpace(req.Name))
+			switch {
+			case len(name) == 0:
+				writeUnprocessable(w, "Domain name is required", "field_missing", "/data/attributes/name")
+			case !strings.Contains(name, ".") || strings.ContainsAny(name, " /:"):
+				writeUnprocessable(w, "Domain name is invalid", "invalid", "/data/attributes/name")
+			case s.siteTrackingDomain(name) >= 0:
+				writeUnprocessable(w, "Domain is already whitelisted", "duplicate", "/data/attributes/name")
+			default:
+				s.siteTrackingDomains = append(s.siteTrackingDomains, name)
+				writeJSON(w, http.StatusCreated, map[string]interface{}{"siteTrackingDomain": s.renderSiteTrackingDomain(name)})
+			}
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	// The path is unescaped already.
+	x := s.siteTrackingDomain(strings.ToLower(parts[0]))
+	if len(parts) > 1 || x < 0 {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "No Result found for SiteTrackingDomain with name " + parts[0]})
+		return
+	}
+
+	if r.Method != http.MethodDelete {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	s.siteTrackingDomains = append(s.siteTrackingDomains[:x], s.siteTrackingDomains[x+1:]...)
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
@@ -0,0 +1,286 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tag is a tag held by the server.
+type Tag struct {
+	ID          int64
+	Name        string
+	Type        string
+	Description string
+	Created     time.Time
+}
+
+// ContactTag links a tag to a contact.
+type ContactTag struct {
+	ID        int64
+	ContactID int64
+	TagID     int64
+	Created   time.Time
+}
+
+// AddTag adds a tag and returns its ID.  The type defaults to "contact".
+func (s *Server) AddTag(t Tag) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addTag(t).ID
+}
+
+// AddContactTag links a tag to a contact and returns the ID of the link.
+func (s *Server) AddContactTag(contactID int64, tagID int64) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ct, _ := s.addContactTag(contactID, tagID)
+
+	return ct.ID
+}
+
+// ContactTags returns the tag IDs linked to a contact.
+func (s *Server) ContactTags(contactID int64) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var l []int64
+	for _, id := range sortedIDs(s.ids["contactTags"], func(id int64) bool { ct, ok := s.contactTags[id]; return ok && ct.ContactID == contactID }) {
+		l = append(l, s.contactTags[id].TagID)
+	}
+
+	return l
+}
+
+// Adds a tag.  Must be called with the lock held.
+func (s *Server) addTag(t Tag) *Tag {
+	t.ID = s.nextID("tags")
+	if len(t.Type) == 0 {
+		t.Type = "contact"
+	}
+	if t.Created.IsZero() {
+		t.Created = time.Now()
+	}
+
+	s.tags[t.ID] = &t
+
+	return &t
+}
+
+// Links a tag to a contact unless already linked.  Returns the link and whether it was created.  Must be called with
+// the lock held.
+func (s *Server) addContactTag(contactID int64, tagID int64) (*ContactTag, bool) {
+	for _, ct := range s.contactTags {
+		if ct.ContactID == contactID && ct.TagID == tagID {
+			return ct, false
+		}
+	}
+
+	ct := &ContactTag{ID: s.nextID("contactTags"), ContactID: contactID, TagID: tagID, Created: time.Now()}
+	s.contactTags[ct.ID] = ct
+
+	return ct, true
+}
+
+// Returns the JSON representation of a tag.
+func (s *Server) renderTag(t *Tag) map[string]interface{} {
+	var count int
+	for _, ct := range s.contactTags {
+		if ct.TagID == t.ID {
+			count++
+		}
+	}
+
+	return map[string]interface{}{
+		"tagType":          t.Type,
+		"tag":              t.Name,
+		"description":      t.Description,
+		"cdate":            timestamp(t.Created),
+		"subscriber_count": strconv.Itoa(count),
+		"links":            map[string]interface{}{"contactGoalTags": s.link("tags/%d/contactGoalTags", t.ID)},
+		"id":               strconv.FormatInt(t.ID, 10),
+	}
+}
+
+// Returns the JSON representation of a contact tag.  New links are returned with numeric contact and tag IDs while
+// existing links use strings.
+func (s *Server) renderContactTag(ct *ContactTag, numeric bool) map[string]interface{} {
+	m := map[string]interface{}{
+		"cdate": timestamp(ct.Created),
+		"links": map[string]interface{}{
+			"contact": s.link("contactTags/%d/contact", ct.ID),
+			"tag":     s.link("contactTags/%d/tag", ct.ID),
+		},
+		"id":      strconv.FormatInt(ct.ID, 10),
+		"contact": strconv.FormatInt(ct.ContactID, 10),
+		"tag":     strconv.FormatInt(ct.TagID, 10),
+	}
+
+	if numeric {
+		m["contact"] = ct.ContactID
+		m["tag"] = ct.TagID
+	}
+
+	return m
+}
+
+// Returns the tags linked to a contact.
+func (s *Server) contactTagsByContact(contactID int64) []map[string]interface{} {
+	l := []map[string]interface{}{}
+
+	for _, id := range sortedIDs(s.ids["contactTags"], func(id int64) bool { ct, ok := s.contactTags[id]; return ok && ct.ContactID == contactID }) {
+		l = append(l, s.renderContactTag(s.contactTags[id], false))
+	}
+
+	return l
+}
+
+// Handles /api/3/tags.
+func (s *Server) handleTags(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			s.tagList(w, r)
+		case http.MethodPost:
+			s.tagCreate(w, r)
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	t, ok := s.tags[id]
+	if !ok {
+		writeNotFound(w, "Tag", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"tag": s.renderTag(t)})
+	case http.MethodDelete:
+		delete(s.tags, id)
+		for x, ct := range s.contactTags {
+			if ct.TagID == id {
+				delete(s.contactTags, x)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// Lists tags, optionally filtered by a partial name (filters[tag] or search).
+func (s *Server) tagList(w http.ResponseWriter, r *http.Request) {
+	var (
+		q      = r.URL.Query()
+		search = strings.ToLower(q.Get("filters[tag]"))
+		l      []map[string]interface{}
+	)
+	if len(search) == 0 {
+		search = strings.ToLower(q.Get("search"))
+	}
+
+	for _, id := range sortedIDs(s.ids["tags"], func(id int64) bool { _, ok := s.tags[id]; return ok }) {
+		t := s.tags[id]
+		if len(search) > 0 && !strings.Contains(strings.ToLower(t.Name), search) {
+			continue
+		}
+		l = append(l, s.renderTag(t))
+	}
+
+	limit, offset := page(r)
+	start, end := window(len(l), limit, offset)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tags": append([]map[string]interface{}{}, l[start:end]...),
+		"meta": map[string]interface{}{"total": strconv.Itoa(len(l))},
+	})
+}
+
+// Creates a tag.
+func (s *Server) tagCreate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name        string `json:"tag"`
+		Type        string `json:"tagType"`
+		Description string `json:"description"`
+	}
+	if err := decode(r, "tag", &req); err != nil {
+		writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+		return
+	}
+
+	if len(strings.TrimSpace(req.Name)) == 0 {
+		writeUnprocessable(w, "Tag name is required", "field_missing", "/data/attributes/tag")
+		return
+	}
+	for _, t := range s.tags {
+		if strings.EqualFold(t.Name, req.Name) {
+			writeUnprocessable(w, "Tag name already exists", "duplicate", "/data/attributes/tag")
+			return
+		}
+	}
+
+	t := s.addTag(Tag{Name: req.Name, Type: req.Type, Description: req.Description})
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"tag": s.renderTag(t)})
+}
+
+// Handles /api/3/contactTags.  Linking a tag that is already linked returns 200 and the existing link.
+func (s *Server) handleContactTags(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	switch {
+	case !hasID && r.Method == http.MethodPost:
+		var req struct {
+			ContactID flexID `json:"contact"`
+			TagID     flexID `json:"tag"`
+		}
+		if err := decode(r, "contactTag", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		c, ok := s.contacts[int64(req.ContactID)]
+		if !ok {
+			writeNotFound(w, "Subscriber", int64(req.ContactID))
+			return
+		}
+		if _, ok := s.tags[int64(req.TagID)]; !ok {
+			writeNotFound(w, "Tag", int64(req.TagID))
+			return
+		}
+
+		ct, created := s.addContactTag(c.ID, int64(req.TagID))
+		if !created {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"contactTag": s.renderContactTag(ct, false)})
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"contacts":   []interface{}{s.renderContact(c)},
+			"contactTag": s.renderContactTag(ct, true),
+		})
+
+	case hasID && r.Method == http.MethodGet:
+		ct, ok := s.contactTags[id]
+		if !ok {
+			writeNotFound(w, "ContactTag", id)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"contactTag": s.renderContactTag(ct, false)})
+
+	case hasID && r.Method == http.MethodDelete:
+		if _, ok := s.contactTags[id]; !ok {
+			writeNotFound(w, "ContactTag", id)
+			return
+		}
+		delete(s.contactTags, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
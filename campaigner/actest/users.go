@@ -0,0 +1,413 @@
+package actest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// User is a user held by the server.  A new server holds an admin user (ID 1) which is returned by /users/me.
+type User struct {
+	ID           int64
+	Username     string
+	FirstName    string
+	LastName     string
+	EmailAddress string
+	PhoneNumber  string
+	GroupID      int64
+	Created      time.Time
+	Updated      time.Time
+}
+
+// Group is a user group held by the server.  Permissions holds the enabled pg_* flags, e.g. "pg_list_add".  A new
+// server holds an admin group (ID 1) with every permission.
+type Group struct {
+	ID          int64
+	Title       string
+	Description string
+	Permissions map[string]bool
+}
+
+// The permission flags sent with every group.
+var permissions = []string{
+	"pg_list_add", "pg_list_edit", "pg_list_delete",
+	"pg_message_add", "pg_message_edit", "pg_message_delete", "pg_message_send",
+	"pg_subscriber_add", "pg_subscriber_edit", "pg_subscriber_delete", "pg_subscriber_import", "pg_subscriber_export",
+	"pg_subscriber_fields",
+	"pg_user_add", "pg_user_edit", "pg_user_delete",
+	"pg_group_add", "pg_group_edit", "pg_group_delete",
+	"pg_reports_campaign",
+	"pg_deal", "pg_deal_delete",
+}
+
+// Returns a permission set with every flag enabled.
+func allPermissions() map[string]bool {
+	m := map[string]bool{}
+	for _, p := range permissions {
+		m[p] = true
+	}
+
+	return m
+}
+
+// AddUser adds a user and returns its ID.
+func (s *Server) AddUser(u User) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addUser(u).ID
+}
+
+// AddGroup adds a group and returns its ID.
+func (s *Server) AddGroup(g Group) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addGroup(g).ID
+}
+
+// UserGroup returns the group ID of a user, 0 if the user doesn't exist.
+func (s *Server) UserGroup(userID int64) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u, ok := s.users[userID]; ok {
+		return u.GroupID
+	}
+
+	return 0
+}
+
+// Adds a user.  Must be called with the lock held.
+func (s *Server) addUser(u User) *User {
+	u.ID = s.nextID("users")
+	if u.Created.IsZero() {
+		u.Created = time.Now()
+	}
+	u.Updated = u.Created
+
+	s.users[u.ID] = &u
+
+	return &u
+}
+
+// Adds a group.  Must be called with the lock held.
+func (s *Server) addGroup(g Group) *Group {
+	g.ID = s.nextID("groups")
+	if g.Permissions == nil {
+		g.Permissions = map[string]bool{}
+	}
+
+	s.groups[g.ID] = &g
+
+	return &g
+}
+
+// Returns the JSON representation of a user.
+func (s *Server) renderUser(u *User) map[string]interface{} {
+	links := map[string]interface{}{}
+	for _, l := range []string{"lists", "userGroup", "dealGroupTotals", "dealGroupUsers", "configs", "dealConnection", "seatUser"} {
+		links[l] = s.link("users/%d/%s", u.ID, l)
+	}
+
+	return map[string]interface{}{
+		"username":    u.Username,
+		"firstName":   u.FirstName,
+		"lastName":    u.LastName,
+		"email":       u.EmailAddress,
+		"phone":       u.PhoneNumber,
+		"signature":   nil,
+		"lang":        "english",
+		"localZoneid": "America/Chicago",
+		"mfaEnabled":  "0",
+		"cdate":       timestamp(u.Created),
+		"udate":       timestamp(u.Updated),
+		"links":       links,
+		"id":          strconv.FormatInt(u.ID, 10),
+	}
+}
+
+// Returns the JSON representation of a group.
+func (s *Server) renderGroup(g *Group) map[string]interface{} {
+	links := map[string]interface{}{}
+	for _, l := range []string{"userGroups", "groupLimit", "dealGroupGroups", "listGroups", "addressGroups", "automationGroups"} {
+		links[l] = s.link("groups/%d/%s", g.ID, l)
+	}
+
+	m := map[string]interface{}{
+		"title":           g.Title,
+		"descript":        g.Description,
+		"unsubscribelink": "0",
+		"optinconfirm":    "0",
+		"links":           links,
+		"id":              strconv.FormatInt(g.ID, 10),
+	}
+	for _, p := range permissions {
+		m[p] = flag(g.Permissions[p])
+	}
+
+	return m
+}
+
+// Returns a flag the way the API sends it.
+func flag(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+// Returns the error title, code and field for an invalid user, if any.
+func (s *Server) userError(u *User, self *User) (title string, code string, field string) {
+	switch {
+	case len(strings.TrimSpace(u.Username)) == 0:
+		return "Username is required", "field_missing", "username"
+	case len(strings.TrimSpace(u.EmailAddress)) == 0:
+		return "Email is required", "field_missing", "email"
+	}
+
+	if _, ok := s.groups[u.GroupID]; !ok {
+		return "Group does not exist", "invalid", "group"
+	}
+
+	for _, y := range s.users {
+		if y == self {
+			continue
+		}
+		if strings.EqualFold(y.Username, u.Username) {
+			return "Username already exists", "duplicate", "username"
+		}
+		if strings.EqualFold(y.EmailAddress, u.EmailAddress) {
+			return "Email already exists", "duplicate", "email"
+		}
+	}
+
+	return "", "", ""
+}
+
+// Handles /api/3/users.
+func (s *Server) handleUsers(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	var req struct {
+		Username  *string `json:"username"`
+		Email     *string `json:"email"`
+		FirstName *string `json:"firstName"`
+		LastName  *string `json:"lastName"`
+		Group     *flexID `json:"group"`
+		Password  string  `json:"password"`
+	}
+
+	// Applies the request to a user.
+	apply := func(u *User) {
+		if req.Username != nil {
+			u.Username = *req.Username
+		}
+		if req.Email != nil {
+			u.EmailAddress = *req.Email
+		}
+		if req.FirstName != nil {
+			u.FirstName = *req.FirstName
+		}
+		if req.LastName != nil {
+			u.LastName = *req.LastName
+		}
+		if req.Group != nil {
+			u.GroupID = int64(*req.Group)
+		}
+	}
+
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			var l []map[string]interface{}
+			for _, id := range sortedIDs(s.ids["users"], func(id int64) bool { _, ok := s.users[id]; return ok }) {
+				l = append(l, s.renderUser(s.users[id]))
+			}
+
+			limit, offset := page(r)
+			start, end := window(len(l), limit, offset)
+
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"users": append([]map[string]interface{}{}, l[start:end]...),
+				"meta":  map[string]interface{}{"total": strconv.Itoa(len(l))},
+			})
+
+		case http.MethodPost:
+			if err := decode(r, "user", &req); err != nil {
+				writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+				return
+			}
+
+			var u User
+			apply(&u)
+			if title, code, field := s.userError(&u, nil); len(title) > 0 {
+				writeUnprocessable(w, title, code, "/data/attributes/"+field)
+				return
+			}
+
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"user": s.renderUser(s.addUser(u))})
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	u, ok := s.users[id]
+	if !ok {
+		writeNotFound(w, "User", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"user": s.renderUser(u)})
+
+	case http.MethodPut:
+		if err := decode(r, "user", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		updated := *u
+		apply(&updated)
+		if title, code, field := s.userError(&updated, u); len(title) > 0 {
+			writeUnprocessable(w, title, code, "/data/attributes/"+field)
+			return
+		}
+		updated.Updated = time.Now()
+		*u = updated
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"user": s.renderUser(u)})
+
+	case http.MethodDelete:
+		delete(s.users, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// Handles /api/3/users/me, /api/3/users/email/{email} and /api/3/users/username/{username}.  The API token belongs to
+// the first user.
+func (s *Server) userLookup(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	var match func(u *User) bool
+	switch parts[0] {
+	case "me":
+		match = func(u *User) bool { return true }
+	default:
+		value := parts[1]
+		match = func(u *User) bool {
+			if parts[0] == "email" {
+				return strings.EqualFold(u.EmailAddress, value)
+			}
+			return strings.EqualFold(u.Username, value)
+		}
+	}
+
+	for _, id := range sortedIDs(s.ids["users"], func(id int64) bool { _, ok := s.users[id]; return ok }) {
+		if match(s.users[id]) {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"user": s.renderUser(s.users[id])})
+			return
+		}
+	}
+
+	writeNotFound(w, "User", 0)
+}
+
+// Handles /api/3/groups.  Permissions missing from an update are left unchanged.
+func (s *Server) handleGroups(w http.ResponseWriter, r *http.Request, id int64, hasID bool) {
+	var req map[string]interface{}
+
+	// Applies the request to a group.
+	apply := func(g *Group) {
+		for k, v := range req {
+			switch {
+			case k == "title":
+				g.Title, _ = v.(string)
+			case k == "descript":
+				g.Description, _ = v.(string)
+			case strings.HasPrefix(k, "pg_"):
+				switch x := v.(type) {
+				case string:
+					g.Permissions[k] = x == "1"
+				case float64:
+					g.Permissions[k] = x != 0
+				case bool:
+					g.Permissions[k] = x
+				}
+			}
+		}
+	}
+
+	if !hasID {
+		switch r.Method {
+		case http.MethodGet:
+			var l []map[string]interface{}
+			for _, id := range sortedIDs(s.ids["groups"], func(id int64) bool { _, ok := s.groups[id]; return ok }) {
+				l = append(l, s.renderGroup(s.groups[id]))
+			}
+
+			limit, offset := page(r)
+			start, end := window(len(l), limit, offset)
+
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"groups": append([]map[string]interface{}{}, l[start:end]...),
+				"meta":   map[string]interface{}{"total": strconv.Itoa(len(l))},
+			})
+
+		case http.MethodPost:
+			if err := decode(r, "group", &req); err != nil {
+				writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+				return
+			}
+
+			g := Group{Permissions: map[string]bool{}}
+			apply(&g)
+			if len(strings.TrimSpace(g.Title)) == 0 {
+				writeUnprocessable(w, "Group title is required", "field_missing", "/data/attributes/title")
+				return
+			}
+
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"group": s.renderGroup(s.addGroup(g))})
+
+		default:
+			writeMethodNotAllowed(w)
+		}
+		return
+	}
+
+	g, ok := s.groups[id]
+	if !ok {
+		writeNotFound(w, "Group", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"group": s.renderGroup(g)})
+
+	case http.MethodPut:
+		if err := decode(r, "group", &req); err != nil {
+			writeUnprocessable(w, "Invalid request body", "invalid", "/data/attributes")
+			return
+		}
+
+		apply(g)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"group": s.renderGroup(g)})
+
+	case http.MethodDelete:
+		delete(s.groups, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
@@ -0,0 +1,291 @@
+package campaigner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// DEFAULT_BATCH_CONCURRENCY is the number of operations a batch runs at the same time unless told otherwise.
+const DEFAULT_BATCH_CONCURRENCY = DEFAULT_RATE_LIMIT
+
+// BatchOperation performs a single operation.  The client passed in is a copy of the client running the batch with the
+// batch's retry setting, it should be used for every API call the operation makes along with the context.
+type BatchOperation func(ctx context.Context, c *Campaigner) error
+
+// BatchItem holds a single operation of a batch.  Key identifies the item in results and checkpoints, it should be
+// unique within a batch.
+type BatchItem struct {
+	Key       string
+	Operation BatchOperation
+}
+
+// BatchOptions holds the settings for a batch.  The zero value is usable.
+type BatchOptions struct {
+	// Concurrency is the maximum number of operations running at the same time.
+	Concurrency int
+
+	// Progress is called (never concurrently) after each item has finished or been skipped.
+	Progress func(BatchProgress)
+
+	// Checkpoint records finished items.  Items it already knows about are skipped, which allows a batch to resume.
+	Checkpoint BatchCheckpoint
+
+	// MaxRetries is the number of times requests sent by operations are retried after being rate limited (negative
+	// disables retries).  The client's MaxRetries is used if 0, DEFAULT_MAX_RETRIES if that is 0 too.
+	MaxRetries int
+}
+
+// BatchProgress holds the progress of a batch (see BatchOptions.Progress).
+type BatchProgress struct {
+	Total     int
+	Completed int
+	Failed    int
+	Skipped   int
+	Last      BatchResult
+}
+
+// BatchResult holds the outcome of a single batch item.
+type BatchResult struct {
+	Index   int
+	Key     string
+	Skipped bool
+	Err     error
+}
+
+// BatchError holds the results of every failed item of a batch.
+type BatchError struct {
+	Failed []BatchResult
+}
+
+// Error satisfies the error interface.  Generates and returns the error string.
+func (e BatchError) Error() string {
+	var l []string
+
+	for _, y := range e.Failed {
+		l = append(l, fmt.Sprintf("%s: %s", y.Key, y.Err))
+	}
+
+	return fmt.Sprintf("batch failed, %d item(s) with errors (%s)", len(e.Failed), strings.Join(l, ", "))
+}
+
+// Batch runs operations with bounded concurrency.  Every operation shares the client's rate limiter with all other
+// traffic of the client, so set RateLimiter (e.g. NewRateLimiter(DEFAULT_RATE_LIMIT)) before running large batches.
+// Requests sent by operations are retried as set by options.MaxRetries.
+//
+// Items that haven't started when ctx is done fail with the context's error.  Results are returned in the same order as
+// items.  If any item failed the error is a BatchError.
+func (c *Campaigner) Batch(ctx context.Context, items []BatchItem, options BatchOptions) ([]BatchResult, error) {
+	// Setup.
+	var (
+		results  = make([]BatchResult, len(items))
+		progress = BatchProgress{Total: len(items)}
+		queue    = make(chan int)
+		done     = make(chan BatchResult)
+		wg       sync.WaitGroup
+	)
+
+	client := c.batchClient(options.MaxRetries)
+
+	if options.Concurrency < 1 {
+		options.Concurrency = DEFAULT_BATCH_CONCURRENCY
+	}
+
+	// Workers.
+	for x := 0; x < options.Concurrency; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				done <- client.batchRun(ctx, i, items[i], options.Checkpoint)
+			}
+		}()
+	}
+
+	go func() {
+		for i := range items {
+			queue <- i
+		}
+		close(queue)
+		wg.Wait()
+		close(done)
+	}()
+
+	// Collect results.
+	var failed []BatchResult
+	for r := range done {
+		results[r.Index] = r
+
+		progress.Completed++
+		if r.Skipped {
+			progress.Skipped++
+		} else if r.Err != nil {
+			progress.Failed++
+			failed = append(failed, r)
+		}
+		progress.Last = r
+
+		if options.Progress != nil {
+			options.Progress(progress)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, BatchError{Failed: failed}
+	}
+
+	return results, nil
+}
+
+// Runs a single batch item, consulting and updating the checkpoint.
+// Returns the copy of a client that batch operations send requests with.  It shares the client's transport, rate limiter
+// and cache.
+func (c *Campaigner) batchClient(maxRetries int) *Campaigner {
+	client := *c
+	switch {
+	case maxRetries != 0:
+		client.MaxRetries = maxRetries
+	case client.MaxRetries == 0:
+		client.MaxRetries = DEFAULT_MAX_RETRIES
+	}
+
+	return client.Init()
+}
+
+func (c *Campaigner) batchRun(ctx context.Context, index int, item BatchItem, checkpoint BatchCheckpoint) BatchResult {
+	r := BatchResult{Index: index, Key: item.Key}
+
+	if checkpoint != nil && checkpoint.Done(item.Key) {
+		r.Skipped = true
+		return r
+	}
+
+	if item.Operation == nil {
+		r.Err = fmt.Errorf("batch item has no operation")
+		return r
+	}
+
+	if r.Err = ctx.Err(); r.Err != nil {
+		return r
+	}
+
+	if r.Err = item.Operation(ctx, c); r.Err != nil {
+		return r
+	}
+
+	if checkpoint != nil {
+		if err := checkpoint.MarkDone(item.Key); err != nil {
+			r.Err = fmt.Errorf("operation succeeded but checkpoint failed: %s", err)
+		}
+	}
+
+	return r
+}
+
+// BatchContactDelete returns a batch item that deletes a contact.
+func BatchContactDelete(id int64) BatchItem {
+	return BatchItem{
+		Key: fmt.Sprintf("contact-delete:%d", id),
+		Operation: func(ctx context.Context, c *Campaigner) error {
+			return c.contactAPI().Delete(ctx, id)
+		},
+	}
+}
+
+// BatchContactTagCreate returns a batch item that links a tag to a contact.
+func BatchContactTagCreate(request RequestContactTagCreate) BatchItem {
+	return BatchItem{
+		Key: fmt.Sprintf("contact-tag-create:%d:%d", request.ContactID, request.TagID),
+		Operation: func(ctx context.Context, c *Campaigner) error {
+			_, err := c.contactAPI().AddTag(ctx, request)
+			return err
+		},
+	}
+}
+
+// BatchContactTagDelete returns a batch item that removes a tag from a contact (see ContactAPI.RemoveTag).
+func BatchContactTagDelete(id int64) BatchItem {
+	return BatchItem{
+		Key: fmt.Sprintf("contact-tag-delete:%d", id),
+		Operation: func(ctx context.Context, c *Campaigner) error {
+			return c.contactAPI().RemoveTag(ctx, id)
+		},
+	}
+}
+
+// BatchListContactAdd returns a batch item that adds a contact to a list.
+func BatchListContactAdd(listID int64, contactID int64) BatchItem {
+	return BatchItem{
+		Key: fmt.Sprintf("list-contact-add:%d:%d", listID, contactID),
+		Operation: func(ctx context.Context, c *Campaigner) error {
+			_, err := c.listAPI().AddContact(ctx, listID, contactID)
+			return err
+		},
+	}
+}
+
+// BatchCheckpoint records which batch items have finished so that an interrupted batch can be resumed.
+// Implementations must be safe for concurrent use.
+type BatchCheckpoint interface {
+	Done(key string) bool
+	MarkDone(key string) error
+}
+
+// FileCheckpoint is a BatchCheckpoint that appends finished keys to a file, one per line.
+type FileCheckpoint struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[string]bool
+}
+
+// NewFileCheckpoint opens (or creates) a checkpoint file.  Keys already in the file are treated as done.
+func NewFileCheckpoint(path string) (*FileCheckpoint, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint open failed: %s", err)
+	}
+
+	cp := &FileCheckpoint{file: f, done: map[string]bool{}}
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if k := strings.TrimSpace(s.Text()); len(k) > 0 {
+			cp.done[k] = true
+		}
+	}
+	if err := s.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("checkpoint read failed: %s", err)
+	}
+
+	return cp, nil
+}
+
+// Done checks whether a key has been recorded.
+func (f *FileCheckpoint) Done(key string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.done[key]
+}
+
+// MarkDone records a key.
+func (f *FileCheckpoint) MarkDone(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := fmt.Fprintln(f.file, key); err != nil {
+		return err
+	}
+	f.done[key] = true
+
+	return nil
+}
+
+// Close closes the checkpoint file.
+func (f *FileCheckpoint) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,264 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Tests all batch functionality as a group.  These tests run against a local test server.
+func TestBatchSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestBatch_SuccessConcurrency)
+	runTestWithPackagePath(t, TestBatch_FailureAggregated)
+	runTestWithPackagePath(t, TestBatch_SuccessCheckpointResume)
+	runTestWithPackagePath(t, TestBatch_FailureCanceled)
+	runTestWithPackagePath(t, TestBatch_SuccessSharedRateLimiter)
+	runTestWithPackagePath(t, TestSend_SuccessRetryRateLimited)
+	runTestWithPackagePath(t, TestRateLimiter_Wait)
+}
+
+// Starts a test server that deletes contacts.  Contact 3 can't be deleted.
+func newBatchTestServer(running *int32, max *int32, deleted *sync.Map) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(running, 1)
+		defer atomic.AddInt32(running, -1)
+		for {
+			m := atomic.LoadInt32(max)
+			if n <= m || atomic.CompareAndSwapInt32(max, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if r.URL.Path == "/api/3/contacts/3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{}`)
+			return
+		}
+
+		deleted.Store(strings.TrimPrefix(r.URL.Path, "/api/3/contacts/"), true)
+		fmt.Fprint(w, `{}`)
+	}))
+}
+
+func TestBatch_SuccessConcurrency(t *testing.T) {
+	var (
+		running, max int32
+		deleted      sync.Map
+		items        []BatchItem
+		calls        int
+	)
+
+	s := newBatchTestServer(&running, &max, &deleted)
+	defer s.Close()
+
+	for x := int64(10); x < 30; x++ {
+		items = append(items, BatchContactDelete(x))
+	}
+
+	c := Campaigner{APIToken: "token", BaseURL: s.URL, RateLimiter: NewRateLimiter(1000)}
+	r, err := c.Batch(context.Background(), items, BatchOptions{Concurrency: 4, Progress: func(p BatchProgress) {
+		calls++
+		assert.Equal(t, 20, p.Total)
+		assert.Equal(t, calls, p.Completed)
+	}})
+
+	require.Nil(t, err)
+	assert.Equal(t, 20, calls)
+	assert.True(t, max <= 4, "concurrency exceeded: %d", max)
+	require.Len(t, r, 20)
+	for x, y := range r {
+		assert.Equal(t, x, y.Index)
+		assert.Equal(t, fmt.Sprintf("contact-delete:%d", x+10), y.Key)
+		_, ok := deleted.Load(fmt.Sprintf("%d", x+10))
+		assert.True(t, ok)
+	}
+}
+
+func TestBatch_FailureAggregated(t *testing.T) {
+	var (
+		running, max int32
+		deleted      sync.Map
+	)
+
+	s := newBatchTestServer(&running, &max, &deleted)
+	defer s.Close()
+
+	items := []BatchItem{BatchContactDelete(1), BatchContactDelete(3), {Key: "empty"}}
+
+	c := Campaigner{APIToken: "token", BaseURL: s.URL, RateLimiter: NewRateLimiter(1000)}
+	r, err := c.Batch(context.Background(), items, BatchOptions{})
+
+	require.NotNil(t, err)
+	require.IsType(t, BatchError{}, err)
+	assert.Len(t, err.(BatchError).Failed, 2)
+	assert.Nil(t, r[0].Err)
+	assert.NotNil(t, r[1].Err)
+	assert.NotNil(t, r[2].Err)
+}
+
+func TestBatch_SuccessCheckpointResume(t *testing.T) {
+	var (
+		running, max int32
+		deleted      sync.Map
+	)
+
+	s := newBatchTestServer(&running, &max, &deleted)
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "campaigner")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint")
+
+	items := []BatchItem{BatchContactDelete(1), BatchContactDelete(2), BatchContactDelete(3)}
+	c := Campaigner{APIToken: "token", BaseURL: s.URL, RateLimiter: NewRateLimiter(1000)}
+
+	// First run, contact 3 fails.
+	cp, err := NewFileCheckpoint(path)
+	require.Nil(t, err)
+	_, err = c.Batch(context.Background(), items, BatchOptions{Checkpoint: cp})
+	assert.NotNil(t, err)
+	require.Nil(t, cp.Close())
+
+	// Second run, only contact 3 is retried.
+	cp, err = NewFileCheckpoint(path)
+	require.Nil(t, err)
+	defer cp.Close()
+
+	r, err := c.Batch(context.Background(), items, BatchOptions{Checkpoint: cp})
+	assert.NotNil(t, err)
+	assert.True(t, r[0].Skipped)
+	assert.True(t, r[1].Skipped)
+	assert.False(t, r[2].Skipped)
+	assert.NotNil(t, r[2].Err)
+}
+
+func TestBatch_FailureCanceled(t *testing.T) {
+	var (
+		running, max int32
+		deleted      sync.Map
+	)
+
+	s := newBatchTestServer(&running, &max, &deleted)
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := Campaigner{APIToken: "token", BaseURL: s.URL}
+	r, err := c.Batch(ctx, []BatchItem{BatchContactDelete(1), BatchContactDelete(2)}, BatchOptions{})
+
+	require.IsType(t, BatchError{}, err)
+	assert.Len(t, err.(BatchError).Failed, 2)
+	assert.Equal(t, context.Canceled, r[0].Err)
+	assert.Equal(t, int32(0), max)
+}
+
+// Concurrent batches share the client's rate limiter and retry rate limited requests without changing the client.
+func TestBatch_SuccessSharedRateLimiter(t *testing.T) {
+	var calls int32
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request is rate limited, batch requests are retried.
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer s.Close()
+
+	var (
+		c        = New("token", s.URL)
+		limiter  = NewRateLimiter(1000)
+		mu       sync.Mutex
+		limiters = map[*RateLimiter]bool{}
+		wg       sync.WaitGroup
+	)
+
+	item := BatchItem{Key: "limiter", Operation: func(ctx context.Context, c *Campaigner) error {
+		mu.Lock()
+		limiters[c.RateLimiter] = true
+		mu.Unlock()
+
+		return c.Contacts.Delete(ctx, 1)
+	}}
+
+	c.RateLimiter = limiter
+	for x := 0; x < 2; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Batch(context.Background(), []BatchItem{item}, BatchOptions{})
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[*RateLimiter]bool{limiter: true}, limiters)
+	assert.Equal(t, int32(3), calls)
+	assert.True(t, c.RateLimiter == limiter)
+	assert.Equal(t, 0, c.MaxRetries)
+
+	// Retries set for the batch.
+	calls = 0
+	_, err := c.Batch(context.Background(), []BatchItem{item}, BatchOptions{MaxRetries: -1})
+	assert.IsType(t, BatchError{}, err)
+	assert.Equal(t, int32(1), calls)
+
+	// Clients without a rate limiter are left without one.
+	c = New("token", s.URL)
+	_, err = c.Batch(context.Background(), []BatchItem{item}, BatchOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, c.RateLimiter)
+}
+
+func TestSend_SuccessRetryRateLimited(t *testing.T) {
+	var calls int
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer s.Close()
+
+	// Retries disabled.
+	c := Campaigner{APIToken: "token", BaseURL: s.URL}
+	assert.NotNil(t, c.ContactDelete(1))
+	assert.Equal(t, 1, calls)
+
+	// Retries enabled.
+	calls = 0
+	c.MaxRetries = 3
+	assert.Nil(t, c.ContactDelete(1))
+	assert.Equal(t, 3, calls)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(100)
+	start := time.Now()
+
+	for x := 0; x < 11; x++ {
+		l.Wait()
+	}
+
+	assert.True(t, time.Since(start) >= 100*time.Millisecond)
+}
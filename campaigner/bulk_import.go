@@ -0,0 +1,380 @@
+package campaigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BULK_IMPORT_MAX_CONTACTS is the maximum number of contacts the API accepts in a single bulk import request.
+const BULK_IMPORT_MAX_CONTACTS = 250
+
+// BULK_IMPORT_STATUS_COMPLETED is the status reported by the API once a bulk import batch has been processed.
+const BULK_IMPORT_STATUS_COMPLETED = "completed"
+
+// BulkImportContact holds a JSON compatible contact as it is sent to the bulk import API.
+type BulkImportContact struct {
+	EmailAddress        string                   `json:"email"`
+	FirstName           string                   `json:"first_name,omitempty"`
+	LastName            string                   `json:"last_name,omitempty"`
+	PhoneNumber         string                   `json:"phone,omitempty"`
+	CustomerAccountName string                   `json:"customer_acct_name,omitempty"`
+	Tags                []string                 `json:"tags,omitempty"`
+	Fields              []BulkImportField        `json:"fields,omitempty"`
+	Subscribe           []BulkImportSubscription `json:"subscribe,omitempty"`
+	Unsubscribe         []BulkImportSubscription `json:"unsubscribe,omitempty"`
+}
+
+// BulkImportField holds a JSON compatible custom field value (nested structure, see BulkImportContact).
+type BulkImportField struct {
+	ID    int64  `json:"id"`
+	Value string `json:"value"`
+}
+
+// BulkImportSubscription holds a JSON compatible list subscription (nested structure, see BulkImportContact).
+type BulkImportSubscription struct {
+	ListID int64 `json:"listid"`
+}
+
+// BulkImportCallback holds a JSON compatible callback that the API calls once a batch has been processed.
+type BulkImportCallback struct {
+	URL             string                        `json:"url"`
+	RequestType     string                        `json:"requestType"`
+	DetailedResults bool                          `json:"detailed_results,string"`
+	Params          []BulkImportCallbackParameter `json:"params,omitempty"`
+	Headers         []BulkImportCallbackParameter `json:"headers,omitempty"`
+}
+
+// BulkImportCallbackParameter holds a JSON compatible key/value pair (nested structure, see BulkImportCallback).
+type BulkImportCallbackParameter struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// BulkImportBatch holds the details of a single chunk submitted by BulkImportAPI.Create.
+type BulkImportBatch struct {
+	BatchID        string
+	Offset         int
+	Count          int
+	QueuedContacts int
+}
+
+// BulkImportFailure holds a rejected row.  Index refers to the position of the contact in the original request.
+type BulkImportFailure struct {
+	Index        int
+	EmailAddress string
+	Reasons      []string
+}
+
+// BulkImportFailureReason holds a JSON compatible failure reason.  Contact is the index of the contact within the
+// submitted chunk.
+//
+// TODO(api): The failure format is not well documented.  Plain strings have also been seen, in which case Contact is -1.
+type BulkImportFailureReason struct {
+	Contact int      `json:"contact"`
+	Reasons []string `json:"failureReasons"`
+}
+
+// UnmarshalJSON loads a BulkImportFailureReason from either an object or a plain string.
+func (f *BulkImportFailureReason) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*f = BulkImportFailureReason{Contact: -1, Reasons: []string{s}}
+		return nil
+	}
+
+	var o struct {
+		Contact *Int64json      `json:"contact"`
+		Reason  string          `json:"failureReason"`
+		Reasons json.RawMessage `json:"failureReasons"`
+	}
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+
+	*f = BulkImportFailureReason{Contact: -1}
+	if o.Contact != nil {
+		f.Contact = int(*o.Contact)
+	}
+	if len(o.Reason) > 0 {
+		f.Reasons = append(f.Reasons, o.Reason)
+	}
+	if len(o.Reasons) > 0 {
+		var l []string
+		if err := json.Unmarshal(o.Reasons, &l); err != nil {
+			var s string
+			if err := json.Unmarshal(o.Reasons, &s); err != nil {
+				return err
+			}
+			l = []string{s}
+		}
+		f.Reasons = append(f.Reasons, l...)
+	}
+
+	return nil
+}
+
+// BulkImportResult holds the combined outcome of a (possibly chunked) bulk import.
+type BulkImportResult struct {
+	Batches  []BulkImportBatch
+	Failures []BulkImportFailure
+}
+
+// BatchIDs returns the IDs of all batches that were queued by the API.
+func (r BulkImportResult) BatchIDs() []string {
+	var l []string
+
+	for _, b := range r.Batches {
+		l = append(l, b.BatchID)
+	}
+
+	return l
+}
+
+// RequestBulkImport holds a JSON compatible request for bulk importing contacts.
+type RequestBulkImport struct {
+	Contacts []BulkImportContact `json:"contacts"`
+	Callback *BulkImportCallback `json:"callback,omitempty"`
+}
+
+// ResponseBulkImport holds a JSON compatible response for a single bulk import request.
+type ResponseBulkImport struct {
+	Success        int                       `json:"success"`
+	QueuedContacts int                       `json:"queued_contacts"`
+	BatchID        string                    `json:"batchId"`
+	Message        string                    `json:"message"`
+	FailureReasons []BulkImportFailureReason `json:"failureReasons"`
+}
+
+// ResponseBulkImportInfo holds a JSON compatible response for reading the status of a bulk import batch.
+//
+// TODO(api): Success and failure appear to hold contact IDs but this is not documented.
+type ResponseBulkImportInfo struct {
+	Status  string   `json:"status"`
+	Success []string `json:"success"`
+	Failure []string `json:"failure"`
+}
+
+// ResponseBulkImportList holds a JSON compatible response for listing bulk import batches.
+type ResponseBulkImportList struct {
+	Outstanding       []BulkImportBatchStatus `json:"outstanding"`
+	RecentlyCompleted []BulkImportBatchStatus `json:"recentlyCompleted"`
+}
+
+// BulkImportBatchStatus holds a JSON compatible batch summary (nested structure, see ResponseBulkImportList).
+type BulkImportBatchStatus struct {
+	BatchID  string    `json:"batchId"`
+	Contacts Int64json `json:"contacts"`
+}
+
+// Create imports contacts in bulk.  Requests larger than BULK_IMPORT_MAX_CONTACTS are split into chunks and each chunk
+// is queued as a separate batch.
+//
+// Rows rejected by the API are collected in the result.  The API rejects a whole chunk when any of its rows are
+// invalid, so the remaining rows of a rejected chunk are resubmitted once without the failed rows.
+func (s *BulkImportAPI) Create(ctx context.Context, request RequestBulkImport) (result BulkImportResult, err error) {
+	// Error check.
+	if len(request.Contacts) == 0 {
+		return result, fmt.Errorf("bulk import failed, no contacts given")
+	}
+
+	for offset := 0; offset < len(request.Contacts); offset += BULK_IMPORT_MAX_CONTACTS {
+		end := offset + BULK_IMPORT_MAX_CONTACTS
+		if end > len(request.Contacts) {
+			end = len(request.Contacts)
+		}
+
+		err = s.chunk(ctx, request, offset, end, &result)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Submits contacts [offset, end) as a single batch, resubmitting valid rows once if the chunk is rejected.
+func (s *BulkImportAPI) chunk(ctx context.Context, request RequestBulkImport, offset int, end int, result *BulkImportResult) error {
+	// Setup.
+	var (
+		contacts = request.Contacts[offset:end]
+		indexes  = make([]int, len(contacts))
+	)
+
+	for x := range indexes {
+		indexes[x] = offset + x
+	}
+
+	for attempt := 0; attempt < 2 && len(contacts) > 0; attempt++ {
+		response, rejected, err := s.post(ctx, RequestBulkImport{Contacts: contacts, Callback: request.Callback})
+		if err != nil {
+			return err
+		}
+
+		if !rejected {
+			result.Batches = append(result.Batches, BulkImportBatch{BatchID: response.BatchID, Offset: offset, Count: len(contacts), QueuedContacts: response.QueuedContacts})
+			return nil
+		}
+
+		// Record rejected rows and keep the rest for resubmission.
+		var (
+			failed    = map[int][]string{}
+			remaining []BulkImportContact
+			kept      []int
+		)
+
+		for _, f := range response.FailureReasons {
+			if f.Contact >= 0 && f.Contact < len(contacts) {
+				failed[f.Contact] = append(failed[f.Contact], f.Reasons...)
+			}
+		}
+
+		// Nothing can be matched to a row, or this was the second attempt.  Reject everything left in the chunk.
+		if len(failed) == 0 || attempt == 1 {
+			for x, y := range contacts {
+				reasons := failed[x]
+				if len(reasons) == 0 {
+					reasons = bulkImportReasons(response)
+				}
+				result.Failures = append(result.Failures, BulkImportFailure{Index: indexes[x], EmailAddress: y.EmailAddress, Reasons: reasons})
+			}
+
+			return nil
+		}
+
+		for x, y := range contacts {
+			if reasons, ok := failed[x]; ok {
+				result.Failures = append(result.Failures, BulkImportFailure{Index: indexes[x], EmailAddress: y.EmailAddress, Reasons: reasons})
+				continue
+			}
+
+			remaining = append(remaining, y)
+			kept = append(kept, indexes[x])
+		}
+
+		contacts, indexes = remaining, kept
+	}
+
+	return nil
+}
+
+// Sends a single bulk import request.  A rejected request (HTTP 400) is not treated as an error.
+func (s *BulkImportAPI) post(ctx context.Context, request RequestBulkImport) (response ResponseBulkImport, rejected bool, err error) {
+	// Setup.
+	var uri = "/api/3/import/bulk_import"
+
+	// Send POST request.
+	r, body, err := s.client.post(ctx, uri, request)
+	if err != nil {
+		return response, false, fmt.Errorf("bulk import failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		if err = s.client.decode(r, body, &response); err != nil {
+			return response, false, fmt.Errorf("bulk import failed, JSON error: %s", err)
+		}
+
+		return response, false, nil
+
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		if err = s.client.decode(r, body, &response); err != nil {
+			return response, false, fmt.Errorf("bulk import failed, unspecified error (%d): %s", r.StatusCode, string(body))
+		}
+
+		return response, true, nil
+
+	default:
+		return response, false, fmt.Errorf("bulk import failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Returns the reasons to report for a row that could not be matched to a specific failure.
+func bulkImportReasons(response ResponseBulkImport) []string {
+	var l []string
+
+	for _, f := range response.FailureReasons {
+		if f.Contact < 0 {
+			l = append(l, f.Reasons...)
+		}
+	}
+
+	if len(l) == 0 && len(response.Message) > 0 {
+		l = append(l, response.Message)
+	}
+
+	return l
+}
+
+// Info reads the status of a bulk import batch.
+func (s *BulkImportAPI) Info(ctx context.Context, batchID string) (response ResponseBulkImportInfo, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("batchId", batchID)
+	u := url.URL{Path: "/api/3/import/info", RawQuery: qs.Encode()}
+
+	// Error check.
+	if len(strings.TrimSpace(batchID)) == 0 {
+		return response, fmt.Errorf("bulk import info failed, batch ID is empty")
+	}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("bulk import info failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "bulk import info", fmt.Sprintf("batch %s", batchID))
+
+	return response, err
+}
+
+// List lists outstanding and recently completed bulk import batches.
+func (s *BulkImportAPI) List(ctx context.Context) (response ResponseBulkImportList, err error) {
+	// Send GET request.
+	r, body, err := s.client.get(ctx, "/api/3/import/bulk_import")
+	if err != nil {
+		return response, fmt.Errorf("bulk import list failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		if err = s.client.decode(r, body, &response); err != nil {
+			return response, fmt.Errorf("bulk import list failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	default:
+		return response, fmt.Errorf("bulk import list failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Wait polls the status of a bulk import batch every interval until it has completed.  Waiting stops with an error
+// once ctx is done, use context.WithTimeout to limit how long to wait.
+func (s *BulkImportAPI) Wait(ctx context.Context, batchID string, interval time.Duration) (response ResponseBulkImportInfo, err error) {
+	for {
+		response, err = s.Info(ctx, batchID)
+		if err != nil {
+			return response, err
+		}
+
+		if response.Status == BULK_IMPORT_STATUS_COMPLETED {
+			return response, nil
+		}
+
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return response, fmt.Errorf("bulk import wait failed, batch `%s` still %s: %s", batchID, response.Status, ctx.Err())
+		case <-t.C:
+		}
+	}
+}
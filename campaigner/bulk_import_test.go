@@ -0,0 +1,166 @@
+package campaigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Tests all bulk import functionality as a group.  These tests run against a local test server.
+func TestBulkImportSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestBulkImportCreate_FailureEmpty)
+	runTestWithPackagePath(t, TestBulkImportCreate_SuccessChunked)
+	runTestWithPackagePath(t, TestBulkImportCreate_SuccessPartialFailure)
+	runTestWithPackagePath(t, TestBulkImportWait_Success)
+	runTestWithPackagePath(t, TestBulkImportWait_FailureTimeout)
+	runTestWithPackagePath(t, TestBulkImportInfo_FailureNotFound)
+}
+
+// Generates a list of n bulk import contacts.
+func bulkImportTestContacts(n int) []BulkImportContact {
+	var l []BulkImportContact
+
+	for x := 0; x < n; x++ {
+		l = append(l, BulkImportContact{EmailAddress: fmt.Sprintf("bulk%05d@user.com", x), FirstName: "Bulk", LastName: fmt.Sprintf("User %05d", x)})
+	}
+
+	return l
+}
+
+func TestBulkImportCreate_FailureEmpty(t *testing.T) {
+	c := New("token", "http://invalid")
+
+	_, err := c.BulkImports.Create(context.Background(), RequestBulkImport{})
+	assert.NotNil(t, err)
+}
+
+func TestBulkImportCreate_SuccessChunked(t *testing.T) {
+	var sizes []int
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestBulkImport
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "/api/3/import/bulk_import", r.URL.Path)
+		assert.Equal(t, "token", r.Header.Get("Api-Token"))
+
+		sizes = append(sizes, len(req.Contacts))
+		fmt.Fprintf(w, `{"success":1,"queued_contacts":%d,"batchId":"batch-%d","message":"Contact import queued"}`, len(req.Contacts), len(sizes))
+	}))
+	defer s.Close()
+
+	c := New("token", s.URL)
+	r, err := c.BulkImports.Create(context.Background(), RequestBulkImport{Contacts: bulkImportTestContacts(600)})
+
+	require.Nil(t, err)
+	assert.Equal(t, []int{250, 250, 100}, sizes)
+	assert.Empty(t, r.Failures)
+	require.Len(t, r.Batches, 3)
+	assert.Equal(t, []string{"batch-1", "batch-2", "batch-3"}, r.BatchIDs())
+	assert.Equal(t, 500, r.Batches[2].Offset)
+	assert.Equal(t, 100, r.Batches[2].QueuedContacts)
+}
+
+func TestBulkImportCreate_SuccessPartialFailure(t *testing.T) {
+	var requests []RequestBulkImport
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestBulkImport
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+
+		// Reject the first request, rows 1 and 3 are invalid.
+		if len(requests) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"success":0,"message":"Invalid contacts","failureReasons":[{"contact":1,"failureReasons":["invalid email"]},{"contact":"3","failureReason":"missing email"}]}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"success":1,"queued_contacts":%d,"batchId":"batch-%d"}`, len(req.Contacts), len(requests))
+	}))
+	defer s.Close()
+
+	c := New("token", s.URL)
+	r, err := c.BulkImports.Create(context.Background(), RequestBulkImport{Contacts: bulkImportTestContacts(5)})
+
+	require.Nil(t, err)
+	require.Len(t, requests, 2)
+	assert.Len(t, requests[1].Contacts, 3)
+
+	require.Len(t, r.Failures, 2)
+	assert.Equal(t, 1, r.Failures[0].Index)
+	assert.Equal(t, "bulk00001@user.com", r.Failures[0].EmailAddress)
+	assert.Equal(t, []string{"invalid email"}, r.Failures[0].Reasons)
+	assert.Equal(t, 3, r.Failures[1].Index)
+	assert.Equal(t, []string{"missing email"}, r.Failures[1].Reasons)
+
+	require.Len(t, r.Batches, 1)
+	assert.Equal(t, "batch-2", r.Batches[0].BatchID)
+	assert.Equal(t, 3, r.Batches[0].Count)
+}
+
+func TestBulkImportWait_Success(t *testing.T) {
+	var polls int
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/3/import/info", r.URL.Path)
+		assert.Equal(t, "batch-1", r.URL.Query().Get("batchId"))
+
+		polls++
+		if polls < 3 {
+			fmt.Fprint(w, `{"status":"processing","success":[],"failure":[]}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"status":"completed","success":["10","11"],"failure":["12"]}`)
+	}))
+	defer s.Close()
+
+	c := New("token", s.URL)
+	r, err := c.BulkImports.Wait(context.Background(), "batch-1", time.Millisecond)
+
+	require.Nil(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, BULK_IMPORT_STATUS_COMPLETED, r.Status)
+	assert.Equal(t, []string{"12"}, r.Failure)
+}
+
+func TestBulkImportWait_FailureTimeout(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"processing","success":[],"failure":[]}`)
+	}))
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// The poll interval is far longer than the timeout, waiting must stop with the context.
+	start := time.Now()
+	r, err := New("token", s.URL).BulkImports.Wait(ctx, "batch-1", time.Hour)
+
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "still processing")
+	assert.Equal(t, "processing", r.Status)
+	assert.True(t, time.Since(start) < time.Second)
+
+	// The deprecated wrapper times out the same way.
+	_, err = New("token", s.URL).BulkImportWait("batch-1", time.Hour, 20*time.Millisecond)
+	assert.NotNil(t, err)
+}
+
+func TestBulkImportInfo_FailureNotFound(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"No Result found for batch"}`)
+	}))
+	defer s.Close()
+
+	_, err := New("token", s.URL).BulkImports.Info(context.Background(), "batch-9")
+	require.IsType(t, CustomErrorNotFound{}, err)
+	assert.Contains(t, err.Error(), "bulk import info failed, batch batch-9 not found")
+}
@@ -0,0 +1,245 @@
+package campaigner
+
+import (
+	"container/list"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cacheable resource types.  The resource type of an API call is the first segment after /api/3/.
+const (
+	CACHE_FIELDS = "fields"
+	CACHE_LISTS  = "lists"
+	CACHE_TAGS   = "tags"
+)
+
+// DEFAULT_CACHE_SIZE is the number of responses kept by the default in-memory store.
+const DEFAULT_CACHE_SIZE = 1000
+
+// CacheEntry holds a cached response.
+type CacheEntry struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+	Expires      time.Time
+}
+
+// CacheStore stores cached responses.  Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+}
+
+// Cache caches GET responses of read-mostly metadata (see Campaigner.Cache).  Only resources with a TTL are cached.
+// Successful POST, PUT and DELETE requests sent through the same cache invalidate their resource type.
+//
+// Expired entries that came with an ETag or Last-Modified header are revalidated with a conditional request.
+type Cache struct {
+	Store CacheStore
+	TTL   map[string]time.Duration
+
+	mu          sync.Mutex
+	generations map[string]int
+}
+
+// NewCache returns a cache backed by an in-memory LRU store, caching tags and lists for 5 minutes and fields for 15.
+func NewCache() *Cache {
+	return &Cache{
+		Store: NewLRUCacheStore(DEFAULT_CACHE_SIZE),
+		TTL: map[string]time.Duration{
+			CACHE_FIELDS: 15 * time.Minute,
+			CACHE_LISTS:  5 * time.Minute,
+			CACHE_TAGS:   5 * time.Minute,
+		},
+	}
+}
+
+// Invalidate drops every cached response of the given resource types (e.g. CACHE_TAGS).
+func (c *Cache) Invalidate(resources ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations == nil {
+		c.generations = map[string]int{}
+	}
+	for _, r := range resources {
+		c.generations[r]++
+	}
+}
+
+// Returns the store key for a URL.  Keys include the generation of the resource so that invalidation doesn't need to
+// find the entries belonging to a resource.
+func (c *Cache) key(resource string, url string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return fmt.Sprintf("%s#%d %s", resource, c.generations[resource], url)
+}
+
+// Returns the cache middleware for a client.
+func (c *Cache) middleware(client *Campaigner, next APIHandler) APIHandler {
+	return func(request *APIRequest) (*APIResponse, error) {
+		resource := cacheResource(request.Endpoint)
+
+		if _, ok := c.TTL[resource]; !ok {
+			return next(request)
+		}
+
+		if request.Method != http.MethodGet {
+			response, err := next(request)
+			if err == nil && response.StatusCode() < http.StatusBadRequest {
+				c.Invalidate(resource)
+			}
+
+			return response, err
+		}
+
+		key := c.key(resource, client.GenerateURL(request.Path))
+		entry, found := c.Store.Get(key)
+
+		if found && time.Now().Before(entry.Expires) {
+			if client.Logger != nil {
+				client.Logger.Debug("campaigner cache hit", "method", request.Method, "path", request.Path)
+			}
+
+			return cachedResponse(entry), nil
+		}
+
+		// Conditional request.
+		if found {
+			if request.Header == nil {
+				request.Header = http.Header{}
+			}
+			if len(entry.ETag) > 0 {
+				request.Header.Set("If-None-Match", entry.ETag)
+			}
+			if len(entry.LastModified) > 0 {
+				request.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+
+		response, err := next(request)
+		if err != nil {
+			return response, err
+		}
+
+		switch response.StatusCode() {
+		case http.StatusNotModified:
+			if !found {
+				return response, nil
+			}
+
+			entry.Expires = time.Now().Add(c.TTL[resource])
+			c.Store.Set(key, entry)
+
+			return cachedResponse(entry), nil
+
+		case http.StatusOK:
+			h := response.HTTPResponse.Header
+			c.Store.Set(key, CacheEntry{Body: response.Body, ETag: h.Get("ETag"), LastModified: h.Get("Last-Modified"), Expires: time.Now().Add(c.TTL[resource])})
+		}
+
+		return response, nil
+	}
+}
+
+// Returns a response for a cache entry.
+func cachedResponse(entry CacheEntry) *APIResponse {
+	r := &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}}
+
+	if len(entry.ETag) > 0 {
+		r.Header.Set("ETag", entry.ETag)
+	}
+
+	return &APIResponse{HTTPResponse: r, Body: entry.Body}
+}
+
+// Returns the resource type of an endpoint, e.g. "tags" for /api/3/tags/{id}.
+func cacheResource(endpoint string) string {
+	parts := strings.Split(strings.TrimPrefix(endpoint, "/"), "/")
+
+	if len(parts) < 3 || parts[0] != "api" {
+		return ""
+	}
+
+	return parts[2]
+}
+
+// LRUCacheStore is an in-memory CacheStore that evicts the least recently used entry once it is full.
+type LRUCacheStore struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// An LRU list element.
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCacheStore returns an in-memory store holding up to size entries.
+func NewLRUCacheStore(size int) *LRUCacheStore {
+	if size < 1 {
+		size = 1
+	}
+
+	return &LRUCacheStore{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get returns an entry and marks it as recently used.
+func (s *LRUCacheStore) Get(key string) (CacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	s.order.MoveToFront(e)
+
+	return e.Value.(*lruItem).entry, true
+}
+
+// Set adds or replaces an entry, evicting the least recently used entry if the store is full.
+func (s *LRUCacheStore) Set(key string, entry CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		e.Value.(*lruItem).entry = entry
+		s.order.MoveToFront(e)
+		return
+	}
+
+	s.entries[key] = s.order.PushFront(&lruItem{key: key, entry: entry})
+
+	for s.order.Len() > s.size {
+		e := s.order.Back()
+		s.order.Remove(e)
+		delete(s.entries, e.Value.(*lruItem).key)
+	}
+}
+
+// Delete removes an entry.
+func (s *LRUCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		s.order.Remove(e)
+		delete(s.entries, key)
+	}
+}
+
+// Len returns the number of entries in the store.
+func (s *LRUCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
@@ -0,0 +1,114 @@
+package campaigner
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Tests all cache functionality as a group.  These tests run against a local test server.
+func TestCacheSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestCache_SuccessHitAndInvalidate)
+	runTestWithPackagePath(t, TestCache_SuccessConditional)
+	runTestWithPackagePath(t, TestLRUCacheStore_Eviction)
+}
+
+// Starts a test server for tags that counts GET requests.
+func newCacheTestServer(gets *int, conditional *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			*gets++
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				*conditional++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			fmt.Fprint(w, `{"tags":[{"id":"1","tag":"One","tagType":"contact"}],"meta":{"total":"1"}}`)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"tag":{"id":"2","tag":"Two","tagType":"contact"}}`)
+		}
+	}))
+}
+
+func TestCache_SuccessHitAndInvalidate(t *testing.T) {
+	var gets, conditional int
+
+	s := newCacheTestServer(&gets, &conditional)
+	defer s.Close()
+
+	c := Campaigner{APIToken: "token", BaseURL: s.URL, Cache: NewCache()}
+
+	for x := 0; x < 3; x++ {
+		r, err := c.TagList()
+		require.Nil(t, err)
+		require.Len(t, r.Tags, 1)
+		assert.Equal(t, "One", r.Tags[0].Name)
+	}
+	assert.Equal(t, 1, gets)
+
+	// Creating a tag invalidates cached tags.
+	_, err := c.TagCreate(Tag{Name: "Two", Description: "Two", Type: "contact"})
+	require.Nil(t, err)
+	_, err = c.TagList()
+	require.Nil(t, err)
+	assert.Equal(t, 2, gets)
+
+	// Explicit invalidation.
+	c.Cache.Invalidate(CACHE_TAGS)
+	_, err = c.TagList()
+	require.Nil(t, err)
+	assert.Equal(t, 3, gets)
+	assert.Equal(t, 0, conditional)
+
+	// Other resources are not cached.
+	_, _ = c.ContactTagReadByContactID(1)
+	_, _ = c.ContactTagReadByContactID(1)
+	assert.Equal(t, 5, gets)
+}
+
+func TestCache_SuccessConditional(t *testing.T) {
+	var gets, conditional int
+
+	s := newCacheTestServer(&gets, &conditional)
+	defer s.Close()
+
+	cache := NewCache()
+	cache.TTL[CACHE_TAGS] = time.Nanosecond
+	c := Campaigner{APIToken: "token", BaseURL: s.URL, Cache: cache}
+
+	_, err := c.TagList()
+	require.Nil(t, err)
+	time.Sleep(time.Millisecond)
+
+	r, err := c.TagList()
+	require.Nil(t, err)
+	require.Len(t, r.Tags, 1)
+	assert.Equal(t, 2, gets)
+	assert.Equal(t, 1, conditional)
+}
+
+func TestLRUCacheStore_Eviction(t *testing.T) {
+	s := NewLRUCacheStore(2)
+
+	s.Set("a", CacheEntry{Body: []byte("a")})
+	s.Set("b", CacheEntry{Body: []byte("b")})
+	_, _ = s.Get("a")
+	s.Set("c", CacheEntry{Body: []byte("c")})
+
+	_, ok := s.Get("b")
+	assert.False(t, ok)
+	e, ok := s.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "a", string(e.Body))
+	assert.Equal(t, 2, s.Len())
+
+	s.Delete("a")
+	assert.Equal(t, 1, s.Len())
+}
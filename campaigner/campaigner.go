@@ -2,17 +2,92 @@ package campaigner
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	neturl "net/url"
+	"strconv"
 	"strings"
-
-	"github.com/parnurzeal/gorequest"
+	"time"
 )
 
+// DEFAULT_MAX_RETRIES is the number of retries used by Batch when the client does not set MaxRetries.
+const DEFAULT_MAX_RETRIES = 3
+
+// DEFAULT_RETRY_DELAY is the delay before the first retry of a rate limited request (when the API doesn't say).
+const DEFAULT_RETRY_DELAY = time.Second
+
+// DEFAULT_HTTP_TIMEOUT is the timeout of requests sent by clients without an HTTPClient.
+const DEFAULT_HTTP_TIMEOUT = 30 * time.Second
+
+// Sends requests for clients without an HTTPClient.  Unlike http.DefaultClient it doesn't wait forever.
+var defaultHTTPClient = &http.Client{Timeout: DEFAULT_HTTP_TIMEOUT}
+
 // Campaigner is a library for interacting with ActiveCampaign.
+//
+// Endpoints are grouped by resource, e.g. c.Contacts.Read(ctx, id).  The services are set up by New (or Init for
+// clients built as struct literals) and share the client's transport, authentication, rate limiter and logger.
 type Campaigner struct {
 	APIToken string
 	BaseURL  string
+
+	// HTTPClient sends requests, including events and form submissions.  A client with a DEFAULT_HTTP_TIMEOUT timeout is
+	// used if nil.
+	HTTPClient *http.Client
+
+	// RateLimiter is shared by every request sent by this client (and copies of it).  Requests are not limited if nil.
+	RateLimiter *RateLimiter
+
+	// Location is the time zone of the account.  The API sends some timestamps without an offset (e.g. "2006-01-02
+	// 15:04:05"), these are read in Location, or UTC if nil.
+	Location *time.Location
+
+	// MaxRetries is the number of times a request is retried after the API responds with 429 Too Many Requests.
+	MaxRetries int
+
+	// Logger receives request and response logs (at debug level).  Nothing is logged if nil.
+	Logger Logger
+
+	// LogBodies adds request and response bodies to logs and hooks.  Bodies pass through RedactBody first if set.
+	LogBodies  bool
+	RedactBody func(body []byte) []byte
+
+	// DumpCurl logs every request as an equivalent curl command with the API token masked.
+	DumpCurl bool
+
+	// OnRequest and OnResponse are called for every request sent and every response received (including retries).
+	OnRequest  func(RequestLog)
+	OnResponse func(ResponseLog)
+
+	// Middleware wraps every API call (including its retries), the first middleware is the outermost.
+	Middleware []Middleware
+
+	// Cache caches responses of read-mostly metadata (see NewCache).  Cache hits don't pass through middleware.
+	Cache *Cache
+
+	// Strict compares every response with its model and reports unknown fields and type mismatches to OnDrift (or
+	// logs them as warnings if OnDrift is nil).  Mismatched values are left at their zero value instead of failing.
+	Strict  bool
+	OnDrift func(Drift)
+
+	// Services.
+	Contacts      *ContactAPI
+	Tags          *TagAPI
+	Lists         *ListAPI
+	Fields        *FieldAPI
+	Organizations *OrganizationAPI
+	BulkImports   *BulkImportAPI
+	Users         *UserAPI
+	Groups        *GroupAPI
+	CustomObjects *CustomObjectAPI
+	Ecommerce     *EcommerceAPI
+	SiteTracking  *SiteTrackingAPI
+	Segments      *SegmentAPI
+	Forms         *FormAPI
+	History       *HistoryAPI
+	Scores        *ScoreAPI
 }
 
 // CheckConfig checks that API Token and BaseURL have been defined.
@@ -37,118 +112,233 @@ func (c *Campaigner) GenerateURL(url string) string {
 	return url
 }
 
-func (c *Campaigner) delete(url string) (gorequest.Response, []byte, error) {
-	// Locals.
-	var (
-		r    gorequest.Response
-		b    []byte
-		errs []error
-	)
+// Returns the API path of a link returned by the API.  Links hold full URLs, e.g. Contact.Links.TrackingLogs.
+func (c *Campaigner) linkPath(link string) string {
+	base := strings.TrimSuffix(c.BaseURL, "/")
+	if len(base) > 0 && strings.HasPrefix(link, base+"/") {
+		return strings.TrimPrefix(link, base)
+	}
 
-	// Check API config.
-	if err := c.CheckConfig(); err != nil {
-		return r, b, err
+	if u, err := neturl.Parse(link); err == nil && u.IsAbs() {
+		return u.RequestURI()
 	}
 
-	r, b, errs = gorequest.New().
-		Delete(c.GenerateURL(url)).
-		Set("Api-Token", c.APIToken).
-		EndBytes()
+	return link
+}
 
-	if errs != nil {
-		return r, b, CustomError{Message: "could not perform HTTP DELETE request", HTTPErrors: errs}
+// Posts an HTML form to an endpoint outside of the API (e.g. event tracking).  The API token is not sent and the
+// request doesn't pass through middleware, the rate limiter or the cache.
+func (c *Campaigner) postForm(ctx context.Context, target string, form neturl.Values) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, nil, err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	return r, b, nil
-}
+	client := c.httpClient()
 
-func (c *Campaigner) get(url string) (gorequest.Response, []byte, error) {
-	// Locals.
-	var (
-		r    gorequest.Response
-		b    []byte
-		errs []error
-	)
+	r, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Body.Close()
 
-	// Check API config.
-	if err := c.CheckConfig(); err != nil {
-		return r, b, err
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	url = c.GenerateURL(url)
+	return r, b, nil
+}
+
+// Returns the HTTP client requests are sent with.
+func (c *Campaigner) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return defaultHTTPClient
+	}
 
-	r, b, errs = gorequest.New().
-		Get(url).
-		Set("Api-Token", c.APIToken).
-		EndBytes()
+	return c.HTTPClient
+}
 
-	if errs != nil {
-		return r, b, CustomError{Message: "could not perform HTTP GET request", HTTPErrors: errs}
+// Send a DELETE request to the Active Campaign API.
+func (c *Campaigner) delete(ctx context.Context, url string) (*http.Response, []byte, error) {
+	r, b, err := c.send(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return r, b, CustomError{Message: "could not perform HTTP DELETE request", HTTPErrors: []error{err}}
 	}
 
-	// TODO(questions): Not sure if output should be indented by default.  Makes dev easier and indentation should never break things but still smells bad.
-	var pretty bytes.Buffer
-	err := json.Indent(&pretty, b, "", "\t")
+	return r, b, nil
+}
+
+// Send a GET request to the Active Campaign API.
+func (c *Campaigner) get(ctx context.Context, url string) (*http.Response, []byte, error) {
+	r, b, err := c.send(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		panic(err)
+		return r, b, CustomError{Message: "could not perform HTTP GET request", HTTPErrors: []error{err}}
 	}
 
 	return r, b, nil
 }
 
 // Send a POST request to the Active Campaign API.
-func (c *Campaigner) post(url string, i interface{}) (r gorequest.Response, b []byte, err error) {
-	// Check API config.
-	if err := c.CheckConfig(); err != nil {
-		return r, b, err
+func (c *Campaigner) post(ctx context.Context, url string, i interface{}) (*http.Response, []byte, error) {
+	j, err := json.Marshal(i)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not marshall json for interface: %s", err)
 	}
 
-	// Generate URL and JSON.
-	url = c.GenerateURL(url)
-	j, err := json.Marshal(i)
+	r, b, err := c.send(ctx, http.MethodPost, url, j)
 	if err != nil {
-		return r, b, fmt.Errorf("Could not marshall json for interface: %s\n", err)
+		return r, b, CustomError{Message: "could not perform HTTP POST request", HTTPErrors: []error{err}}
 	}
 
-	// Send POST request.
-	r, b, errs := gorequest.New().
-		Post(url).
-		Send(string(j)).
-		Set("Api-Token", c.APIToken).
-		EndBytes()
+	return r, b, nil
+}
+
+// Send a PUT request to the Active Campaign API.
+func (c *Campaigner) put(ctx context.Context, url string, i interface{}) (*http.Response, []byte, error) {
+	j, err := json.Marshal(i)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not marshall json for interface: %s", err)
+	}
 
-	// Error check.
-	if errs != nil {
-		return r, b, CustomError{Message: "could not perform HTTP POST request", HTTPErrors: errs}
+	r, b, err := c.send(ctx, http.MethodPut, url, j)
+	if err != nil {
+		return r, b, CustomError{Message: "could not perform HTTP PUT request", HTTPErrors: []error{err}}
 	}
 
 	return r, b, nil
 }
 
-// Send a PUT request to the Active Campaign API.
-func (c *Campaigner) put(url string, i interface{}) (r gorequest.Response, b []byte, err error) {
+// Checks the response of a call and decodes a successful one into v unless v is nil.  action names the call and what
+// the requested object in errors, e.g. "tag read" and "tag 12".
+func (c *Campaigner) result(r *http.Response, body []byte, v interface{}, action string, what string) error {
+	switch {
+	case r.StatusCode >= 200 && r.StatusCode < 300:
+		if v == nil {
+			return nil
+		}
+
+		if err := c.decode(r, body, v); err != nil {
+			return fmt.Errorf("%s failed, JSON error: %s", action, err)
+		}
+
+		return nil
+
+	case r.StatusCode == http.StatusNotFound:
+		return CustomErrorNotFound{CustomError{Message: fmt.Sprintf("%s failed, %s not found", action, what)}}
+
+	case r.StatusCode == http.StatusUnprocessableEntity:
+		var apiError ActiveCampaignError
+		if err := json.Unmarshal(body, &apiError); err != nil {
+			return fmt.Errorf("%s failed, API error unmarshall error: %s", action, err)
+		}
+
+		return apiError
+
+	default:
+		return fmt.Errorf("%s failed, unspecified error (%d): %s", action, r.StatusCode, string(body))
+	}
+}
+
+// Sends a request to the Active Campaign API.  This is the path shared by every API call: the request passes through
+// the configured middleware before being sent (see transport).
+//
+// The response body is read and closed, the returned response is only good for its status code and headers.
+func (c *Campaigner) send(ctx context.Context, method string, url string, body []byte) (*http.Response, []byte, error) {
 	// Check API config.
 	if err := c.CheckConfig(); err != nil {
-		return r, b, err
+		return nil, nil, err
 	}
 
-	url = c.GenerateURL(url)
+	// Build the middleware chain, the first middleware is the outermost.
+	h := c.transport
+	for x := len(c.Middleware) - 1; x >= 0; x-- {
+		h = c.Middleware[x](h)
+	}
+	if c.Cache != nil {
+		h = c.Cache.middleware(c, h)
+	}
 
-	j, err := json.Marshal(i)
-	if err != nil {
-		return r, b, fmt.Errorf("Could not marshall json for interface: %s\n", err)
+	resp, err := h(&APIRequest{Context: ctx, Method: method, Path: url, Endpoint: EndpointTemplate(url), Body: body})
+	if resp == nil {
+		return nil, nil, err
 	}
 
-	r, b, errs := gorequest.New().
-		Put(url).
-		Send(string(j)).
-		Set("Api-Token", c.APIToken).
-		EndBytes()
+	return resp.HTTPResponse, resp.Body, err
+}
+
+// Sends a request over HTTP.  Waits for the rate limiter and retries requests that were rejected with 429 Too Many
+// Requests.  Waiting stops early when the request context is done.
+func (c *Campaigner) transport(request *APIRequest) (*APIResponse, error) {
+	client := c.httpClient()
+
+	url := c.GenerateURL(request.Path)
+	ctx := request.context()
+
+	for attempt := 0; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.WaitContext(ctx); err != nil {
+				return &APIResponse{Retries: attempt}, err
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, request.Method, url, bytes.NewReader(request.Body))
+		if err != nil {
+			return nil, err
+		}
 
-	// Error check.
-	if errs != nil {
-		return r, b, CustomError{Message: "could not perform HTTP POST request", HTTPErrors: errs}
+		for k, v := range request.Header {
+			req.Header[k] = v
+		}
+		req.Header.Set("Api-Token", c.APIToken)
+		if request.Body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		l := c.requestLog(req, request.Body, attempt)
+		c.logRequest(l)
+		start := time.Now()
+
+		r, err := client.Do(req)
+		if err != nil {
+			c.logResponse(ResponseLog{Request: l, Latency: time.Since(start), Err: err})
+			return &APIResponse{Retries: attempt}, err
+		}
+
+		b, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			c.logResponse(ResponseLog{Request: l, StatusCode: r.StatusCode, Latency: time.Since(start), Err: err})
+			return &APIResponse{HTTPResponse: r, Retries: attempt}, err
+		}
+
+		response := ResponseLog{Request: l, StatusCode: r.StatusCode, Latency: time.Since(start)}
+		if c.LogBodies {
+			response.Body = c.redact(b)
+		}
+		c.logResponse(response)
+
+		if r.StatusCode != http.StatusTooManyRequests || attempt >= c.MaxRetries {
+			return &APIResponse{HTTPResponse: r, Body: b, Retries: attempt}, nil
+		}
+
+		t := time.NewTimer(retryDelay(r, attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return &APIResponse{HTTPResponse: r, Body: b, Retries: attempt}, ctx.Err()
+		case <-t.C:
+		}
 	}
+}
 
-	return r, b, nil
+// Returns how long to wait before retrying a rate limited request.  The Retry-After header is used if present,
+// otherwise the delay doubles with each attempt.
+func retryDelay(r *http.Response, attempt int) time.Duration {
+	if s, err := strconv.Atoi(r.Header.Get("Retry-After")); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+
+	return DEFAULT_RETRY_DELAY << uint(attempt)
 }
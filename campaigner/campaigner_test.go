@@ -2,6 +2,7 @@ package campaigner
 
 import (
 	"flag"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
 	"github.com/kelseyhightower/envconfig"
 	"log"
 	"os"
@@ -24,17 +25,41 @@ var (
 	config configSetup
 	C      Campaigner
 	NOW    = time.Now().Format("20060102_150405")
+
+	// testServer is the fake API used when no API token is configured.
+	testServer *actest.Server
 )
 
 func init() {
-	err := envconfig.Process("ac", &config)
-	if err != nil {
-		log.Fatal(err)
+	// Without an API token the tests run against an in-memory fake of the API.
+	if len(os.Getenv("AC_API_TOKEN")) == 0 {
+		testServer = newTestServer()
+		config = configSetup{APIToken: testServer.APIToken, BaseURL: testServer.URL, UnitTestEmail: "unit.test@example.com", UnitTestPhone: "2125551212"}
+		testServer.AddContact(actest.Contact{EmailAddress: config.UnitTestEmail, FirstName: "Unit", LastName: "Test", PhoneNumber: config.UnitTestPhone})
+	} else {
+		err := envconfig.Process("ac", &config)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	C = Campaigner{APIToken: config.APIToken, BaseURL: config.BaseURL}
 }
 
+// Starts a fake API seeded with the data the live tests expect to find in an account.
+func newTestServer() *actest.Server {
+	s := actest.NewServer()
+
+	s.AddTag(actest.Tag{Name: "Leads, cold", Description: "Cold leads"})
+	s.AddTag(actest.Tag{Name: "Onboarding - Account Activated", Description: "Account activated"})
+	s.AddList(actest.List{Name: "Newsletter"})
+	s.AddField(actest.Field{Title: "Company Size"})
+	s.AddField(actest.Field{Title: "Signup Source"})
+	s.AddOrganization(actest.Organization{Name: "Lightsaber Pizza"})
+
+	return s
+}
+
 func TestMain(m *testing.M) {
 	if flag.Parsed() == false {
 		flag.Parse()
@@ -49,14 +74,19 @@ func TestMain(m *testing.M) {
 	}
 
 	// Set filter to group runners.  This allows tests to be run both piecemeal or ordered / "suite".
-	err := flag.Set("test.run", "TestContactSuite|TestTagSuite|TestContactTaggingSuite|TestOrganizationSuite")
+	err := flag.Set("test.run", "TestContactSuite|TestTagSuite|TestContactTaggingSuite|TestOrganizationSuite|TestBulkImportSuite|TestBatchSuite|TestLoggingSuite|TestMiddlewareSuite|TestCacheSuite|TestCassetteSuite|TestServicesSuite|TestContactDetailsSuite|TestACTimeSuite|TestJSONTypesSuite|FuzzInt64json|FuzzNullInt64json|FuzzDecimaljson|FuzzBooljson|TestStrictSuite|TestDoSuite|TestRegistrySuite|TestUserSuite|TestCustomObjectSuite|TestEcommerceSuite|TestEventTrackerSuite|TestSiteTrackingSuite|TestSegmentSuite|TestFormSuite|TestHistorySuite|TestScoreSuite")
 	if err != nil {
 		log.Fatal(err)
 	}
 	l2 := flag.Lookup("test.run")
 	log.Printf("test run? %s\n", l2)
 
-	os.Exit(m.Run())
+	code := m.Run()
+	if testServer != nil {
+		testServer.Close()
+	}
+
+	os.Exit(code)
 }
 
 func TestPrint(t *testing.T) {
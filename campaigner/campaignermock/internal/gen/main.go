@@ -0,0 +1,173 @@
+// Command gen writes mock_gen.go, the fakes of the campaigner service interfaces of package campaignermock.
+//
+// Run it with go generate from the campaignermock directory after changing the interfaces in campaigner.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/henrocdotnet/active-campaigner/campaigner"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// The services to fake, by the name of the fake (the name of the service on campaigner.Campaigner).
+var services = []struct {
+	name  string
+	iface reflect.Type
+}{
+	{"Contacts", reflect.TypeOf((*campaigner.ContactService)(nil)).Elem()},
+	{"Tags", reflect.TypeOf((*campaigner.TagService)(nil)).Elem()},
+	{"Lists", reflect.TypeOf((*campaigner.ListService)(nil)).Elem()},
+	{"Fields", reflect.TypeOf((*campaigner.FieldService)(nil)).Elem()},
+	{"Organizations", reflect.TypeOf((*campaigner.OrganizationService)(nil)).Elem()},
+	{"BulkImports", reflect.TypeOf((*campaigner.BulkImportService)(nil)).Elem()},
+	{"Users", reflect.TypeOf((*campaigner.UserService)(nil)).Elem()},
+	{"Groups", reflect.TypeOf((*campaigner.GroupService)(nil)).Elem()},
+	{"CustomObjects", reflect.TypeOf((*campaigner.CustomObjectService)(nil)).Elem()},
+	{"Ecommerce", reflect.TypeOf((*campaigner.EcommerceService)(nil)).Elem()},
+	{"SiteTracking", reflect.TypeOf((*campaigner.SiteTrackingService)(nil)).Elem()},
+	{"Segments", reflect.TypeOf((*campaigner.SegmentService)(nil)).Elem()},
+	{"Forms", reflect.TypeOf((*campaigner.FormService)(nil)).Elem()},
+	{"History", reflect.TypeOf((*campaigner.HistoryService)(nil)).Elem()},
+	{"Scores", reflect.TypeOf((*campaigner.ScoreService)(nil)).Elem()},
+}
+
+func main() {
+	output := flag.String("o", "mock_gen.go", "output file")
+	flag.Parse()
+
+	var (
+		b       bytes.Buffer
+		imports = map[string]bool{}
+	)
+
+	for _, s := range services {
+		for x := 0; x < s.iface.NumMethod(); x++ {
+			addImports(s.iface.Method(x).Type, imports)
+		}
+
+		// Canned response fields.
+		fmt.Fprintf(&b, "// %s is a fake %s.\n", s.name, s.iface)
+		b.WriteString("// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.\n")
+		fmt.Fprintf(&b, "type %s struct {\n\tRecorder\n\n", s.name)
+		for x := 0; x < s.iface.NumMethod(); x++ {
+			m := s.iface.Method(x)
+			fmt.Fprintf(&b, "\t%sFunc func%s\n", m.Name, signature(m.Type))
+		}
+		b.WriteString("}\n\n")
+
+		// Methods.
+		for x := 0; x < s.iface.NumMethod(); x++ {
+			m := s.iface.Method(x)
+			if m.Type.IsVariadic() {
+				log.Fatalf("%s.%s: variadic methods are not supported", s.iface, m.Name)
+			}
+
+			// Contexts are passed on but not recorded.
+			var args, names, recorded, zeros, results []string
+			for y := 0; y < m.Type.NumIn(); y++ {
+				name := fmt.Sprintf("a%d", y)
+				if m.Type.In(y).String() == "context.Context" {
+					name = "ctx"
+				} else {
+					recorded = append(recorded, name)
+				}
+				args = append(args, fmt.Sprintf("%s %s", name, m.Type.In(y)))
+				names = append(names, name)
+			}
+			for y := 0; y < m.Type.NumOut(); y++ {
+				zeros = append(zeros, fmt.Sprintf("var r%d %s", y, m.Type.Out(y)))
+				results = append(results, fmt.Sprintf("r%d", y))
+			}
+
+			fmt.Fprintf(&b, "// %s records the call and returns the result of %sFunc.\n", m.Name, m.Name)
+			fmt.Fprintf(&b, "func (m *%s) %s(%s) %s {\n", s.name, m.Name, strings.Join(args, ", "), resultList(m.Type))
+			fmt.Fprintf(&b, "\tm.record(%s)\n", strings.Join(append([]string{fmt.Sprintf("%q", m.Name)}, recorded...), ", "))
+			fmt.Fprintf(&b, "\tif f := m.%sFunc; f != nil {\n\t\treturn f(%s)\n\t}\n", m.Name, strings.Join(names, ", "))
+			fmt.Fprintf(&b, "\t%s\n\treturn %s\n}\n\n", strings.Join(zeros, "\n\t"), strings.Join(results, ", "))
+		}
+	}
+
+	// Compile time checks.
+	b.WriteString("// Compile time checks that the fakes satisfy their interfaces.\nvar (\n")
+	for _, s := range services {
+		fmt.Fprintf(&b, "\t_ %s = (*%s)(nil)\n", s.iface, s.name)
+	}
+	b.WriteString(")\n")
+
+	var paths []string
+	for p := range imports {
+		paths = append(paths, fmt.Sprintf("%q", p))
+	}
+	sort.Strings(paths)
+
+	var h bytes.Buffer
+	h.WriteString("// Code generated by internal/gen; DO NOT EDIT.\n\n")
+	h.WriteString("package campaignermock\n\n")
+	fmt.Fprintf(&h, "import (\n\t%s\n)\n\n", strings.Join(paths, "\n\t"))
+
+	src, err := format.Source(append(h.Bytes(), b.Bytes()...))
+	if err != nil {
+		log.Fatalf("could not format generated code: %s", err)
+	}
+
+	if err = ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Adds the packages of the named types used by a type to imports.
+func addImports(t reflect.Type, imports map[string]bool) {
+	if len(t.Name()) > 0 {
+		if len(t.PkgPath()) > 0 {
+			imports[t.PkgPath()] = true
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+		addImports(t.Elem(), imports)
+	case reflect.Map:
+		addImports(t.Key(), imports)
+		addImports(t.Elem(), imports)
+	case reflect.Func:
+		for x := 0; x < t.NumIn(); x++ {
+			addImports(t.In(x), imports)
+		}
+		for x := 0; x < t.NumOut(); x++ {
+			addImports(t.Out(x), imports)
+		}
+	}
+}
+
+// Returns the parameter and result list of a function type, e.g. (context.Context, int64) (campaigner.ResponseTagRead,
+// error).
+func signature(t reflect.Type) string {
+	var in []string
+	for x := 0; x < t.NumIn(); x++ {
+		in = append(in, t.In(x).String())
+	}
+
+	return fmt.Sprintf("(%s) %s", strings.Join(in, ", "), resultList(t))
+}
+
+// Returns the result list of a function type.
+func resultList(t reflect.Type) string {
+	var out []string
+	for x := 0; x < t.NumOut(); x++ {
+		out = append(out, t.Out(x).String())
+	}
+
+	if len(out) == 1 {
+		return out[0]
+	}
+
+	return "(" + strings.Join(out, ", ") + ")"
+}
@@ -0,0 +1,67 @@
+// Package campaignermock provides fakes of the campaigner service interfaces for testing code that depends on
+// campaigner.  The fakes record every call and return canned responses.
+//
+//	tags := &campaignermock.Tags{}
+//	tags.ReadFunc = func(ctx context.Context, id int64) (campaigner.ResponseTagRead, error) {
+//		return campaigner.ResponseTagRead{Tag: campaigner.Tag{ID: campaigner.Int64json(id), Name: "Customer"}}, nil
+//	}
+//	service := NewService(tags)  // Accepts a campaigner.TagService, e.g. c.Tags.
+//	...
+//	calls := tags.CallsTo("Read")
+package campaignermock
+
+//go:generate go run ./internal/gen
+
+import "sync"
+
+// Call holds a recorded call.  Args holds the arguments after the context.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recorder records the calls of a fake.  Every fake embeds one, fakes are safe for concurrent use once their functions
+// are set.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Records a call.
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call{}, r.calls...)
+}
+
+// CallsTo returns the recorded calls of a method, e.g. "Read".
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var l []Call
+	for _, c := range r.calls {
+		if c.Method == method {
+			l = append(l, c)
+		}
+	}
+
+	return l
+}
+
+// Reset forgets all recorded calls.  Canned responses are kept.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
@@ -0,0 +1,1268 @@
+// Code generated by internal/gen; DO NOT EDIT.
+
+package campaignermock
+
+import (
+	"context"
+	"github.com/henrocdotnet/active-campaigner/campaigner"
+	"time"
+)
+
+// Contacts is a fake campaigner.ContactService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Contacts struct {
+	Recorder
+
+	AddTagFunc           func(context.Context, campaigner.RequestContactTagCreate) (campaigner.ResponseContactTagCreate, error)
+	CreateFunc           func(context.Context, campaigner.Contact) (campaigner.ResponseContactCreate, error)
+	DeleteFunc           func(context.Context, int64) error
+	DeleteFieldValueFunc func(context.Context, int64) error
+	FindFunc             func(context.Context, string) (campaigner.ResponseContactList, error)
+	ListFunc             func(context.Context, int, int) (campaigner.ResponseContactList, error)
+	ReadFunc             func(context.Context, int64) (campaigner.ResponseContactRead, error)
+	ReadDetailedFunc     func(context.Context, int64) (campaigner.ContactDetails, error)
+	RemoveTagFunc        func(context.Context, int64) error
+	TagsFunc             func(context.Context, int64) (campaigner.ResponseContactTagRead, error)
+	UpdateFunc           func(context.Context, int64, campaigner.RequestContactUpdate) (campaigner.ResponseContactUpdate, error)
+	UpdateFieldFunc      func(context.Context, int64, int64, string) (campaigner.ResponseContactFieldUpdate, error)
+}
+
+// AddTag records the call and returns the result of AddTagFunc.
+func (m *Contacts) AddTag(ctx context.Context, a1 campaigner.RequestContactTagCreate) (campaigner.ResponseContactTagCreate, error) {
+	m.record("AddTag", a1)
+	if f := m.AddTagFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseContactTagCreate
+	var r1 error
+	return r0, r1
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *Contacts) Create(ctx context.Context, a1 campaigner.Contact) (campaigner.ResponseContactCreate, error) {
+	m.record("Create", a1)
+	if f := m.CreateFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseContactCreate
+	var r1 error
+	return r0, r1
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *Contacts) Delete(ctx context.Context, a1 int64) error {
+	m.record("Delete", a1)
+	if f := m.DeleteFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// DeleteFieldValue records the call and returns the result of DeleteFieldValueFunc.
+func (m *Contacts) DeleteFieldValue(ctx context.Context, a1 int64) error {
+	m.record("DeleteFieldValue", a1)
+	if f := m.DeleteFieldValueFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// Find records the call and returns the result of FindFunc.
+func (m *Contacts) Find(ctx context.Context, a1 string) (campaigner.ResponseContactList, error) {
+	m.record("Find", a1)
+	if f := m.FindFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseContactList
+	var r1 error
+	return r0, r1
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Contacts) List(ctx context.Context, a1 int, a2 int) (campaigner.ResponseContactList, error) {
+	m.record("List", a1, a2)
+	if f := m.ListFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseContactList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Contacts) Read(ctx context.Context, a1 int64) (campaigner.ResponseContactRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseContactRead
+	var r1 error
+	return r0, r1
+}
+
+// ReadDetailed records the call and returns the result of ReadDetailedFunc.
+func (m *Contacts) ReadDetailed(ctx context.Context, a1 int64) (campaigner.ContactDetails, error) {
+	m.record("ReadDetailed", a1)
+	if f := m.ReadDetailedFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ContactDetails
+	var r1 error
+	return r0, r1
+}
+
+// RemoveTag records the call and returns the result of RemoveTagFunc.
+func (m *Contacts) RemoveTag(ctx context.Context, a1 int64) error {
+	m.record("RemoveTag", a1)
+	if f := m.RemoveTagFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// Tags records the call and returns the result of TagsFunc.
+func (m *Contacts) Tags(ctx context.Context, a1 int64) (campaigner.ResponseContactTagRead, error) {
+	m.record("Tags", a1)
+	if f := m.TagsFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseContactTagRead
+	var r1 error
+	return r0, r1
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *Contacts) Update(ctx context.Context, a1 int64, a2 campaigner.RequestContactUpdate) (campaigner.ResponseContactUpdate, error) {
+	m.record("Update", a1, a2)
+	if f := m.UpdateFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseContactUpdate
+	var r1 error
+	return r0, r1
+}
+
+// UpdateField records the call and returns the result of UpdateFieldFunc.
+func (m *Contacts) UpdateField(ctx context.Context, a1 int64, a2 int64, a3 string) (campaigner.ResponseContactFieldUpdate, error) {
+	m.record("UpdateField", a1, a2, a3)
+	if f := m.UpdateFieldFunc; f != nil {
+		return f(ctx, a1, a2, a3)
+	}
+	var r0 campaigner.ResponseContactFieldUpdate
+	var r1 error
+	return r0, r1
+}
+
+// Tags is a fake campaigner.TagService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Tags struct {
+	Recorder
+
+	CreateFunc func(context.Context, campaigner.Tag) (campaigner.ResponseTagCreate, error)
+	DeleteFunc func(context.Context, int64) error
+	FindFunc   func(context.Context, string) (campaigner.ResponseTagList, error)
+	ListFunc   func(context.Context) (campaigner.ResponseTagList, error)
+	ReadFunc   func(context.Context, int64) (campaigner.ResponseTagRead, error)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *Tags) Create(ctx context.Context, a1 campaigner.Tag) (campaigner.ResponseTagCreate, error) {
+	m.record("Create", a1)
+	if f := m.CreateFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseTagCreate
+	var r1 error
+	return r0, r1
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *Tags) Delete(ctx context.Context, a1 int64) error {
+	m.record("Delete", a1)
+	if f := m.DeleteFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// Find records the call and returns the result of FindFunc.
+func (m *Tags) Find(ctx context.Context, a1 string) (campaigner.ResponseTagList, error) {
+	m.record("Find", a1)
+	if f := m.FindFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseTagList
+	var r1 error
+	return r0, r1
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Tags) List(ctx context.Context) (campaigner.ResponseTagList, error) {
+	m.record("List")
+	if f := m.ListFunc; f != nil {
+		return f(ctx)
+	}
+	var r0 campaigner.ResponseTagList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Tags) Read(ctx context.Context, a1 int64) (campaigner.ResponseTagRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseTagRead
+	var r1 error
+	return r0, r1
+}
+
+// Lists is a fake campaigner.ListService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Lists struct {
+	Recorder
+
+	AddContactFunc func(context.Context, int64, int64) (campaigner.ResponseListContactAdd, error)
+	ListFunc       func(context.Context) (campaigner.ResponseListList, error)
+	ReadFunc       func(context.Context, int64) (campaigner.ResponseListRead, error)
+}
+
+// AddContact records the call and returns the result of AddContactFunc.
+func (m *Lists) AddContact(ctx context.Context, a1 int64, a2 int64) (campaigner.ResponseListContactAdd, error) {
+	m.record("AddContact", a1, a2)
+	if f := m.AddContactFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseListContactAdd
+	var r1 error
+	return r0, r1
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Lists) List(ctx context.Context) (campaigner.ResponseListList, error) {
+	m.record("List")
+	if f := m.ListFunc; f != nil {
+		return f(ctx)
+	}
+	var r0 campaigner.ResponseListList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Lists) Read(ctx context.Context, a1 int64) (campaigner.ResponseListRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseListRead
+	var r1 error
+	return r0, r1
+}
+
+// Fields is a fake campaigner.FieldService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Fields struct {
+	Recorder
+
+	ListFunc func(context.Context) (campaigner.ResponseFieldList, error)
+	ReadFunc func(context.Context, int64) (campaigner.ResponseFieldRead, error)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Fields) List(ctx context.Context) (campaigner.ResponseFieldList, error) {
+	m.record("List")
+	if f := m.ListFunc; f != nil {
+		return f(ctx)
+	}
+	var r0 campaigner.ResponseFieldList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Fields) Read(ctx context.Context, a1 int64) (campaigner.ResponseFieldRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseFieldRead
+	var r1 error
+	return r0, r1
+}
+
+// Organizations is a fake campaigner.OrganizationService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Organizations struct {
+	Recorder
+
+	CreateFunc func(context.Context, campaigner.Organization) (campaigner.ResponseOrganizationCreate, error)
+	DeleteFunc func(context.Context, int64) error
+	FindFunc   func(context.Context, string) (campaigner.ResponseOrganizationList, error)
+	ListFunc   func(context.Context, int, int) (campaigner.ResponseOrganizationList, error)
+	ReadFunc   func(context.Context, int64) (campaigner.ResponseOrganizationRead, error)
+	UpdateFunc func(context.Context, int64, campaigner.RequestOrganizationUpdate) (campaigner.ResponseOrganizationUpdate, error)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *Organizations) Create(ctx context.Context, a1 campaigner.Organization) (campaigner.ResponseOrganizationCreate, error) {
+	m.record("Create", a1)
+	if f := m.CreateFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseOrganizationCreate
+	var r1 error
+	return r0, r1
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *Organizations) Delete(ctx context.Context, a1 int64) error {
+	m.record("Delete", a1)
+	if f := m.DeleteFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// Find records the call and returns the result of FindFunc.
+func (m *Organizations) Find(ctx context.Context, a1 string) (campaigner.ResponseOrganizationList, error) {
+	m.record("Find", a1)
+	if f := m.FindFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseOrganizationList
+	var r1 error
+	return r0, r1
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Organizations) List(ctx context.Context, a1 int, a2 int) (campaigner.ResponseOrganizationList, error) {
+	m.record("List", a1, a2)
+	if f := m.ListFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseOrganizationList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Organizations) Read(ctx context.Context, a1 int64) (campaigner.ResponseOrganizationRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseOrganizationRead
+	var r1 error
+	return r0, r1
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *Organizations) Update(ctx context.Context, a1 int64, a2 campaigner.RequestOrganizationUpdate) (campaigner.ResponseOrganizationUpdate, error) {
+	m.record("Update", a1, a2)
+	if f := m.UpdateFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseOrganizationUpdate
+	var r1 error
+	return r0, r1
+}
+
+// BulkImports is a fake campaigner.BulkImportService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type BulkImports struct {
+	Recorder
+
+	CreateFunc func(context.Context, campaigner.RequestBulkImport) (campaigner.BulkImportResult, error)
+	InfoFunc   func(context.Context, string) (campaigner.ResponseBulkImportInfo, error)
+	ListFunc   func(context.Context) (campaigner.ResponseBulkImportList, error)
+	WaitFunc   func(context.Context, string, time.Duration) (campaigner.ResponseBulkImportInfo, error)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *BulkImports) Create(ctx context.Context, a1 campaigner.RequestBulkImport) (campaigner.BulkImportResult, error) {
+	m.record("Create", a1)
+	if f := m.CreateFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.BulkImportResult
+	var r1 error
+	return r0, r1
+}
+
+// Info records the call and returns the result of InfoFunc.
+func (m *BulkImports) Info(ctx context.Context, a1 string) (campaigner.ResponseBulkImportInfo, error) {
+	m.record("Info", a1)
+	if f := m.InfoFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseBulkImportInfo
+	var r1 error
+	return r0, r1
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *BulkImports) List(ctx context.Context) (campaigner.ResponseBulkImportList, error) {
+	m.record("List")
+	if f := m.ListFunc; f != nil {
+		return f(ctx)
+	}
+	var r0 campaigner.ResponseBulkImportList
+	var r1 error
+	return r0, r1
+}
+
+// Wait records the call and returns the result of WaitFunc.
+func (m *BulkImports) Wait(ctx context.Context, a1 string, a2 time.Duration) (campaigner.ResponseBulkImportInfo, error) {
+	m.record("Wait", a1, a2)
+	if f := m.WaitFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseBulkImportInfo
+	var r1 error
+	return r0, r1
+}
+
+// Users is a fake campaigner.UserService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Users struct {
+	Recorder
+
+	CreateFunc         func(context.Context, campaigner.RequestUserCreate) (campaigner.ResponseUserRead, error)
+	DeleteFunc         func(context.Context, int64) error
+	FindByEmailFunc    func(context.Context, string) (campaigner.ResponseUserRead, error)
+	FindByUsernameFunc func(context.Context, string) (campaigner.ResponseUserRead, error)
+	ListFunc           func(context.Context, int, int) (campaigner.ResponseUserList, error)
+	MeFunc             func(context.Context) (campaigner.ResponseUserRead, error)
+	ReadFunc           func(context.Context, int64) (campaigner.ResponseUserRead, error)
+	UpdateFunc         func(context.Context, int64, campaigner.RequestUserUpdate) (campaigner.ResponseUserRead, error)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *Users) Create(ctx context.Context, a1 campaigner.RequestUserCreate) (campaigner.ResponseUserRead, error) {
+	m.record("Create", a1)
+	if f := m.CreateFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseUserRead
+	var r1 error
+	return r0, r1
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *Users) Delete(ctx context.Context, a1 int64) error {
+	m.record("Delete", a1)
+	if f := m.DeleteFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// FindByEmail records the call and returns the result of FindByEmailFunc.
+func (m *Users) FindByEmail(ctx context.Context, a1 string) (campaigner.ResponseUserRead, error) {
+	m.record("FindByEmail", a1)
+	if f := m.FindByEmailFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseUserRead
+	var r1 error
+	return r0, r1
+}
+
+// FindByUsername records the call and returns the result of FindByUsernameFunc.
+func (m *Users) FindByUsername(ctx context.Context, a1 string) (campaigner.ResponseUserRead, error) {
+	m.record("FindByUsername", a1)
+	if f := m.FindByUsernameFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseUserRead
+	var r1 error
+	return r0, r1
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Users) List(ctx context.Context, a1 int, a2 int) (campaigner.ResponseUserList, error) {
+	m.record("List", a1, a2)
+	if f := m.ListFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseUserList
+	var r1 error
+	return r0, r1
+}
+
+// Me records the call and returns the result of MeFunc.
+func (m *Users) Me(ctx context.Context) (campaigner.ResponseUserRead, error) {
+	m.record("Me")
+	if f := m.MeFunc; f != nil {
+		return f(ctx)
+	}
+	var r0 campaigner.ResponseUserRead
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Users) Read(ctx context.Context, a1 int64) (campaigner.ResponseUserRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseUserRead
+	var r1 error
+	return r0, r1
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *Users) Update(ctx context.Context, a1 int64, a2 campaigner.RequestUserUpdate) (campaigner.ResponseUserRead, error) {
+	m.record("Update", a1, a2)
+	if f := m.UpdateFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseUserRead
+	var r1 error
+	return r0, r1
+}
+
+// Groups is a fake campaigner.GroupService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Groups struct {
+	Recorder
+
+	CreateFunc func(context.Context, campaigner.Group) (campaigner.ResponseGroupRead, error)
+	DeleteFunc func(context.Context, int64) error
+	ListFunc   func(context.Context, int, int) (campaigner.ResponseGroupList, error)
+	ReadFunc   func(context.Context, int64) (campaigner.ResponseGroupRead, error)
+	UpdateFunc func(context.Context, int64, campaigner.Group) (campaigner.ResponseGroupRead, error)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *Groups) Create(ctx context.Context, a1 campaigner.Group) (campaigner.ResponseGroupRead, error) {
+	m.record("Create", a1)
+	if f := m.CreateFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseGroupRead
+	var r1 error
+	return r0, r1
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *Groups) Delete(ctx context.Context, a1 int64) error {
+	m.record("Delete", a1)
+	if f := m.DeleteFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Groups) List(ctx context.Context, a1 int, a2 int) (campaigner.ResponseGroupList, error) {
+	m.record("List", a1, a2)
+	if f := m.ListFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseGroupList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Groups) Read(ctx context.Context, a1 int64) (campaigner.ResponseGroupRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseGroupRead
+	var r1 error
+	return r0, r1
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *Groups) Update(ctx context.Context, a1 int64, a2 campaigner.Group) (campaigner.ResponseGroupRead, error) {
+	m.record("Update", a1, a2)
+	if f := m.UpdateFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseGroupRead
+	var r1 error
+	return r0, r1
+}
+
+// CustomObjects is a fake campaigner.CustomObjectService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type CustomObjects struct {
+	Recorder
+
+	CreateSchemaFunc             func(context.Context, campaigner.CustomObjectSchema) (campaigner.ResponseCustomObjectSchemaRead, error)
+	DeleteRecordFunc             func(context.Context, string, string) error
+	DeleteRecordByExternalIDFunc func(context.Context, string, string) error
+	DeleteSchemaFunc             func(context.Context, string) error
+	ListRecordsFunc              func(context.Context, string, int, int) (campaigner.ResponseCustomObjectRecordList, error)
+	ListSchemasFunc              func(context.Context, int, int) (campaigner.ResponseCustomObjectSchemaList, error)
+	ReadRecordFunc               func(context.Context, string, string) (campaigner.ResponseCustomObjectRecordRead, error)
+	ReadRecordByExternalIDFunc   func(context.Context, string, string) (campaigner.ResponseCustomObjectRecordRead, error)
+	ReadSchemaFunc               func(context.Context, string) (campaigner.ResponseCustomObjectSchemaRead, error)
+	UpdateSchemaFunc             func(context.Context, string, campaigner.CustomObjectSchema) (campaigner.ResponseCustomObjectSchemaRead, error)
+	UpsertRecordFunc             func(context.Context, string, campaigner.CustomObjectRecord) (campaigner.ResponseCustomObjectRecordRead, error)
+}
+
+// CreateSchema records the call and returns the result of CreateSchemaFunc.
+func (m *CustomObjects) CreateSchema(ctx context.Context, a1 campaigner.CustomObjectSchema) (campaigner.ResponseCustomObjectSchemaRead, error) {
+	m.record("CreateSchema", a1)
+	if f := m.CreateSchemaFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseCustomObjectSchemaRead
+	var r1 error
+	return r0, r1
+}
+
+// DeleteRecord records the call and returns the result of DeleteRecordFunc.
+func (m *CustomObjects) DeleteRecord(ctx context.Context, a1 string, a2 string) error {
+	m.record("DeleteRecord", a1, a2)
+	if f := m.DeleteRecordFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 error
+	return r0
+}
+
+// DeleteRecordByExternalID records the call and returns the result of DeleteRecordByExternalIDFunc.
+func (m *CustomObjects) DeleteRecordByExternalID(ctx context.Context, a1 string, a2 string) error {
+	m.record("DeleteRecordByExternalID", a1, a2)
+	if f := m.DeleteRecordByExternalIDFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 error
+	return r0
+}
+
+// DeleteSchema records the call and returns the result of DeleteSchemaFunc.
+func (m *CustomObjects) DeleteSchema(ctx context.Context, a1 string) error {
+	m.record("DeleteSchema", a1)
+	if f := m.DeleteSchemaFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// ListRecords records the call and returns the result of ListRecordsFunc.
+func (m *CustomObjects) ListRecords(ctx context.Context, a1 string, a2 int, a3 int) (campaigner.ResponseCustomObjectRecordList, error) {
+	m.record("ListRecords", a1, a2, a3)
+	if f := m.ListRecordsFunc; f != nil {
+		return f(ctx, a1, a2, a3)
+	}
+	var r0 campaigner.ResponseCustomObjectRecordList
+	var r1 error
+	return r0, r1
+}
+
+// ListSchemas records the call and returns the result of ListSchemasFunc.
+func (m *CustomObjects) ListSchemas(ctx context.Context, a1 int, a2 int) (campaigner.ResponseCustomObjectSchemaList, error) {
+	m.record("ListSchemas", a1, a2)
+	if f := m.ListSchemasFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseCustomObjectSchemaList
+	var r1 error
+	return r0, r1
+}
+
+// ReadRecord records the call and returns the result of ReadRecordFunc.
+func (m *CustomObjects) ReadRecord(ctx context.Context, a1 string, a2 string) (campaigner.ResponseCustomObjectRecordRead, error) {
+	m.record("ReadRecord", a1, a2)
+	if f := m.ReadRecordFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseCustomObjectRecordRead
+	var r1 error
+	return r0, r1
+}
+
+// ReadRecordByExternalID records the call and returns the result of ReadRecordByExternalIDFunc.
+func (m *CustomObjects) ReadRecordByExternalID(ctx context.Context, a1 string, a2 string) (campaigner.ResponseCustomObjectRecordRead, error) {
+	m.record("ReadRecordByExternalID", a1, a2)
+	if f := m.ReadRecordByExternalIDFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseCustomObjectRecordRead
+	var r1 error
+	return r0, r1
+}
+
+// ReadSchema records the call and returns the result of ReadSchemaFunc.
+func (m *CustomObjects) ReadSchema(ctx context.Context, a1 string) (campaigner.ResponseCustomObjectSchemaRead, error) {
+	m.record("ReadSchema", a1)
+	if f := m.ReadSchemaFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseCustomObjectSchemaRead
+	var r1 error
+	return r0, r1
+}
+
+// UpdateSchema records the call and returns the result of UpdateSchemaFunc.
+func (m *CustomObjects) UpdateSchema(ctx context.Context, a1 string, a2 campaigner.CustomObjectSchema) (campaigner.ResponseCustomObjectSchemaRead, error) {
+	m.record("UpdateSchema", a1, a2)
+	if f := m.UpdateSchemaFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseCustomObjectSchemaRead
+	var r1 error
+	return r0, r1
+}
+
+// UpsertRecord records the call and returns the result of UpsertRecordFunc.
+func (m *CustomObjects) UpsertRecord(ctx context.Context, a1 string, a2 campaigner.CustomObjectRecord) (campaigner.ResponseCustomObjectRecordRead, error) {
+	m.record("UpsertRecord", a1, a2)
+	if f := m.UpsertRecordFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseCustomObjectRecordRead
+	var r1 error
+	return r0, r1
+}
+
+// Ecommerce is a fake campaigner.EcommerceService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Ecommerce struct {
+	Recorder
+
+	CreateConnectionFunc  func(context.Context, campaigner.EcomConnection) (campaigner.ResponseEcomConnectionRead, error)
+	CreateCustomerFunc    func(context.Context, campaigner.EcomCustomer) (campaigner.ResponseEcomCustomerRead, error)
+	CreateOrderFunc       func(context.Context, campaigner.EcomOrder) (campaigner.ResponseEcomOrderRead, error)
+	DeleteConnectionFunc  func(context.Context, int64) error
+	DeleteCustomerFunc    func(context.Context, int64) error
+	DeleteOrderFunc       func(context.Context, int64) error
+	FindAbandonedCartFunc func(context.Context, int64, string) (campaigner.EcomOrder, error)
+	FindCustomerFunc      func(context.Context, int64, string) (campaigner.EcomCustomer, error)
+	FindOrderFunc         func(context.Context, int64, string) (campaigner.EcomOrder, error)
+	ListConnectionsFunc   func(context.Context, int, int) (campaigner.ResponseEcomConnectionList, error)
+	ListCustomersFunc     func(context.Context, int, int) (campaigner.ResponseEcomCustomerList, error)
+	ListOrdersFunc        func(context.Context, int, int) (campaigner.ResponseEcomOrderList, error)
+	OrderProductsFunc     func(context.Context, int64) (campaigner.ResponseEcomOrderProductList, error)
+	ReadConnectionFunc    func(context.Context, int64) (campaigner.ResponseEcomConnectionRead, error)
+	ReadCustomerFunc      func(context.Context, int64) (campaigner.ResponseEcomCustomerRead, error)
+	ReadOrderFunc         func(context.Context, int64) (campaigner.ResponseEcomOrderRead, error)
+	UpdateConnectionFunc  func(context.Context, int64, campaigner.EcomConnection) (campaigner.ResponseEcomConnectionRead, error)
+	UpdateCustomerFunc    func(context.Context, int64, campaigner.EcomCustomer) (campaigner.ResponseEcomCustomerRead, error)
+	UpdateOrderFunc       func(context.Context, int64, campaigner.EcomOrder) (campaigner.ResponseEcomOrderRead, error)
+}
+
+// CreateConnection records the call and returns the result of CreateConnectionFunc.
+func (m *Ecommerce) CreateConnection(ctx context.Context, a1 campaigner.EcomConnection) (campaigner.ResponseEcomConnectionRead, error) {
+	m.record("CreateConnection", a1)
+	if f := m.CreateConnectionFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseEcomConnectionRead
+	var r1 error
+	return r0, r1
+}
+
+// CreateCustomer records the call and returns the result of CreateCustomerFunc.
+func (m *Ecommerce) CreateCustomer(ctx context.Context, a1 campaigner.EcomCustomer) (campaigner.ResponseEcomCustomerRead, error) {
+	m.record("CreateCustomer", a1)
+	if f := m.CreateCustomerFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseEcomCustomerRead
+	var r1 error
+	return r0, r1
+}
+
+// CreateOrder records the call and returns the result of CreateOrderFunc.
+func (m *Ecommerce) CreateOrder(ctx context.Context, a1 campaigner.EcomOrder) (campaigner.ResponseEcomOrderRead, error) {
+	m.record("CreateOrder", a1)
+	if f := m.CreateOrderFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseEcomOrderRead
+	var r1 error
+	return r0, r1
+}
+
+// DeleteConnection records the call and returns the result of DeleteConnectionFunc.
+func (m *Ecommerce) DeleteConnection(ctx context.Context, a1 int64) error {
+	m.record("DeleteConnection", a1)
+	if f := m.DeleteConnectionFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// DeleteCustomer records the call and returns the result of DeleteCustomerFunc.
+func (m *Ecommerce) DeleteCustomer(ctx context.Context, a1 int64) error {
+	m.record("DeleteCustomer", a1)
+	if f := m.DeleteCustomerFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// DeleteOrder records the call and returns the result of DeleteOrderFunc.
+func (m *Ecommerce) DeleteOrder(ctx context.Context, a1 int64) error {
+	m.record("DeleteOrder", a1)
+	if f := m.DeleteOrderFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// FindAbandonedCart records the call and returns the result of FindAbandonedCartFunc.
+func (m *Ecommerce) FindAbandonedCart(ctx context.Context, a1 int64, a2 string) (campaigner.EcomOrder, error) {
+	m.record("FindAbandonedCart", a1, a2)
+	if f := m.FindAbandonedCartFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.EcomOrder
+	var r1 error
+	return r0, r1
+}
+
+// FindCustomer records the call and returns the result of FindCustomerFunc.
+func (m *Ecommerce) FindCustomer(ctx context.Context, a1 int64, a2 string) (campaigner.EcomCustomer, error) {
+	m.record("FindCustomer", a1, a2)
+	if f := m.FindCustomerFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.EcomCustomer
+	var r1 error
+	return r0, r1
+}
+
+// FindOrder records the call and returns the result of FindOrderFunc.
+func (m *Ecommerce) FindOrder(ctx context.Context, a1 int64, a2 string) (campaigner.EcomOrder, error) {
+	m.record("FindOrder", a1, a2)
+	if f := m.FindOrderFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.EcomOrder
+	var r1 error
+	return r0, r1
+}
+
+// ListConnections records the call and returns the result of ListConnectionsFunc.
+func (m *Ecommerce) ListConnections(ctx context.Context, a1 int, a2 int) (campaigner.ResponseEcomConnectionList, error) {
+	m.record("ListConnections", a1, a2)
+	if f := m.ListConnectionsFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseEcomConnectionList
+	var r1 error
+	return r0, r1
+}
+
+// ListCustomers records the call and returns the result of ListCustomersFunc.
+func (m *Ecommerce) ListCustomers(ctx context.Context, a1 int, a2 int) (campaigner.ResponseEcomCustomerList, error) {
+	m.record("ListCustomers", a1, a2)
+	if f := m.ListCustomersFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseEcomCustomerList
+	var r1 error
+	return r0, r1
+}
+
+// ListOrders records the call and returns the result of ListOrdersFunc.
+func (m *Ecommerce) ListOrders(ctx context.Context, a1 int, a2 int) (campaigner.ResponseEcomOrderList, error) {
+	m.record("ListOrders", a1, a2)
+	if f := m.ListOrdersFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseEcomOrderList
+	var r1 error
+	return r0, r1
+}
+
+// OrderProducts records the call and returns the result of OrderProductsFunc.
+func (m *Ecommerce) OrderProducts(ctx context.Context, a1 int64) (campaigner.ResponseEcomOrderProductList, error) {
+	m.record("OrderProducts", a1)
+	if f := m.OrderProductsFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseEcomOrderProductList
+	var r1 error
+	return r0, r1
+}
+
+// ReadConnection records the call and returns the result of ReadConnectionFunc.
+func (m *Ecommerce) ReadConnection(ctx context.Context, a1 int64) (campaigner.ResponseEcomConnectionRead, error) {
+	m.record("ReadConnection", a1)
+	if f := m.ReadConnectionFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseEcomConnectionRead
+	var r1 error
+	return r0, r1
+}
+
+// ReadCustomer records the call and returns the result of ReadCustomerFunc.
+func (m *Ecommerce) ReadCustomer(ctx context.Context, a1 int64) (campaigner.ResponseEcomCustomerRead, error) {
+	m.record("ReadCustomer", a1)
+	if f := m.ReadCustomerFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseEcomCustomerRead
+	var r1 error
+	return r0, r1
+}
+
+// ReadOrder records the call and returns the result of ReadOrderFunc.
+func (m *Ecommerce) ReadOrder(ctx context.Context, a1 int64) (campaigner.ResponseEcomOrderRead, error) {
+	m.record("ReadOrder", a1)
+	if f := m.ReadOrderFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseEcomOrderRead
+	var r1 error
+	return r0, r1
+}
+
+// UpdateConnection records the call and returns the result of UpdateConnectionFunc.
+func (m *Ecommerce) UpdateConnection(ctx context.Context, a1 int64, a2 campaigner.EcomConnection) (campaigner.ResponseEcomConnectionRead, error) {
+	m.record("UpdateConnection", a1, a2)
+	if f := m.UpdateConnectionFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseEcomConnectionRead
+	var r1 error
+	return r0, r1
+}
+
+// UpdateCustomer records the call and returns the result of UpdateCustomerFunc.
+func (m *Ecommerce) UpdateCustomer(ctx context.Context, a1 int64, a2 campaigner.EcomCustomer) (campaigner.ResponseEcomCustomerRead, error) {
+	m.record("UpdateCustomer", a1, a2)
+	if f := m.UpdateCustomerFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseEcomCustomerRead
+	var r1 error
+	return r0, r1
+}
+
+// UpdateOrder records the call and returns the result of UpdateOrderFunc.
+func (m *Ecommerce) UpdateOrder(ctx context.Context, a1 int64, a2 campaigner.EcomOrder) (campaigner.ResponseEcomOrderRead, error) {
+	m.record("UpdateOrder", a1, a2)
+	if f := m.UpdateOrderFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseEcomOrderRead
+	var r1 error
+	return r0, r1
+}
+
+// SiteTracking is a fake campaigner.SiteTrackingService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type SiteTracking struct {
+	Recorder
+
+	AddDomainFunc    func(context.Context, string) (campaigner.ResponseSiteTrackingDomain, error)
+	ContactLogsFunc  func(context.Context, int64) (campaigner.ResponseTrackingLogList, error)
+	EnabledFunc      func(context.Context) (bool, error)
+	ListDomainsFunc  func(context.Context, int, int) (campaigner.ResponseSiteTrackingDomainList, error)
+	LogsFunc         func(context.Context, string) (campaigner.ResponseTrackingLogList, error)
+	RemoveDomainFunc func(context.Context, string) error
+	SetEnabledFunc   func(context.Context, bool) error
+}
+
+// AddDomain records the call and returns the result of AddDomainFunc.
+func (m *SiteTracking) AddDomain(ctx context.Context, a1 string) (campaigner.ResponseSiteTrackingDomain, error) {
+	m.record("AddDomain", a1)
+	if f := m.AddDomainFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseSiteTrackingDomain
+	var r1 error
+	return r0, r1
+}
+
+// ContactLogs records the call and returns the result of ContactLogsFunc.
+func (m *SiteTracking) ContactLogs(ctx context.Context, a1 int64) (campaigner.ResponseTrackingLogList, error) {
+	m.record("ContactLogs", a1)
+	if f := m.ContactLogsFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseTrackingLogList
+	var r1 error
+	return r0, r1
+}
+
+// Enabled records the call and returns the result of EnabledFunc.
+func (m *SiteTracking) Enabled(ctx context.Context) (bool, error) {
+	m.record("Enabled")
+	if f := m.EnabledFunc; f != nil {
+		return f(ctx)
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+// ListDomains records the call and returns the result of ListDomainsFunc.
+func (m *SiteTracking) ListDomains(ctx context.Context, a1 int, a2 int) (campaigner.ResponseSiteTrackingDomainList, error) {
+	m.record("ListDomains", a1, a2)
+	if f := m.ListDomainsFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseSiteTrackingDomainList
+	var r1 error
+	return r0, r1
+}
+
+// Logs records the call and returns the result of LogsFunc.
+func (m *SiteTracking) Logs(ctx context.Context, a1 string) (campaigner.ResponseTrackingLogList, error) {
+	m.record("Logs", a1)
+	if f := m.LogsFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseTrackingLogList
+	var r1 error
+	return r0, r1
+}
+
+// RemoveDomain records the call and returns the result of RemoveDomainFunc.
+func (m *SiteTracking) RemoveDomain(ctx context.Context, a1 string) error {
+	m.record("RemoveDomain", a1)
+	if f := m.RemoveDomainFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// SetEnabled records the call and returns the result of SetEnabledFunc.
+func (m *SiteTracking) SetEnabled(ctx context.Context, a1 bool) error {
+	m.record("SetEnabled", a1)
+	if f := m.SetEnabledFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 error
+	return r0
+}
+
+// Segments is a fake campaigner.SegmentService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Segments struct {
+	Recorder
+
+	ContactsFunc     func(context.Context, int64, int) *campaigner.SegmentContactIterator
+	ListFunc         func(context.Context, int, int) (campaigner.ResponseSegmentList, error)
+	ListContactsFunc func(context.Context, int64, int, int) (campaigner.ResponseContactList, error)
+	ReadFunc         func(context.Context, int64) (campaigner.ResponseSegmentRead, error)
+}
+
+// Contacts records the call and returns the result of ContactsFunc.
+func (m *Segments) Contacts(ctx context.Context, a1 int64, a2 int) *campaigner.SegmentContactIterator {
+	m.record("Contacts", a1, a2)
+	if f := m.ContactsFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 *campaigner.SegmentContactIterator
+	return r0
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Segments) List(ctx context.Context, a1 int, a2 int) (campaigner.ResponseSegmentList, error) {
+	m.record("List", a1, a2)
+	if f := m.ListFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseSegmentList
+	var r1 error
+	return r0, r1
+}
+
+// ListContacts records the call and returns the result of ListContactsFunc.
+func (m *Segments) ListContacts(ctx context.Context, a1 int64, a2 int, a3 int) (campaigner.ResponseContactList, error) {
+	m.record("ListContacts", a1, a2, a3)
+	if f := m.ListContactsFunc; f != nil {
+		return f(ctx, a1, a2, a3)
+	}
+	var r0 campaigner.ResponseContactList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Segments) Read(ctx context.Context, a1 int64) (campaigner.ResponseSegmentRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseSegmentRead
+	var r1 error
+	return r0, r1
+}
+
+// Forms is a fake campaigner.FormService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Forms struct {
+	Recorder
+
+	ListFunc   func(context.Context, int, int) (campaigner.ResponseFormList, error)
+	ReadFunc   func(context.Context, int64) (campaigner.ResponseFormRead, error)
+	SubmitFunc func(context.Context, int64, campaigner.FormSubmission) error
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Forms) List(ctx context.Context, a1 int, a2 int) (campaigner.ResponseFormList, error) {
+	m.record("List", a1, a2)
+	if f := m.ListFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseFormList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Forms) Read(ctx context.Context, a1 int64) (campaigner.ResponseFormRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseFormRead
+	var r1 error
+	return r0, r1
+}
+
+// Submit records the call and returns the result of SubmitFunc.
+func (m *Forms) Submit(ctx context.Context, a1 int64, a2 campaigner.FormSubmission) error {
+	m.record("Submit", a1, a2)
+	if f := m.SubmitFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 error
+	return r0
+}
+
+// History is a fake campaigner.HistoryService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type History struct {
+	Recorder
+
+	ActivitiesFunc      func(context.Context, int64, int, int) (campaigner.ResponseActivityList, error)
+	BounceLogsFunc      func(context.Context, int64, int, int) (campaigner.ResponseBounceLogList, error)
+	EmailActivitiesFunc func(context.Context, int64, int, int) (campaigner.ResponseEmailActivityList, error)
+}
+
+// Activities records the call and returns the result of ActivitiesFunc.
+func (m *History) Activities(ctx context.Context, a1 int64, a2 int, a3 int) (campaigner.ResponseActivityList, error) {
+	m.record("Activities", a1, a2, a3)
+	if f := m.ActivitiesFunc; f != nil {
+		return f(ctx, a1, a2, a3)
+	}
+	var r0 campaigner.ResponseActivityList
+	var r1 error
+	return r0, r1
+}
+
+// BounceLogs records the call and returns the result of BounceLogsFunc.
+func (m *History) BounceLogs(ctx context.Context, a1 int64, a2 int, a3 int) (campaigner.ResponseBounceLogList, error) {
+	m.record("BounceLogs", a1, a2, a3)
+	if f := m.BounceLogsFunc; f != nil {
+		return f(ctx, a1, a2, a3)
+	}
+	var r0 campaigner.ResponseBounceLogList
+	var r1 error
+	return r0, r1
+}
+
+// EmailActivities records the call and returns the result of EmailActivitiesFunc.
+func (m *History) EmailActivities(ctx context.Context, a1 int64, a2 int, a3 int) (campaigner.ResponseEmailActivityList, error) {
+	m.record("EmailActivities", a1, a2, a3)
+	if f := m.EmailActivitiesFunc; f != nil {
+		return f(ctx, a1, a2, a3)
+	}
+	var r0 campaigner.ResponseEmailActivityList
+	var r1 error
+	return r0, r1
+}
+
+// Scores is a fake campaigner.ScoreService.
+// Methods return the result of their XFunc field, or zero values and a nil error if it isn't set.
+type Scores struct {
+	Recorder
+
+	ContactValuesFunc func(context.Context, int64) (campaigner.ResponseScoreValueList, error)
+	DealValuesFunc    func(context.Context, int64) (campaigner.ResponseScoreValueList, error)
+	ListFunc          func(context.Context, int, int) (campaigner.ResponseScoreList, error)
+	ReadFunc          func(context.Context, int64) (campaigner.ResponseScoreRead, error)
+}
+
+// ContactValues records the call and returns the result of ContactValuesFunc.
+func (m *Scores) ContactValues(ctx context.Context, a1 int64) (campaigner.ResponseScoreValueList, error) {
+	m.record("ContactValues", a1)
+	if f := m.ContactValuesFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseScoreValueList
+	var r1 error
+	return r0, r1
+}
+
+// DealValues records the call and returns the result of DealValuesFunc.
+func (m *Scores) DealValues(ctx context.Context, a1 int64) (campaigner.ResponseScoreValueList, error) {
+	m.record("DealValues", a1)
+	if f := m.DealValuesFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseScoreValueList
+	var r1 error
+	return r0, r1
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *Scores) List(ctx context.Context, a1 int, a2 int) (campaigner.ResponseScoreList, error) {
+	m.record("List", a1, a2)
+	if f := m.ListFunc; f != nil {
+		return f(ctx, a1, a2)
+	}
+	var r0 campaigner.ResponseScoreList
+	var r1 error
+	return r0, r1
+}
+
+// Read records the call and returns the result of ReadFunc.
+func (m *Scores) Read(ctx context.Context, a1 int64) (campaigner.ResponseScoreRead, error) {
+	m.record("Read", a1)
+	if f := m.ReadFunc; f != nil {
+		return f(ctx, a1)
+	}
+	var r0 campaigner.ResponseScoreRead
+	var r1 error
+	return r0, r1
+}
+
+// Compile time checks that the fakes satisfy their interfaces.
+var (
+	_ campaigner.ContactService      = (*Contacts)(nil)
+	_ campaigner.TagService          = (*Tags)(nil)
+	_ campaigner.ListService         = (*Lists)(nil)
+	_ campaigner.FieldService        = (*Fields)(nil)
+	_ campaigner.OrganizationService = (*Organizations)(nil)
+	_ campaigner.BulkImportService   = (*BulkImports)(nil)
+	_ campaigner.UserService         = (*Users)(nil)
+	_ campaigner.GroupService        = (*Groups)(nil)
+	_ campaigner.CustomObjectService = (*CustomObjects)(nil)
+	_ campaigner.EcommerceService    = (*Ecommerce)(nil)
+	_ campaigner.SiteTrackingService = (*SiteTracking)(nil)
+	_ campaigner.SegmentService      = (*Segments)(nil)
+	_ campaigner.FormService         = (*Forms)(nil)
+	_ campaigner.HistoryService      = (*History)(nil)
+	_ campaigner.ScoreService        = (*Scores)(nil)
+)
@@ -0,0 +1,62 @@
+package campaignermock
+
+import (
+	"context"
+	"errors"
+	"github.com/henrocdotnet/active-campaigner/campaigner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// Tags a contact by tag name, the way downstream code would use the service interfaces.
+func tagByName(ctx context.Context, tags campaigner.TagService, contacts campaigner.ContactService, contactID int64, name string) error {
+	r, err := tags.Find(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(r.Tags) == 0 {
+		return errors.New("tag not found")
+	}
+
+	_, err = contacts.AddTag(ctx, campaigner.RequestContactTagCreate{ContactID: contactID, TagID: r.Tags[0].ID.Int64()})
+	return err
+}
+
+func TestMock_CannedResponses(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		tags     = &Tags{}
+		contacts = &Contacts{}
+	)
+	tags.FindFunc = func(ctx context.Context, n string) (campaigner.ResponseTagList, error) {
+		return campaigner.ResponseTagList{Tags: []campaigner.Tag{{ID: 7, Name: n}}}, nil
+	}
+
+	require.Nil(t, tagByName(ctx, tags, contacts, 3, "Customer"))
+
+	calls := tags.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, Call{Method: "Find", Args: []interface{}{"Customer"}}, calls[0])
+	assert.Equal(t, []interface{}{campaigner.RequestContactTagCreate{ContactID: 3, TagID: 7}}, contacts.CallsTo("AddTag")[0].Args)
+
+	tags.Reset()
+	assert.Empty(t, tags.Calls())
+	assert.NotNil(t, tags.FindFunc)
+}
+
+func TestMock_ZeroValues(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		tags     = &Tags{}
+		contacts = &Contacts{}
+	)
+
+	assert.NotNil(t, tagByName(ctx, tags, contacts, 3, "Customer"))
+	assert.Len(t, tags.CallsTo("Find"), 1)
+	assert.Empty(t, contacts.Calls())
+
+	contacts.DeleteFunc = func(ctx context.Context, id int64) error { return errors.New("boom") }
+	assert.NotNil(t, contacts.Delete(ctx, 1))
+	assert.Nil(t, tags.Delete(ctx, 1))
+}
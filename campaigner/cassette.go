@@ -0,0 +1,342 @@
+package campaigner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Cassette holds recorded API interactions (see Recorder and Replayer).  Cassettes are stored as indented JSON so that
+// fixtures can be reviewed and edited by hand.
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction holds a recorded request and its response.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CASSETTE_HOST replaces the host (the account name) of recorded request URLs.
+const CASSETTE_HOST = "account.api-us1.com"
+
+// CassetteRequest holds a recorded request.  The Api-Token header is always masked and the host is always
+// CASSETTE_HOST.
+type CassetteRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// CassetteResponse holds a recorded response.
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// LoadCassette reads a cassette from a file.
+func LoadCassette(path string) (cassette Cassette, err error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cassette, fmt.Errorf("cassette load failed, file error: %s", err)
+	}
+
+	if err = json.Unmarshal(b, &cassette); err != nil {
+		return cassette, fmt.Errorf("cassette load failed, JSON error: %s", err)
+	}
+
+	return cassette, nil
+}
+
+// Save writes a cassette to a file.
+func (c Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cassette save failed, JSON error: %s", err)
+	}
+
+	if err = ioutil.WriteFile(path, append(b, '\n'), 0644); err != nil {
+		return fmt.Errorf("cassette save failed, file error: %s", err)
+	}
+
+	return nil
+}
+
+// Recorder is an http.RoundTripper that records every request sent through it along with its response.  Call Save
+// once done to write the cassette.
+//
+//	rec := campaigner.NewRecorder("testdata/contact_create.json")
+//	c := campaigner.Campaigner{APIToken: token, BaseURL: baseURL, HTTPClient: &http.Client{Transport: rec}}
+//	...
+//	err := rec.Save()
+type Recorder struct {
+	// Path is the file written by Save.
+	Path string
+
+	// Transport sends the requests.  http.DefaultTransport is used if nil.
+	Transport http.RoundTripper
+
+	// RedactBody is applied to request and response bodies before they are recorded, e.g. RedactJSONFields("email").
+	// Use the same function when replaying so that requests still match.
+	RedactBody func(body []byte) []byte
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a recorder writing to path.
+func NewRecorder(path string) *Recorder {
+	return &Recorder{Path: path}
+}
+
+// RoundTrip sends a request and records it.  The request is cloned rather than modified.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// The body is read from a copy if the request can provide one, otherwise the clone is sent with the body read here.
+	out := req.Clone(req.Context())
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetBody == nil && req.Body != nil {
+		out.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	// The account host also shows up in links and Location headers.
+	host := strings.NewReplacer(req.URL.Host, CASSETTE_HOST)
+	i := CassetteInteraction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			URL:    scrubURL(req.URL),
+			Header: scrubHeader(req.Header, host),
+			Body:   host.Replace(string(r.redact(reqBody))),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header, host),
+			Body:       host.Replace(string(r.redact(respBody))),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Cassette returns a copy of the interactions recorded so far.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Cassette{Interactions: append([]CassetteInteraction{}, r.cassette.Interactions...)}
+}
+
+// Save writes the recorded interactions to Path.
+func (r *Recorder) Save() error {
+	return r.Cassette().Save(r.Path)
+}
+
+// Returns a redacted body.
+func (r *Recorder) redact(body []byte) []byte {
+	if len(body) == 0 || r.RedactBody == nil {
+		return body
+	}
+
+	return r.RedactBody(body)
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette instead of the API.  Requests are matched by
+// method, path, query and body (JSON bodies are compared after normalizing key order and whitespace), the host is
+// ignored.  Each recorded interaction is used once and in order.  A request that matches nothing fails with a
+// CassetteMismatchError.
+type Replayer struct {
+	// RedactBody is applied to request bodies before matching.  Use the function used while recording.
+	RedactBody func(body []byte) []byte
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewReplayer returns a replayer for a cassette file.
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCassetteReplayer(cassette), nil
+}
+
+// NewCassetteReplayer returns a replayer for a loaded cassette.
+func NewCassetteReplayer(cassette Cassette) *Replayer {
+	return &Replayer{cassette: cassette, used: make([]bool, len(cassette.Interactions))}
+}
+
+// CassetteMismatchError is returned for a request that doesn't match any unused recorded interaction.
+type CassetteMismatchError struct {
+	Method string
+	URL    string
+	Body   string
+}
+
+// Error satisfies the error interface.
+func (e CassetteMismatchError) Error() string {
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("cassette has no unused interaction matching %s %s with body %s", e.Method, e.URL, e.Body)
+	}
+
+	return fmt.Sprintf("cassette has no unused interaction matching %s %s", e.Method, e.URL)
+}
+
+// RoundTrip answers a request from the cassette.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > 0 && r.RedactBody != nil {
+		body = r.RedactBody(body)
+	}
+
+	key := cassetteKey(req.Method, req.URL.String(), body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for x, i := range r.cassette.Interactions {
+		if r.used[x] || cassetteKey(i.Request.Method, i.Request.URL, []byte(i.Request.Body)) != key {
+			continue
+		}
+		r.used[x] = true
+
+		header := http.Header{}
+		for k, v := range i.Response.Header {
+			header[k] = append([]string{}, v...)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, CassetteMismatchError{Method: req.Method, URL: req.URL.String(), Body: string(body)}
+}
+
+// Unused returns the recorded interactions that haven't been replayed, e.g. to fail a test that sent fewer requests
+// than were recorded.
+func (r *Replayer) Unused() []CassetteInteraction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var l []CassetteInteraction
+	for x, i := range r.cassette.Interactions {
+		if !r.used[x] {
+			l = append(l, i)
+		}
+	}
+
+	return l
+}
+
+// Reads a request body.  A copy from GetBody is read if there is one, otherwise the body itself is consumed.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body := req.Body
+	if req.GetBody != nil {
+		var err error
+		if body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := ioutil.ReadAll(body)
+	_ = body.Close()
+
+	return b, err
+}
+
+// Returns a URL with the host replaced by CASSETTE_HOST.
+func scrubURL(u *url.URL) string {
+	c := *u
+	c.Host = CASSETTE_HOST
+	c.User = nil
+
+	return c.String()
+}
+
+// Returns a copy of a header with credentials masked and the account host replaced.
+func scrubHeader(h http.Header, host *strings.Replacer) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	c := http.Header{}
+	for k, v := range h {
+		switch http.CanonicalHeaderKey(k) {
+		case "Api-Token", "Authorization", "Cookie", "Set-Cookie":
+			c[k] = []string{MASK}
+		default:
+			for _, y := range v {
+				c[k] = append(c[k], host.Replace(y))
+			}
+		}
+	}
+
+	return c
+}
+
+// Returns the matching key of a request: method, path, sorted query and normalized body.
+func cassetteKey(method string, rawURL string, body []byte) string {
+	path, query := rawURL, ""
+	if u, err := url.Parse(rawURL); err == nil {
+		path = strings.TrimSuffix(u.Path, "/")
+		query = u.Query().Encode()
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			body = b
+		}
+	}
+
+	return strings.Join([]string{strings.ToUpper(method), path, query, strings.TrimSpace(string(body))}, "\n")
+}
@@ -0,0 +1,125 @@
+package campaigner
+
+import (
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Tests all cassette functionality as a group.  These tests run against a local test server.
+func TestCassetteSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestCassette_SuccessRecordReplay)
+	runTestWithPackagePath(t, TestCassette_FailureMismatch)
+	runTestWithPackagePath(t, TestCassette_SuccessRequestUnchanged)
+	runTestWithPackagePath(t, TestCassetteKey)
+}
+
+func TestCassette_SuccessRecordReplay(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	// Record.
+	rec := NewRecorder(path)
+	rec.RedactBody = RedactJSONFields("phone")
+	c := Campaigner{APIToken: s.APIToken, BaseURL: s.URL, HTTPClient: &http.Client{Transport: rec}}
+
+	created, err := c.ContactCreate(Contact{EmailAddress: "cassette@example.com", PhoneNumber: "2125551212"})
+	require.Nil(t, err)
+	_, err = c.ContactRead(created.Contact.ID.Int64())
+	require.Nil(t, err)
+	require.Nil(t, rec.Save())
+
+	b, err := ioutil.ReadFile(path)
+	require.Nil(t, err)
+	assert.NotContains(t, string(b), s.APIToken)
+	assert.NotContains(t, string(b), "2125551212")
+	assert.NotContains(t, string(b), strings.TrimPrefix(s.URL, "http://"))
+	assert.Contains(t, string(b), CASSETTE_HOST)
+	assert.Contains(t, string(b), MASK)
+
+	// Replay without a server.
+	rep, err := NewReplayer(path)
+	require.Nil(t, err)
+	rep.RedactBody = rec.RedactBody
+	c = Campaigner{APIToken: "token", BaseURL: "http://127.0.0.1:1", HTTPClient: &http.Client{Transport: rep}}
+
+	replayed, err := c.ContactCreate(Contact{EmailAddress: "cassette@example.com", PhoneNumber: "7185551212"})
+	require.Nil(t, err)
+	assert.Equal(t, created.Contact.ID, replayed.Contact.ID)
+	assert.Len(t, rep.Unused(), 1)
+
+	r, err := c.ContactRead(created.Contact.ID.Int64())
+	require.Nil(t, err)
+	assert.Equal(t, "cassette@example.com", r.Contact.EmailAddress)
+	assert.Empty(t, rep.Unused())
+}
+
+func TestCassette_FailureMismatch(t *testing.T) {
+	rep := NewCassetteReplayer(Cassette{Interactions: []CassetteInteraction{
+		{Request: CassetteRequest{Method: http.MethodGet, URL: "https://x.api-us1.com/api/3/tags/1"}, Response: CassetteResponse{StatusCode: http.StatusOK, Body: `{"tag":{"id":"1"}}`}},
+	}})
+	c := Campaigner{APIToken: "token", BaseURL: "http://127.0.0.1:1", HTTPClient: &http.Client{Transport: rep}}
+
+	_, err := c.TagRead(2)
+	require.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "cassette has no unused interaction matching GET"), err.Error())
+
+	_, err = c.TagRead(1)
+	require.Nil(t, err)
+
+	// Interactions are only used once.
+	_, err = c.TagRead(1)
+	assert.NotNil(t, err)
+}
+
+// The recorder sends a clone, the caller's request (and its body) is left alone.
+func TestCassette_SuccessRequestUnchanged(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	rec := NewRecorder("")
+	bodies := []string{`{"tag":{"tag":"Customer","tagType":"contact"}}`, `{"tag":{"tag":"Lead","tagType":"contact"}}`}
+
+	// With GetBody (set by http.NewRequest) and without.
+	for x, getBody := range []bool{true, false} {
+		body := bodies[x]
+		req, err := http.NewRequest(http.MethodPost, s.URL+"/api/3/tags", strings.NewReader(body))
+		require.Nil(t, err)
+		req.Header.Set("Api-Token", s.APIToken)
+		if !getBody {
+			req.GetBody = nil
+		}
+		original := req.Body
+
+		resp, err := rec.RoundTrip(req)
+		require.Nil(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.True(t, original == req.Body)
+		assert.Equal(t, s.URL+"/api/3/tags", req.URL.String())
+	}
+
+	i := rec.Cassette().Interactions
+	require.Len(t, i, 2)
+	for x, y := range i {
+		assert.Equal(t, bodies[x], y.Request.Body)
+		assert.Equal(t, "http://"+CASSETTE_HOST+"/api/3/tags", y.Request.URL)
+		assert.NotContains(t, y.Response.Body, strings.TrimPrefix(s.URL, "http://"))
+	}
+}
+
+func TestCassetteKey(t *testing.T) {
+	a := cassetteKey("post", "https://a/api/3/contacts/?b=2&a=1", []byte(`{"contact": {"email": "a@b.c", "phone": ""}}`))
+	b := cassetteKey("POST", "http://b/api/3/contacts?a=1&b=2", []byte(`{"contact":{"phone":"","email":"a@b.c"}}`))
+	assert.Equal(t, a, b)
+
+	c := cassetteKey("POST", "http://b/api/3/contacts?a=1&b=3", []byte(`{"contact":{"phone":"","email":"a@b.c"}}`))
+	assert.NotEqual(t, a, c)
+}
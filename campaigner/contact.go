@@ -1,30 +1,25 @@
 package campaigner
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 )
 
-// ContactList lists contacts.
-func (c *Campaigner) ContactList(limit int, offset int) (response ResponseContactList, err error) {
+// List lists contacts.
+func (s *ContactAPI) List(ctx context.Context, limit int, offset int) (response ResponseContactList, err error) {
 	// Setup.
 	qs := url.Values{}
 	qs.Set("limit", strconv.Itoa(limit))
 	qs.Set("offset", strconv.Itoa(offset))
 	u := url.URL{ Path: "/api/3/contacts", RawQuery: qs.Encode() }
 
-	fmt.Printf("contact list: url = %s\n", u.String())
-
-	//qs := fmt.Sprintf("%s=%s", url2.Query)
-	//url := "/api/3/contacts"
-
 	// Send GET request.
-	r, body, err := c.get(u.String())
+	r, body, err := s.client.get(ctx, u.String())
 	if err != nil {
 		return response, fmt.Errorf("contact list failed, HTTP error: %s", err)
 	}
@@ -32,7 +27,7 @@ func (c *Campaigner) ContactList(limit int, offset int) (response ResponseContac
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		err := json.Unmarshal(body, &response)
+		err := s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("contact list failed, JSON error: %s", err)
 		}
@@ -44,8 +39,8 @@ func (c *Campaigner) ContactList(limit int, offset int) (response ResponseContac
 	}
 }
 
-// ContactCreate creates a contact.
-func (c *Campaigner) ContactCreate(contact Contact) (result ResponseContactCreate, err error) {
+// Create creates a contact.
+func (s *ContactAPI) Create(ctx context.Context, contact Contact) (result ResponseContactCreate, err error) {
 	// TODO(api): The struct used in the request has to be rebuilt as AC does not like all of the extra fields in a "real contact".  This
 	//            might be caused by sending the organization ID in the request which I don't think the unit tests currently cover.
 	// Setup.
@@ -63,7 +58,7 @@ func (c *Campaigner) ContactCreate(contact Contact) (result ResponseContactCreat
 	)
 
 	// Send POST request.
-	r, body, err := c.post(uri, data)
+	r, body, err := s.client.post(ctx, uri, data)
 	if err != nil {
 		return result, fmt.Errorf("contact creation failed, HTTP error: %s", err)
 	}
@@ -71,7 +66,7 @@ func (c *Campaigner) ContactCreate(contact Contact) (result ResponseContactCreat
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusCreated: // Success.
-		err = json.Unmarshal([]byte(body), &result)
+		err = s.client.decode(r, body, &result)
 		if err != nil {
 			return result, fmt.Errorf("contact creation failed, json error: %s", err)
 		}
@@ -93,10 +88,10 @@ func (c *Campaigner) ContactCreate(contact Contact) (result ResponseContactCreat
 }
 
 
-// ContactFind searches for a contact by email.
+// Find searches for a contact by email.
 //
 // Partial emails are not supported by the API.
-func (c *Campaigner) ContactFind(email string) (response ResponseContactList, err error) {
+func (s *ContactAPI) Find(ctx context.Context, email string) (response ResponseContactList, err error) {
 	// Setup.
 	var (
 		qs       = fmt.Sprintf("%s=%s", url.QueryEscape("filters[email]"), url.QueryEscape(email))
@@ -109,7 +104,7 @@ func (c *Campaigner) ContactFind(email string) (response ResponseContactList, er
 	}
 
 	// Send GET request.
-	r, body, err := c.get(u)
+	r, body, err := s.client.get(ctx, u)
 	if err != nil {
 		return response, fmt.Errorf("contact find failed, HTTP failure: %s", err)
 	}
@@ -117,12 +112,11 @@ func (c *Campaigner) ContactFind(email string) (response ResponseContactList, er
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("contact find failed, JSON failure: %s", err)
 		}
 
-		log.Printf("contact find response? %#v\n", response)
 		return response, nil
 	}
 
@@ -130,14 +124,14 @@ func (c *Campaigner) ContactFind(email string) (response ResponseContactList, er
 
 }
 
-// ContactRead reads a contact.
-func (c *Campaigner) ContactRead(id int64) (response ResponseContactRead, err error) {
+// Read reads a contact.
+func (s *ContactAPI) Read(ctx context.Context, id int64) (response ResponseContactRead, err error) {
 	// TODO(response-parsing): Quite a bit of extra data is being returned besides the contact itself.  Not sure if this should be parsed and wrapped back into the main contact struct.
 	// Setup.
 	var uri = fmt.Sprintf("/api/3/contacts/%d", id)
 
 	// Send GET request.
-	r, body, err := c.get(uri)
+	r, body, err := s.client.get(ctx, uri)
 	if err != nil {
 		return response, err
 	}
@@ -145,7 +139,7 @@ func (c *Campaigner) ContactRead(id int64) (response ResponseContactRead, err er
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK: // Success.
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("contact read failed, JSON error: %s", err)
 		}
@@ -158,12 +152,12 @@ func (c *Campaigner) ContactRead(id int64) (response ResponseContactRead, err er
 	}
 }
 
-// ContactUpdate updates a contact.
-func (c *Campaigner) ContactUpdate(id int64, request RequestContactUpdate) (response ResponseContactUpdate, err error) {
+// Update updates a contact.
+func (s *ContactAPI) Update(ctx context.Context, id int64, request RequestContactUpdate) (response ResponseContactUpdate, err error) {
 	// Send PUT request.
 	u := fmt.Sprintf("/api/3/contact/sync")
 	d := map[string]interface{}{"contact": request}
-	r, body, err := c.post(u, d)
+	r, body, err := s.client.post(ctx, u, d)
 	if err != nil {
 		return response, fmt.Errorf("contact update failed, HTTP error: %s", err)
 	}
@@ -171,9 +165,7 @@ func (c *Campaigner) ContactUpdate(id int64, request RequestContactUpdate) (resp
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		log.Println("OK BODY FOOL")
-		log.Println(string(body))
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("contact update failed, JSON error: %s", err)
 		}
@@ -183,11 +175,11 @@ func (c *Campaigner) ContactUpdate(id int64, request RequestContactUpdate) (resp
 	}
 }
 
-// ContactDelete deletes a contact.
-func (c *Campaigner) ContactDelete(id int64) error {
+// Delete deletes a contact.
+func (s *ContactAPI) Delete(ctx context.Context, id int64) error {
 	// TODO(error-checking): Are there specific HTTP codes that can be checked for?
 	// Send DELETE request.
-	r, b, err := c.delete(fmt.Sprintf("/api/3/contacts/%d", id))
+	r, b, err := s.client.delete(ctx, fmt.Sprintf("/api/3/contacts/%d", id))
 	if err != nil {
 		return fmt.Errorf("contact deletion failed, HTTP error: %s", err)
 	}
@@ -201,10 +193,10 @@ func (c *Campaigner) ContactDelete(id int64) error {
 	}
 }
 
-// ContactFieldDeleteByFieldValueID deletes a contact field value by it's ID.  Note that this is different from the Field::ID.
-func (c *Campaigner) ContactFieldDeleteByFieldValueID(id int64) (err error) {
+// DeleteFieldValue deletes a contact field value by it's ID.  Note that this is different from the Field::ID.
+func (s *ContactAPI) DeleteFieldValue(ctx context.Context, id int64) (err error) {
 	u := fmt.Sprintf("/api/3/fieldValues/%d", id)
-	r, body, err := c.delete(u)
+	r, body, err := s.client.delete(ctx, u)
 	if err != nil {
 		return fmt.Errorf("contact field deletion failed, HTTP error: %s", err)
 	}
@@ -224,14 +216,14 @@ func (c *Campaigner) ContactFieldDeleteByFieldValueID(id int64) (err error) {
 	}
 }
 
-// ContactFieldUpdate updates a custom field for a contact.
-func (c *Campaigner) ContactFieldUpdate(contactID int64, fieldID int64, value string) (response ResponseContactFieldUpdate, err error) {
+// UpdateField updates a custom field for a contact.
+func (s *ContactAPI) UpdateField(ctx context.Context, contactID int64, fieldID int64, value string) (response ResponseContactFieldUpdate, err error) {
 	// Check that both the contact and field exist.
-	_, err = c.ContactRead(contactID)
+	_, err = s.Read(ctx, contactID)
 	if err != nil {
 		return response, fmt.Errorf("contact field update failed, could not find contact: %s", err)
 	}
-	_, err = c.FieldRead(fieldID)
+	_, err = s.client.fieldAPI().Read(ctx, fieldID)
 	if err != nil {
 		return response, fmt.Errorf("contact field update failed, could not find field: %s", err)
 	}
@@ -239,7 +231,7 @@ func (c *Campaigner) ContactFieldUpdate(contactID int64, fieldID int64, value st
 	// Send POST request.
 	req := RequestContactFieldUpdate{ContactID: contactID, FieldID: fieldID, Value: value}
 	u := "/api/3/fieldValues"
-	r, body, err := c.post(u, map[string]interface{}{"fieldValue": req})
+	r, body, err := s.client.post(ctx, u, map[string]interface{}{"fieldValue": req})
 	if err != nil {
 		return response, fmt.Errorf("contact field update failed, HTTP error: %s", err)
 	}
@@ -249,7 +241,7 @@ func (c *Campaigner) ContactFieldUpdate(contactID int64, fieldID int64, value st
 	case http.StatusCreated: // Create.
 		fallthrough
 	case http.StatusOK: // Update.
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("contact field update failed, JSON error: %s", err)
 		}
@@ -262,12 +254,12 @@ func (c *Campaigner) ContactFieldUpdate(contactID int64, fieldID int64, value st
 	return response, fmt.Errorf("contact field update failed, unspecified error (%d): %s", r.StatusCode, string(body))
 }
 
-// ContactTagCreate links a tag to a contact.
+// AddTag links a tag to a contact.
 //
 // TODO(API): The API return JSON also includes a contacts[] entry with one contact in it.  Tested this on a tag I know is attached to more than one contact.
 //
 // TODO(API): The API returns different JSON for a request with a bogus ID in it.  The contact and tag ID are returned as strings instead of ints.
-func (c *Campaigner) ContactTagCreate(request RequestContactTagCreate) (response ResponseContactTagCreate, err error) {
+func (s *ContactAPI) AddTag(ctx context.Context, request RequestContactTagCreate) (response ResponseContactTagCreate, err error) {
 	// TODO(error-checking): Is it possible to check for a not found error specifically?
 	// TODO(error-checking): Nonexistent contact or tag should return a CustomErrorNotFound error.
 	// Setup.
@@ -286,19 +278,19 @@ func (c *Campaigner) ContactTagCreate(request RequestContactTagCreate) (response
 	}
 
 	// Check that contact exists.
-	rC, err := c.ContactRead(request.ContactID)
+	rC, err := s.Read(ctx, request.ContactID)
 	if err != nil {
 		return response, fmt.Errorf("contact tagging failed, could not find contact: %s", err)
 	}
 
 	// Check that tag exists.
-	rT, err := c.TagRead(request.TagID)
+	rT, err := s.client.tagAPI().Read(ctx, request.TagID)
 	if err != nil {
 		return response, fmt.Errorf("contact tagging failed, could not find tag: %s", err)
 	}
 
 	// Send POST request.
-	r, b, err := c.post(uri, data)
+	r, b, err := s.client.post(ctx, uri, data)
 	if err != nil {
 		return response, fmt.Errorf("contact tagging failed, HTTP error: %s", err)
 	}
@@ -309,7 +301,7 @@ func (c *Campaigner) ContactTagCreate(request RequestContactTagCreate) (response
 	// Brand new association.  Response JSON includes the contact, bleh.
 	switch r.StatusCode {
 		case http.StatusOK, http.StatusCreated:
-		if err := json.Unmarshal(b, &response); err != nil {
+		if err := s.client.decode(r, b, &response); err != nil {
 			return response, fmt.Errorf("contact tagging failed, JSON error: %s", err)
 		}
 
@@ -322,15 +314,15 @@ func (c *Campaigner) ContactTagCreate(request RequestContactTagCreate) (response
 	}
 }
 
-// ContactTagDelete removes a tag from a contact.  This removes the "link" and not the tag itself.
-func (c *Campaigner) ContactTagDelete(id int64) error {
+// RemoveTag removes a tag from a contact.  This removes the "link" and not the tag itself.
+func (s *ContactAPI) RemoveTag(ctx context.Context, id int64) error {
 	// Setup.
 	var (
 		uri = fmt.Sprintf("/api/3/contactTags/%d", id)
 	)
 
 	// Send DELETE request.
-	r, b, err := c.delete(uri)
+	r, b, err := s.client.delete(ctx, uri)
 	if err != nil {
 		return fmt.Errorf("contact tag deletion failed, HTTP failure: %s", err)
 	}
@@ -348,13 +340,13 @@ func (c *Campaigner) ContactTagDelete(id int64) error {
 	}
 }
 
-// ContactTagReadByContactID reads assigned tags for a contact by it's ID.
-func (c *Campaigner) ContactTagReadByContactID(id int64) (response ResponseContactTagRead, err error) {
+// Tags reads assigned tags for a contact by it's ID.
+func (s *ContactAPI) Tags(ctx context.Context, id int64) (response ResponseContactTagRead, err error) {
 	// Setup.
 	var uri = fmt.Sprintf("/api/3/contacts/%d/contactTags", id)
 
 	// Send GET request.
-	r, body, err := c.get(uri)
+	r, body, err := s.client.get(ctx, uri)
 	if err != nil {
 		return response, fmt.Errorf("contact tags read failed, HTTP error: %s", err)
 	}
@@ -362,7 +354,7 @@ func (c *Campaigner) ContactTagReadByContactID(id int64) (response ResponseConta
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK: // Success.
-		if err = json.Unmarshal(body, &response); err != nil {
+		if err = s.client.decode(r, body, &response); err != nil {
 			return response, fmt.Errorf("contact tags read failed, JSON error: %s", err)
 		}
 
@@ -374,7 +366,6 @@ func (c *Campaigner) ContactTagReadByContactID(id int64) (response ResponseConta
 		return response, e
 
 	default:
-		log.Printf("response? %#v\n", r)
 		return response, fmt.Errorf("contact tags read failed, unspecified error: %s", string(body))
 	}
 }
@@ -387,7 +378,7 @@ type ResponseContactFieldUpdate struct {
 
 // ContactTag holds a JSON compatible contact tag.
 type ContactTag struct {
-	DateCreated string          `json:"cdate"`
+	DateCreated ACTime          `json:"cdate"`
 	ContactID   Int64json       `json:"contact"`
 	ID          Int64json       `json:"id"`
 	TagID       Int64json       `json:"tag"`
@@ -408,6 +399,7 @@ type ResponseContactTagCreate struct {
 		TagName      string
 	}
 	ContactTag ContactTag `json:"contactTag"`
+	Contacts   []Contact  `json:"contacts"` // Only sent for new associations.
 }
 
 // ResponseContactTagRead holds a JSON compatible response for reading contacts.
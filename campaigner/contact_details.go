@@ -0,0 +1,304 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// List membership statuses.
+const (
+	CONTACT_LIST_STATUS_UNCONFIRMED  = 0
+	CONTACT_LIST_STATUS_ACTIVE       = 1
+	CONTACT_LIST_STATUS_UNSUBSCRIBED = 2
+	CONTACT_LIST_STATUS_BOUNCED      = 3
+)
+
+// ContactDetails holds a contact along with the records the API side-loads when reading it (see ContactAPI.Read),
+// stitched together into typed structs.  Names that aren't side-loaded (lists, tags, fields and automations) are
+// filled in by ContactAPI.ReadDetailed.
+type ContactDetails struct {
+	Contact     Contact
+	Lists       []ContactDetailsList
+	Tags        []ContactDetailsTag
+	FieldValues map[int64]ContactDetailsFieldValue // By field ID.
+	Automations []ContactDetailsAutomation
+	Deals       []ContactDetailsDeal
+	GeoIPs      []ContactDetailsGeoIP
+}
+
+// ContactDetailsList holds a list membership.
+type ContactDetailsList struct {
+	ID               int64
+	ListID           int64
+	Name             string
+	Status           int
+	DateSubscribed   ACTime
+	DateUnsubscribed ACTime
+}
+
+// Subscribed returns true if the membership is active.
+func (l ContactDetailsList) Subscribed() bool {
+	return l.Status == CONTACT_LIST_STATUS_ACTIVE
+}
+
+// ContactDetailsTag holds a tag linked to the contact.  ID is the ID of the link (see ContactAPI.RemoveTag).
+type ContactDetailsTag struct {
+	ID          int64
+	TagID       int64
+	Name        string
+	DateCreated ACTime
+}
+
+// ContactDetailsFieldValue holds a custom field value.  ID is the ID of the value (see ContactAPI.DeleteFieldValue).
+type ContactDetailsFieldValue struct {
+	ID      int64
+	FieldID int64
+	Title   string
+	PersTag string
+	Value   string
+}
+
+// ContactDetailsAutomation holds an automation the contact entered.
+type ContactDetailsAutomation struct {
+	ID           int64
+	AutomationID int64
+	Name         string
+	Status       string
+	DateAdded    ACTime
+	DateRemoved  ACTime
+	Completed    bool
+}
+
+// ContactDetailsDeal holds a deal of the contact.
+type ContactDetailsDeal struct {
+	ID       int64
+	Title    string
+	Status   string
+	Currency string
+	OwnerID  int64
+}
+
+// ContactDetailsGeoIP holds an IP address the contact was seen at with its location.
+type ContactDetailsGeoIP struct {
+	IP4       string
+	Date      ACTime
+	Country   string
+	State     string
+	City      string
+	Zip       string
+	Latitude  Decimaljson
+	Longitude Decimaljson
+	Timezone  string
+}
+
+// NewContactDetails stitches the side-loaded records of a read response onto the contact.  No requests are sent, so
+// names that the API doesn't side-load are left empty.
+func NewContactDetails(r ResponseContactRead) ContactDetails {
+	d := ContactDetails{Contact: r.Contact, FieldValues: map[int64]ContactDetailsFieldValue{}}
+
+	for _, y := range r.ContactLists {
+		d.Lists = append(d.Lists, ContactDetailsList{
+			ID:               y.ID.Int64(),
+			ListID:           y.List.Int64(),
+			Status:           int(y.Status),
+			DateSubscribed:   y.Sdate,
+			DateUnsubscribed: y.Udate,
+		})
+	}
+
+	for _, y := range r.FieldValues {
+		d.FieldValues[y.FieldID.Int64()] = ContactDetailsFieldValue{ID: y.ID.Int64(), FieldID: y.FieldID.Int64(), Value: y.Value}
+	}
+
+	for _, y := range r.ContactAutomations {
+		d.Automations = append(d.Automations, ContactDetailsAutomation{
+			ID:           y.ID.Int64(),
+			AutomationID: y.Automation.Int64(),
+			Status:       y.Status,
+			DateAdded:    y.Adddate,
+			DateRemoved:  y.Remdate,
+			Completed:    y.Completed.Bool(),
+		})
+	}
+
+	for _, y := range r.Deals {
+		d.Deals = append(d.Deals, ContactDetailsDeal{ID: y.ID.Int64(), Title: y.Title, Status: y.Status, Currency: y.Currency, OwnerID: y.Owner.Int64()})
+	}
+
+	addresses := map[Int64json]int{}
+	for x, y := range r.GeoAddresses {
+		addresses[y.ID] = x
+	}
+	for _, y := range r.GeoIps {
+		g := ContactDetailsGeoIP{IP4: y.IP4, Date: y.Tstamp}
+		if x, ok := addresses[y.Geoaddrid]; ok {
+			a := r.GeoAddresses[x]
+			g.Country, g.State, g.City, g.Zip, g.Latitude, g.Longitude, g.Timezone = a.Country, a.State, a.City, a.Zip, a.Lat, a.Lon, a.Tz
+		}
+		d.GeoIPs = append(d.GeoIPs, g)
+	}
+
+	return d
+}
+
+// List returns the membership of a list.
+func (d ContactDetails) List(id int64) (ContactDetailsList, bool) {
+	for _, l := range d.Lists {
+		if l.ListID == id {
+			return l, true
+		}
+	}
+
+	return ContactDetailsList{}, false
+}
+
+// HasTag returns true if a tag with the given name (case insensitive) is linked to the contact.
+func (d ContactDetails) HasTag(name string) bool {
+	for _, t := range d.Tags {
+		if strings.EqualFold(t.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldValueByTitle returns the value of a custom field by its title or personalization tag (case insensitive).
+func (d ContactDetails) FieldValueByTitle(title string) (ContactDetailsFieldValue, bool) {
+	for _, v := range d.FieldValues {
+		if strings.EqualFold(v.Title, title) || (len(v.PersTag) > 0 && strings.EqualFold(v.PersTag, title)) {
+			return v, true
+		}
+	}
+
+	return ContactDetailsFieldValue{}, false
+}
+
+// Automation returns an automation by name (case insensitive).
+func (d ContactDetails) Automation(name string) (ContactDetailsAutomation, bool) {
+	for _, a := range d.Automations {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+
+	return ContactDetailsAutomation{}, false
+}
+
+// ReadDetailed reads a contact and fetches what the read doesn't side-load: tags, list names, field titles and
+// automation names.
+func (s *ContactAPI) ReadDetailed(ctx context.Context, id int64) (details ContactDetails, err error) {
+	r, err := s.Read(ctx, id)
+	if err != nil {
+		return details, err
+	}
+
+	details = NewContactDetails(r)
+
+	// Tags.  Names come from a single tag list (cached if the client has a Cache), links to deleted tags are skipped.
+	tags, err := s.Tags(ctx, id)
+	if err != nil {
+		return details, fmt.Errorf("contact detailed read failed, could not read tags: %s", err)
+	}
+	if len(tags.ContactTags) > 0 {
+		all, err := s.client.tagAPI().List(ctx)
+		if err != nil {
+			return details, fmt.Errorf("contact detailed read failed, could not list tags: %s", err)
+		}
+		names := map[int64]string{}
+		for _, t := range all.Tags {
+			names[t.ID.Int64()] = t.Name
+		}
+		for _, y := range tags.ContactTags {
+			if name, ok := names[y.TagID.Int64()]; ok {
+				details.Tags = append(details.Tags, ContactDetailsTag{ID: y.ID.Int64(), TagID: y.TagID.Int64(), Name: name, DateCreated: y.DateCreated})
+			}
+		}
+	}
+
+	// List names.
+	if len(details.Lists) > 0 {
+		lists, err := s.client.listAPI().List(ctx)
+		if err != nil {
+			return details, fmt.Errorf("contact detailed read failed, could not list lists: %s", err)
+		}
+		names := map[int64]string{}
+		for _, l := range lists.Lists {
+			names[l.ID.Int64()] = l.Name
+		}
+		for x, l := range details.Lists {
+			if _, ok := names[l.ListID]; !ok {
+				r, err := s.client.listAPI().Read(ctx, l.ListID)
+				if err != nil {
+					return details, fmt.Errorf("contact detailed read failed, could not read list: %s", err)
+				}
+				names[l.ListID] = r.List.Name
+			}
+			details.Lists[x].Name = names[l.ListID]
+		}
+	}
+
+	// Field titles.
+	if len(details.FieldValues) > 0 {
+		fields, err := s.client.fieldAPI().List(ctx)
+		if err != nil {
+			return details, fmt.Errorf("contact detailed read failed, could not list fields: %s", err)
+		}
+		for _, f := range fields.Fields {
+			if v, ok := details.FieldValues[f.ID.Int64()]; ok {
+				v.Title, v.PersTag = f.Title, f.Perstag
+				details.FieldValues[f.ID.Int64()] = v
+			}
+		}
+	}
+
+	// Automation names.  Each automation is read once (contacts can enter an automation more than once).
+	automations := map[int64]string{}
+	for x, a := range details.Automations {
+		name, ok := automations[a.AutomationID]
+		if !ok {
+			if name, err = s.automationName(ctx, a.AutomationID); err != nil {
+				return details, fmt.Errorf("contact detailed read failed, could not read automation: %s", err)
+			}
+			automations[a.AutomationID] = name
+		}
+		details.Automations[x].Name = name
+	}
+
+	return details, nil
+}
+
+// Automation holds a JSON compatible automation as it exists in the API.
+type Automation struct {
+	ID                Int64json         `json:"id"`
+	Name              string            `json:"name"`
+	Status            Int64json         `json:"status"`
+	DateCreated       ACTime            `json:"cdate"`
+	DateModified      ACTime            `json:"mdate"`
+	UserID            Int64json         `json:"userid"`
+	Entered           Int64json         `json:"entered"`
+	Exited            Int64json         `json:"exited"`
+	Hidden            Booljson          `json:"hidden"`
+	DefaultScreenshot string            `json:"defaultscreenshot"`
+	Screenshot        string            `json:"screenshot"`
+	Links             map[string]string `json:"links"`
+}
+
+// Returns the name of an automation.  Deleted automations have no name.
+func (s *ContactAPI) automationName(ctx context.Context, id int64) (string, error) {
+	r, body, err := s.client.get(ctx, fmt.Sprintf("/api/3/automations/%d", id))
+	if err != nil {
+		return "", fmt.Errorf("automation read failed, HTTP error: %s", err)
+	}
+
+	var response struct {
+		Automation Automation `json:"automation"`
+	}
+	err = s.client.result(r, body, &response, "automation read", fmt.Sprintf("automation with id %d", id))
+	if _, ok := err.(CustomErrorNotFound); ok {
+		return "", nil
+	}
+
+	return response.Automation.Name, err
+}
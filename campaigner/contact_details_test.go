@@ -0,0 +1,122 @@
+package campaigner
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Tests all contact details functionality as a group.  These tests run against a local test server.
+func TestContactDetailsSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestNewContactDetails)
+	runTestWithPackagePath(t, TestContactReadDetailed_Success)
+}
+
+func TestNewContactDetails(t *testing.T) {
+	body := `{
+		"contactAutomations":[{"contact":"3","seriesid":"4","status":"2","adddate":"2019-01-02T10:00:00-06:00","remdate":null,"lastblock":"5","completedElements":"3","totalElements":"3","completed":1,"completeValue":100,"id":"8","automation":"4"}],
+		"contactLists":[{"contact":"3","list":"1","sdate":"2019-01-02 10:00:00","udate":null,"status":"1","id":"6"},{"contact":"3","list":"2","sdate":"2019-01-02 10:00:00","udate":"2019-02-01 10:00:00","status":"2","id":"7"}],
+		"deals":[{"owner":"1","contact":"3","title":"Pizza","currency":"usd","status":"0","id":"9"}],
+		"fieldValues":[{"contact":"3","field":"2","value":"large","cdate":"","udate":"","owner":"3","id":"11","links":{"owner":"3","field":"2"}}],
+		"geoAddresses":[{"ip4":"127.0.0.1","country":"US","state":"NY","city":"New York","zip":"10001","lat":"40.7","lon":"-74.0","tz":"America/New_York","id":"12"}],
+		"geoIps":[{"contact":"3","geoaddrid":"12","ip4":"127.0.0.1","tstamp":"2019-01-02 10:00:00","id":"13"}],
+		"contact":{"id":"3","email":"a@b.c","orgid":"0","deleted":"0"}
+	}`
+
+	var r ResponseContactRead
+	require.Nil(t, json.Unmarshal([]byte(body), &r))
+
+	d := NewContactDetails(r)
+	assert.Equal(t, int64(3), d.Contact.ID.Int64())
+
+	require.Len(t, d.Lists, 2)
+	l, ok := d.List(2)
+	require.True(t, ok)
+	assert.False(t, l.Subscribed())
+	assert.Equal(t, time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC), l.DateUnsubscribed.Time)
+
+	assert.Equal(t, "large", d.FieldValues[2].Value)
+	assert.Equal(t, int64(11), d.FieldValues[2].ID)
+
+	require.Len(t, d.Automations, 1)
+	assert.Equal(t, int64(4), d.Automations[0].AutomationID)
+	assert.True(t, d.Automations[0].Completed)
+
+	require.Len(t, d.Deals, 1)
+	assert.Equal(t, "Pizza", d.Deals[0].Title)
+
+	require.Len(t, d.GeoIPs, 1)
+	assert.Equal(t, "New York", d.GeoIPs[0].City)
+}
+
+func TestContactReadDetailed_Success(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	var (
+		contactID = s.AddContact(actest.Contact{EmailAddress: "details@example.com"})
+		tagID     = s.AddTag(actest.Tag{Name: "Customer"})
+		vipID     = s.AddTag(actest.Tag{Name: "VIP"})
+		listID    = s.AddList(actest.List{Name: "Newsletter"})
+		fieldID   = s.AddField(actest.Field{Title: "Pizza Size", PersTag: "PIZZA_SIZE"})
+		welcomeID = s.AddAutomation(actest.Automation{Name: "Welcome"})
+		c         = strictTestClient(t, s)
+		reads     = map[string]int{}
+	)
+
+	// Count reads by endpoint.
+	c.Middleware = []Middleware{func(next APIHandler) APIHandler {
+		return func(request *APIRequest) (*APIResponse, error) {
+			reads[request.Endpoint]++
+			return next(request)
+		}
+	}}
+
+	s.AddContactTag(contactID, tagID)
+	s.AddContactTag(contactID, vipID)
+	s.AddContactTag(contactID, vipID+1) // Deleted tag.
+	s.AddContactAutomation(contactID, welcomeID)
+	s.AddContactAutomation(contactID, welcomeID)
+	s.AddContactAutomation(contactID, welcomeID+1) // Deleted automation.
+	s.AddFieldValue(contactID, fieldID, "large")
+	_, err := c.Lists.AddContact(context.Background(), listID, contactID)
+	require.Nil(t, err)
+
+	d, err := c.Contacts.ReadDetailed(context.Background(), contactID)
+	require.Nil(t, err)
+
+	require.Len(t, d.Tags, 2)
+	assert.True(t, d.HasTag("customer"))
+	assert.True(t, d.HasTag("vip"))
+	require.Len(t, d.Lists, 1)
+	assert.Equal(t, "Newsletter", d.Lists[0].Name)
+	assert.True(t, d.Lists[0].Subscribed())
+
+	v, ok := d.FieldValueByTitle("pizza size")
+	require.True(t, ok)
+	assert.Equal(t, "large", v.Value)
+	assert.Equal(t, "PIZZA_SIZE", v.PersTag)
+
+	require.Len(t, d.Automations, 3)
+	a, ok := d.Automation("welcome")
+	require.True(t, ok)
+	assert.Equal(t, welcomeID, a.AutomationID)
+	assert.Equal(t, "", d.Automations[2].Name)
+
+	assert.Equal(t, 1, reads["/api/3/tags"])
+	assert.Equal(t, 0, reads["/api/3/tags/{id}"])
+	assert.Equal(t, 2, reads["/api/3/automations/{id}"])
+
+	// The flat method reads the same details.
+	flat, err := c.ContactReadDetailed(contactID)
+	require.Nil(t, err)
+	assert.Equal(t, d.Tags, flat.Tags)
+	assert.Equal(t, d.Automations, flat.Automations)
+
+	_, err = c.Contacts.ReadDetailed(context.Background(), contactID+1)
+	assert.NotNil(t, err)
+}
@@ -89,7 +89,7 @@ func TestContactCreate_Success(t *testing.T) {
 
 	assert.NotNil(t, r)
 	assert.Nil(t, err, "could not create contact: %s", err)
-	testContactID = r.Contact.ID
+	testContactID = r.Contact.ID.Int64()
 }
 
 func TestContactDelete_Success(t *testing.T) {
@@ -117,7 +117,7 @@ func TestContactUpdate_Success(t *testing.T) {
 	require.Nil(t, err)
 
 	req := RequestContactUpdate{
-		ID: con.Contact.ID,
+		ID: con.Contact.ID.Int64(),
 		EmailAddress: con.Contact.EmailAddress,
 		FirstName: con.Contact.FirstName,
 		LastName: con.Contact.LastName,
@@ -0,0 +1,122 @@
+package csvio
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/henrocdotnet/active-campaigner/campaigner"
+)
+
+// DEFAULT_PAGE_SIZE is the number of contacts requested per page during an export.
+const DEFAULT_PAGE_SIZE = 100
+
+// Exporter exports all contacts to a CSV file.  Client must be set up with campaigner.New (or Init).
+type Exporter struct {
+	Client *campaigner.Campaigner
+
+	// Fields lists the titles of custom fields to add as columns.  Reading custom fields requires one extra request per
+	// contact.
+	Fields []string
+
+	PageSize int
+}
+
+// ExportHeader holds the standard columns written by an export, custom fields follow in the order requested.
+var ExportHeader = []string{"id", TARGET_EMAIL, TARGET_FIRST_NAME, TARGET_LAST_NAME, TARGET_PHONE}
+
+// Export writes all contacts to w and returns the number of contacts written.
+func (e Exporter) Export(ctx context.Context, w io.Writer) (count int, err error) {
+	// Setup.
+	var (
+		writer = csv.NewWriter(w)
+		limit  = e.PageSize
+		ids    []int64
+	)
+
+	if limit < 1 {
+		limit = DEFAULT_PAGE_SIZE
+	}
+
+	// Resolve custom fields.
+	if len(e.Fields) > 0 {
+		r, err := e.Client.Fields.List(ctx)
+		if err != nil {
+			return count, fmt.Errorf("csv export failed, could not list fields: %s", err)
+		}
+
+		for _, title := range e.Fields {
+			var id int64
+			for _, f := range r.Fields {
+				if strings.EqualFold(f.Title, title) {
+					id = f.ID.Int64()
+				}
+			}
+			if id == 0 {
+				return count, fmt.Errorf("csv export failed, custom field `%s` not found", title)
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	if err = writer.Write(append(append([]string{}, ExportHeader...), e.Fields...)); err != nil {
+		return count, fmt.Errorf("csv export failed, write error: %s", err)
+	}
+
+	// Page through contacts.
+	for offset := 0; ; offset += limit {
+		r, err := e.Client.Contacts.List(ctx, limit, offset)
+		if err != nil {
+			return count, fmt.Errorf("csv export failed, %s", err)
+		}
+
+		for _, contact := range r.Contacts {
+			record := []string{strconv.FormatInt(contact.ID.Int64(), 10), contact.EmailAddress, contact.FirstName, contact.LastName, contact.PhoneNumber}
+
+			if len(ids) > 0 {
+				values, err := e.fieldValues(ctx, contact.ID.Int64())
+				if err != nil {
+					return count, err
+				}
+				for _, id := range ids {
+					record = append(record, values[id])
+				}
+			}
+
+			if err = writer.Write(record); err != nil {
+				return count, fmt.Errorf("csv export failed, write error: %s", err)
+			}
+			count++
+		}
+
+		if len(r.Contacts) < limit || offset+limit >= int(r.Meta.Total) {
+			break
+		}
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return count, fmt.Errorf("csv export failed, write error: %s", err)
+	}
+
+	return count, nil
+}
+
+// Returns the custom field values of a contact by field ID.
+func (e Exporter) fieldValues(ctx context.Context, id int64) (map[int64]string, error) {
+	m := map[int64]string{}
+
+	r, err := e.Client.Contacts.Read(ctx, id)
+	if err != nil {
+		return m, fmt.Errorf("csv export failed, could not read contact %d: %s", id, err)
+	}
+
+	for _, v := range r.FieldValues {
+		m[v.FieldID.Int64()] = v.Value
+	}
+
+	return m, nil
+}
@@ -0,0 +1,63 @@
+package csvio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/henrocdotnet/active-campaigner/campaigner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExport_Success(t *testing.T) {
+	var offsets []string
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/3/fields":
+			fmt.Fprint(w, `{"fields":[{"id":"1","title":"Company Size"},{"id":"2","title":"Favorite Color"}],"meta":{"total":"2"}}`)
+		case "/api/3/contacts":
+			offsets = append(offsets, r.URL.Query().Get("offset"))
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+			var l []string
+			for x := offset; x < offset+2 && x < 3; x++ {
+				l = append(l, fmt.Sprintf(`{"id":"%d","email":"user%d@user.com","firstName":"User","lastName":"%d","phone":""}`, x+1, x+1, x+1))
+			}
+			fmt.Fprintf(w, `{"contacts":[%s],"meta":{"total":"3"}}`, strings.Join(l, ","))
+		default:
+			fmt.Fprintf(w, `{"contact":{"id":"1"},"fieldValues":[{"id":"9","contact":"1","field":"2","value":"blue, green"}]}`)
+		}
+	}))
+	defer s.Close()
+
+	var out bytes.Buffer
+	e := Exporter{Client: campaigner.New("token", s.URL), Fields: []string{"favorite color"}, PageSize: 2}
+
+	n, err := e.Export(context.Background(), &out)
+	require.Nil(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"0", "2"}, offsets)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "id,email,first_name,last_name,phone,favorite color", lines[0])
+	assert.Equal(t, `1,user1@user.com,User,1,,"blue, green"`, lines[1])
+}
+
+func TestExport_FailureUnknownField(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"fields":[],"meta":{"total":"0"}}`)
+	}))
+	defer s.Close()
+
+	e := Exporter{Client: campaigner.New("token", s.URL), Fields: []string{"Missing"}}
+
+	_, err := e.Export(context.Background(), &bytes.Buffer{})
+	assert.NotNil(t, err)
+}
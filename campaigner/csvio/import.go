@@ -0,0 +1,288 @@
+package csvio
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/henrocdotnet/active-campaigner/campaigner"
+)
+
+// Importer imports contacts from a CSV file using the bulk import API.  Client must be set up with campaigner.New (or
+// Init).
+type Importer struct {
+	Client  *campaigner.Campaigner
+	Mapping Mapping
+
+	// DryRun validates and maps every row without sending anything to the API.
+	DryRun bool
+
+	// Rejects receives rejected rows as CSV (the original columns followed by an error column).  Optional.
+	Rejects io.Writer
+}
+
+// ImportResult holds the outcome of an import.
+type ImportResult struct {
+	Rows     int
+	Accepted int
+	Rejected []Reject
+	BatchIDs []string
+}
+
+// Reject holds a row that could not be imported.  Row is the 1-based row number in the file (the header is row 1).
+type Reject struct {
+	Row     int
+	Record  []string
+	Reasons []string
+}
+
+// A mapped row waiting to be sent.
+type importRow struct {
+	row     int
+	record  []string
+	contact campaigner.BulkImportContact
+}
+
+// Import reads contacts from r and imports them.  Rows that fail validation or are rejected by the API are reported in
+// the result (and written to Rejects) rather than stopping the import.
+func (i Importer) Import(ctx context.Context, r io.Reader) (result ImportResult, err error) {
+	// Setup.
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return result, fmt.Errorf("csv import failed, could not read header: %s", err)
+	}
+
+	fields, err := i.fields(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	titles := map[string]string{}
+	for k := range fields {
+		titles[strings.ToLower(k)] = k
+	}
+
+	targets, err := i.Mapping.resolve(header, titles)
+	if err != nil {
+		return result, fmt.Errorf("csv import failed, %s", err)
+	}
+
+	lists, err := i.lists(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	rejects, err := newRejectWriter(i.Rejects, header)
+	if err != nil {
+		return result, err
+	}
+
+	// Map rows.
+	var rows []importRow
+	for n := 2; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if _, ok := err.(*csv.ParseError); !ok {
+				return result, fmt.Errorf("csv import failed, could not read row %d: %s", n, err)
+			}
+		}
+
+		result.Rows++
+
+		var contact campaigner.BulkImportContact
+		if err == nil {
+			contact, err = i.mapRecord(record, targets, fields, lists)
+		}
+		if err != nil {
+			reject := Reject{Row: n, Record: record, Reasons: []string{err.Error()}}
+			result.Rejected = append(result.Rejected, reject)
+			if err := rejects.write(reject); err != nil {
+				return result, err
+			}
+			continue
+		}
+
+		rows = append(rows, importRow{row: n, record: record, contact: contact})
+	}
+
+	if i.DryRun || len(rows) == 0 {
+		result.Accepted = len(rows)
+		return result, rejects.flush()
+	}
+
+	// Send contacts.
+	request := campaigner.RequestBulkImport{}
+	for _, y := range rows {
+		request.Contacts = append(request.Contacts, y.contact)
+	}
+
+	response, err := i.Client.BulkImports.Create(ctx, request)
+	if err != nil {
+		return result, fmt.Errorf("csv import failed, %s", err)
+	}
+
+	result.BatchIDs = response.BatchIDs()
+	result.Accepted = len(rows) - len(response.Failures)
+	for _, f := range response.Failures {
+		reject := Reject{Row: rows[f.Index].row, Record: rows[f.Index].record, Reasons: f.Reasons}
+		result.Rejected = append(result.Rejected, reject)
+		if err := rejects.write(reject); err != nil {
+			return result, err
+		}
+	}
+
+	return result, rejects.flush()
+}
+
+// Maps a single record to a bulk import contact.
+func (i Importer) mapRecord(record []string, targets []string, fields map[string]int64, lists map[string]int64) (contact campaigner.BulkImportContact, err error) {
+	sep := i.Mapping.separator()
+
+	if len(record) != len(targets) {
+		return contact, fmt.Errorf("expected %d columns, got %d", len(targets), len(record))
+	}
+
+	contact.Tags = append(contact.Tags, i.Mapping.Tags...)
+	names := append([]string{}, i.Mapping.Lists...)
+
+	for x, v := range record {
+		v = strings.TrimSpace(v)
+
+		switch t := targets[x]; t {
+		case TARGET_EMAIL:
+			contact.EmailAddress = v
+		case TARGET_FIRST_NAME:
+			contact.FirstName = v
+		case TARGET_LAST_NAME:
+			contact.LastName = v
+		case TARGET_PHONE:
+			contact.PhoneNumber = v
+		case TARGET_ACCOUNT:
+			contact.CustomerAccountName = v
+		case TARGET_TAGS:
+			contact.Tags = append(contact.Tags, split(v, sep)...)
+		case TARGET_LISTS:
+			names = append(names, split(v, sep)...)
+		case TARGET_IGNORE:
+		default:
+			if len(v) > 0 {
+				id := fields[fieldTitle(t, fields)]
+				contact.Fields = append(contact.Fields, campaigner.BulkImportField{ID: id, Value: v})
+			}
+		}
+	}
+
+	// Validation.
+	if len(contact.EmailAddress) == 0 {
+		return contact, fmt.Errorf("email is empty")
+	} else if !strings.Contains(contact.EmailAddress, "@") {
+		return contact, fmt.Errorf("email `%s` is invalid", contact.EmailAddress)
+	}
+
+	for _, n := range names {
+		id, ok := lists[strings.ToLower(n)]
+		if !ok {
+			return contact, fmt.Errorf("list `%s` not found", n)
+		}
+		contact.Subscribe = append(contact.Subscribe, campaigner.BulkImportSubscription{ListID: id})
+	}
+
+	return contact, nil
+}
+
+// Returns custom field IDs by title.
+func (i Importer) fields(ctx context.Context) (map[string]int64, error) {
+	m := map[string]int64{}
+
+	r, err := i.Client.Fields.List(ctx)
+	if err != nil {
+		return m, fmt.Errorf("csv import failed, could not list fields: %s", err)
+	}
+
+	for _, f := range r.Fields {
+		m[f.Title] = f.ID.Int64()
+	}
+
+	return m, nil
+}
+
+// Returns list IDs by lowercase name and by ID (as a string).
+func (i Importer) lists(ctx context.Context) (map[string]int64, error) {
+	m := map[string]int64{}
+
+	r, err := i.Client.Lists.List(ctx)
+	if err != nil {
+		return m, fmt.Errorf("csv import failed, could not list lists: %s", err)
+	}
+
+	for _, l := range r.Lists {
+		m[strings.ToLower(l.Name)] = l.ID.Int64()
+		m[strconv.FormatInt(l.ID.Int64(), 10)] = l.ID.Int64()
+	}
+
+	return m, nil
+}
+
+// Returns the exact field title for a "field:" target (mapping configs are matched without case).
+func fieldTitle(target string, fields map[string]int64) string {
+	title := strings.TrimPrefix(target, TARGET_FIELD_PREFIX)
+	for k := range fields {
+		if strings.EqualFold(k, title) {
+			return k
+		}
+	}
+
+	return title
+}
+
+// Writes rejected rows as CSV.  Does nothing if there is no writer.
+type rejectWriter struct {
+	w *csv.Writer
+}
+
+func newRejectWriter(w io.Writer, header []string) (rejectWriter, error) {
+	if w == nil {
+		return rejectWriter{}, nil
+	}
+
+	r := rejectWriter{w: csv.NewWriter(w)}
+	if err := r.w.Write(append(append([]string{}, header...), "error")); err != nil {
+		return r, fmt.Errorf("csv import failed, could not write rejects: %s", err)
+	}
+
+	return r, nil
+}
+
+func (r rejectWriter) write(reject Reject) error {
+	if r.w == nil {
+		return nil
+	}
+
+	if err := r.w.Write(append(append([]string{}, reject.Record...), strings.Join(reject.Reasons, "; "))); err != nil {
+		return fmt.Errorf("csv import failed, could not write rejects: %s", err)
+	}
+
+	return nil
+}
+
+func (r rejectWriter) flush() error {
+	if r.w == nil {
+		return nil
+	}
+
+	r.w.Flush()
+	if err := r.w.Error(); err != nil {
+		return fmt.Errorf("csv import failed, could not write rejects: %s", err)
+	}
+
+	return nil
+}
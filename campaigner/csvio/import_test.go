@@ -0,0 +1,118 @@
+package csvio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/henrocdotnet/active-campaigner/campaigner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Starts a test server with two custom fields, two lists and a bulk import endpoint that rejects `bad@user.com`.
+func newTestServer(t *testing.T, imported *[]campaigner.BulkImportContact) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/3/fields":
+			fmt.Fprint(w, `{"fields":[{"id":"1","title":"Company Size"},{"id":"2","title":"Favorite Color"}],"meta":{"total":"2"}}`)
+		case "/api/3/lists":
+			fmt.Fprint(w, `{"lists":[{"id":"1","name":"Newsletter"},{"id":"2","name":"Customers"}],"meta":{"total":"2"}}`)
+		case "/api/3/import/bulk_import":
+			var req campaigner.RequestBulkImport
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+
+			for x, y := range req.Contacts {
+				if y.EmailAddress == "bad@user.com" {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, `{"success":0,"failureReasons":[{"contact":%d,"failureReasons":["blocked"]}]}`, x)
+					return
+				}
+			}
+
+			*imported = append(*imported, req.Contacts...)
+			fmt.Fprintf(w, `{"success":1,"queued_contacts":%d,"batchId":"batch-1"}`, len(req.Contacts))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+}
+
+func TestImport_Success(t *testing.T) {
+	var imported []campaigner.BulkImportContact
+	s := newTestServer(t, &imported)
+	defer s.Close()
+
+	in := strings.Join([]string{
+		"E-Mail,First Name,Last Name,company size,Groups,Tags,Notes",
+		"one@user.com,One,User,10,Newsletter,\"a, b\",ignored",
+		"two@user.com,Two,User,,2,,",
+		",Missing,Email,,,,",
+		"three@user.com,Three,User,,Unknown,,",
+		"bad@user.com,Bad,User,,,,",
+	}, "\n")
+
+	var rejects bytes.Buffer
+	i := Importer{
+		Client:  campaigner.New("token", s.URL),
+		Mapping: Mapping{Columns: map[string]string{"Groups": TARGET_LISTS, "Notes": TARGET_IGNORE}, Tags: []string{"Imported"}},
+		Rejects: &rejects,
+	}
+
+	r, err := i.Import(context.Background(), strings.NewReader(in))
+	require.Nil(t, err)
+
+	assert.Equal(t, 5, r.Rows)
+	assert.Equal(t, 2, r.Accepted)
+	assert.Equal(t, []string{"batch-1"}, r.BatchIDs)
+	require.Len(t, r.Rejected, 3)
+	assert.Equal(t, 4, r.Rejected[0].Row)
+	assert.Equal(t, 5, r.Rejected[1].Row)
+	assert.Equal(t, 6, r.Rejected[2].Row)
+	assert.Equal(t, []string{"blocked"}, r.Rejected[2].Reasons)
+
+	require.Len(t, imported, 2)
+	assert.Equal(t, "one@user.com", imported[0].EmailAddress)
+	assert.Equal(t, []string{"Imported", "a", "b"}, imported[0].Tags)
+	assert.Equal(t, []campaigner.BulkImportField{{ID: 1, Value: "10"}}, imported[0].Fields)
+	assert.Equal(t, []campaigner.BulkImportSubscription{{ListID: 1}}, imported[0].Subscribe)
+	assert.Equal(t, []campaigner.BulkImportSubscription{{ListID: 2}}, imported[1].Subscribe)
+
+	lines := strings.Split(strings.TrimSpace(rejects.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "E-Mail,First Name,Last Name,company size,Groups,Tags,Notes,error", lines[0])
+	assert.Equal(t, "three@user.com,Three,User,,Unknown,,,list `Unknown` not found", lines[2])
+}
+
+func TestImport_SuccessDryRun(t *testing.T) {
+	var imported []campaigner.BulkImportContact
+	s := newTestServer(t, &imported)
+	defer s.Close()
+
+	i := Importer{Client: campaigner.New("token", s.URL), DryRun: true}
+
+	r, err := i.Import(context.Background(), strings.NewReader("email\none@user.com\ntwo@user.com\n"))
+	require.Nil(t, err)
+	assert.Equal(t, 2, r.Accepted)
+	assert.Empty(t, r.BatchIDs)
+	assert.Empty(t, imported)
+}
+
+func TestImport_FailureUnknownField(t *testing.T) {
+	var imported []campaigner.BulkImportContact
+	s := newTestServer(t, &imported)
+	defer s.Close()
+
+	i := Importer{
+		Client:  campaigner.New("token", s.URL),
+		Mapping: Mapping{Columns: map[string]string{"Size": TARGET_FIELD_PREFIX + "Size"}},
+	}
+
+	_, err := i.Import(context.Background(), strings.NewReader("email,Size\none@user.com,10\n"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,134 @@
+// Package csvio imports contacts from and exports contacts to CSV files.
+package csvio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// Column targets.  Custom fields are targeted by title using TARGET_FIELD_PREFIX, e.g. "field:Company Size".
+const (
+	TARGET_EMAIL        = "email"
+	TARGET_FIRST_NAME   = "first_name"
+	TARGET_LAST_NAME    = "last_name"
+	TARGET_PHONE        = "phone"
+	TARGET_ACCOUNT      = "account"
+	TARGET_TAGS         = "tags"
+	TARGET_LISTS        = "lists"
+	TARGET_IGNORE       = "-"
+	TARGET_FIELD_PREFIX = "field:"
+)
+
+// DEFAULT_SEPARATOR separates multiple tags or lists inside a single CSV cell.
+const DEFAULT_SEPARATOR = ","
+
+// Mapping maps CSV header names to contact targets.
+//
+// Headers without an explicit mapping are matched automatically against the standard targets (ignoring case, spaces,
+// dashes and underscores) and then against custom field titles.  Headers that still don't match are ignored.
+type Mapping struct {
+	Columns   map[string]string `json:"columns"`
+	Separator string            `json:"separator"`
+	Tags      []string          `json:"tags"`
+	Lists     []string          `json:"lists"`
+}
+
+// LoadMapping reads a JSON mapping config file.
+func LoadMapping(path string) (m Mapping, err error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return m, fmt.Errorf("mapping load failed, could not read file: %s", err)
+	}
+
+	if err = json.Unmarshal(b, &m); err != nil {
+		return m, fmt.Errorf("mapping load failed, JSON error: %s", err)
+	}
+
+	return m, nil
+}
+
+// Returns the configured separator or the default.
+func (m Mapping) separator() string {
+	if len(m.Separator) == 0 {
+		return DEFAULT_SEPARATOR
+	}
+
+	return m.Separator
+}
+
+// Resolves the target for every column in a header.  Fields maps lowercase custom field titles to their titles.
+func (m Mapping) resolve(header []string, fields map[string]string) (targets []string, err error) {
+	for _, h := range header {
+		t, ok := m.Columns[h]
+		if !ok {
+			t = autoTarget(h, fields)
+		}
+
+		t = strings.TrimSpace(t)
+		if strings.HasPrefix(t, TARGET_FIELD_PREFIX) {
+			title := strings.TrimPrefix(t, TARGET_FIELD_PREFIX)
+			if _, ok := fields[strings.ToLower(title)]; !ok {
+				return targets, fmt.Errorf("column `%s` is mapped to unknown custom field `%s`", h, title)
+			}
+		} else if !isStandardTarget(t) {
+			return targets, fmt.Errorf("column `%s` is mapped to unknown target `%s`", h, t)
+		}
+
+		targets = append(targets, t)
+	}
+
+	return targets, nil
+}
+
+// Guesses the target for a header that has no explicit mapping.
+func autoTarget(header string, fields map[string]string) string {
+	n := strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(header)))
+
+	switch n {
+	case "email", "emailaddress":
+		return TARGET_EMAIL
+	case "firstname":
+		return TARGET_FIRST_NAME
+	case "lastname":
+		return TARGET_LAST_NAME
+	case "phone", "phonenumber":
+		return TARGET_PHONE
+	case "account", "accountname", "organization":
+		return TARGET_ACCOUNT
+	case "tags":
+		return TARGET_TAGS
+	case "lists":
+		return TARGET_LISTS
+	}
+
+	if title, ok := fields[strings.ToLower(strings.TrimSpace(header))]; ok {
+		return TARGET_FIELD_PREFIX + title
+	}
+
+	return TARGET_IGNORE
+}
+
+// Checks whether a target is one of the standard (non custom field) targets.
+func isStandardTarget(t string) bool {
+	switch t {
+	case TARGET_EMAIL, TARGET_FIRST_NAME, TARGET_LAST_NAME, TARGET_PHONE, TARGET_ACCOUNT, TARGET_TAGS, TARGET_LISTS, TARGET_IGNORE:
+		return true
+	}
+
+	return false
+}
+
+// Splits a multi-value cell, dropping empty values.
+func split(s string, sep string) []string {
+	var l []string
+
+	for _, v := range strings.Split(s, sep) {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			l = append(l, v)
+		}
+	}
+
+	return l
+}
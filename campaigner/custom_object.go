@@ -0,0 +1,367 @@
+package campaigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Custom object field types.
+const (
+	CUSTOM_OBJECT_FIELD_TEXT        = "text"
+	CUSTOM_OBJECT_FIELD_TEXTAREA    = "textarea"
+	CUSTOM_OBJECT_FIELD_NUMBER      = "number"
+	CUSTOM_OBJECT_FIELD_CURRENCY    = "currency"
+	CUSTOM_OBJECT_FIELD_DATE        = "date"
+	CUSTOM_OBJECT_FIELD_DATETIME    = "datetime"
+	CUSTOM_OBJECT_FIELD_DROPDOWN    = "dropdown"
+	CUSTOM_OBJECT_FIELD_MULTISELECT = "multiselect"
+)
+
+// CUSTOM_OBJECT_PRIMARY_CONTACT is the relationship linking records to contacts.
+const CUSTOM_OBJECT_PRIMARY_CONTACT = "primary-contact"
+
+// CustomObjectLabels holds the singular and plural labels of a schema, field or relationship.
+type CustomObjectLabels struct {
+	Singular string `json:"singular"`
+	Plural   string `json:"plural"`
+}
+
+// CustomObjectSchema holds a JSON compatible custom object schema as it exists in the API.  Schema IDs are UUIDs.
+type CustomObjectSchema struct {
+	ID               string                     `json:"id,omitempty"`
+	Slug             string                     `json:"slug"`
+	Visibility       string                     `json:"visibility,omitempty"`
+	Labels           CustomObjectLabels         `json:"labels"`
+	Description      string                     `json:"description"`
+	Fields           []CustomObjectField        `json:"fields"`
+	Relationships    []CustomObjectRelationship `json:"relationships,omitempty"`
+	CreatedTimestamp ACTime                     `json:"createdTimestamp"`
+	UpdatedTimestamp ACTime                     `json:"updatedTimestamp"`
+}
+
+// Field returns the field with the given ID.
+func (s CustomObjectSchema) Field(id string) (CustomObjectField, bool) {
+	for _, f := range s.Fields {
+		if f.ID == id {
+			return f, true
+		}
+	}
+
+	return CustomObjectField{}, false
+}
+
+// CustomObjectField holds a JSON compatible field of a custom object schema.  The ID is chosen when the field is
+// created and is the key of the field in records.
+type CustomObjectField struct {
+	ID              string                    `json:"id"`
+	Labels          CustomObjectLabels        `json:"labels"`
+	Type            string                    `json:"type"`
+	Description     string                    `json:"description,omitempty"`
+	IsRequired      bool                      `json:"isRequired"`
+	Scale           int                       `json:"scale,omitempty"`
+	DefaultCurrency string                    `json:"defaultCurrency,omitempty"`
+	Options         []CustomObjectFieldOption `json:"options,omitempty"`
+}
+
+// CustomObjectFieldOption holds an option of a dropdown or multiselect field.
+type CustomObjectFieldOption struct {
+	ID    string `json:"id,omitempty"`
+	Value string `json:"value"`
+}
+
+// CustomObjectRelationship holds a JSON compatible relationship of a custom object schema, e.g. primary-contact.
+type CustomObjectRelationship struct {
+	ID          string             `json:"id"`
+	Labels      CustomObjectLabels `json:"labels"`
+	Description string             `json:"description,omitempty"`
+	Namespace   string             `json:"namespace"`
+	HasMany     bool               `json:"hasMany"`
+}
+
+// CustomObjectRecord holds a JSON compatible custom object record as it exists in the API.  Record IDs are UUIDs,
+// ExternalID is the ID of the record in your system.  Relationships hold the related IDs by relationship ID, e.g.
+// the contact IDs under CUSTOM_OBJECT_PRIMARY_CONTACT.
+type CustomObjectRecord struct {
+	ID               string                    `json:"id,omitempty"`
+	ExternalID       string                    `json:"externalId,omitempty"`
+	SchemaID         string                    `json:"schemaId,omitempty"`
+	Fields           []CustomObjectRecordField `json:"fields"`
+	Relationships    map[string][]Int64json    `json:"relationships,omitempty"`
+	CreatedTimestamp ACTime                    `json:"createdTimestamp"`
+	UpdatedTimestamp ACTime                    `json:"updatedTimestamp"`
+}
+
+// CustomObjectRecordField holds the value of a field in a record.
+type CustomObjectRecordField struct {
+	ID    string      `json:"id"`
+	Value interface{} `json:"value"`
+}
+
+// NewCustomObjectRecord returns a record holding the values of v, either a map[string]interface{} keyed by field ID
+// or a struct with JSON tags naming the field IDs, e.g.
+//
+//	type Entitlement struct {
+//		Plan    string `json:"plan"`
+//		Seats   int    `json:"seats"`
+//		Expires ACTime `json:"expires"`
+//	}
+func NewCustomObjectRecord(externalID string, v interface{}) (CustomObjectRecord, error) {
+	record := CustomObjectRecord{ExternalID: externalID}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return record, fmt.Errorf("custom object record failed, could not marshall json for interface: %s", err)
+	}
+
+	var values map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err = d.Decode(&values); err != nil {
+		return record, fmt.Errorf("custom object record failed, values are not an object: %s", err)
+	}
+
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		record.Fields = append(record.Fields, CustomObjectRecordField{ID: id, Value: values[id]})
+	}
+
+	return record, nil
+}
+
+// Values returns the field values of a record by field ID.
+func (r CustomObjectRecord) Values() map[string]interface{} {
+	m := make(map[string]interface{}, len(r.Fields))
+
+	for _, f := range r.Fields {
+		m[f.ID] = f.Value
+	}
+
+	return m
+}
+
+// Decode decodes the field values of a record into v, a struct with JSON tags naming the field IDs (see
+// NewCustomObjectRecord).
+func (r CustomObjectRecord) Decode(v interface{}) error {
+	b, err := json.Marshal(r.Values())
+	if err != nil {
+		return fmt.Errorf("custom object record decode failed, JSON error: %s", err)
+	}
+
+	if err = json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("custom object record decode failed, JSON error: %s", err)
+	}
+
+	return nil
+}
+
+// ResponseCustomObjectSchemaRead holds a JSON compatible response for reading, creating and updating schemas.
+type ResponseCustomObjectSchemaRead struct {
+	Schema CustomObjectSchema `json:"schema"`
+}
+
+// ResponseCustomObjectSchemaList holds a JSON compatible response for listing schemas.
+type ResponseCustomObjectSchemaList struct {
+	Schemas []CustomObjectSchema `json:"schemas"`
+	Meta    struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// ResponseCustomObjectRecordRead holds a JSON compatible response for reading and upserting records.
+type ResponseCustomObjectRecordRead struct {
+	Record CustomObjectRecord `json:"record"`
+}
+
+// ResponseCustomObjectRecordList holds a JSON compatible response for listing records.
+type ResponseCustomObjectRecordList struct {
+	Records []CustomObjectRecord `json:"records"`
+	Meta    struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// CreateSchema creates a schema.
+func (s *CustomObjectAPI) CreateSchema(ctx context.Context, schema CustomObjectSchema) (response ResponseCustomObjectSchemaRead, err error) {
+	r, body, err := s.client.post(ctx, "/api/3/customObjects/schemas", map[string]interface{}{"schema": schemaRequest(schema)})
+	if err != nil {
+		return response, fmt.Errorf("custom object schema creation failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "custom object schema creation", "schema")
+
+	return response, err
+}
+
+// DeleteSchema deletes a schema and all of it's records.
+func (s *CustomObjectAPI) DeleteSchema(ctx context.Context, id string) error {
+	r, body, err := s.client.delete(ctx, "/api/3/customObjects/schemas/"+url.PathEscape(id))
+	if err != nil {
+		return fmt.Errorf("custom object schema delete failed, HTTP error: %s", err)
+	}
+
+	return s.client.result(r, body, nil, "custom object schema delete", fmt.Sprintf("schema %s", id))
+}
+
+// ListSchemas lists schemas with their fields and relationships.
+func (s *CustomObjectAPI) ListSchemas(ctx context.Context, limit int, offset int) (response ResponseCustomObjectSchemaList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	qs.Set("showFields", "all")
+	u := url.URL{Path: "/api/3/customObjects/schemas", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("custom object schema list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "custom object schema list", "schema")
+
+	return response, err
+}
+
+// ReadSchema reads a schema by it's ID.
+func (s *CustomObjectAPI) ReadSchema(ctx context.Context, id string) (response ResponseCustomObjectSchemaRead, err error) {
+	r, body, err := s.client.get(ctx, "/api/3/customObjects/schemas/"+url.PathEscape(id)+"?showFields=all")
+	if err != nil {
+		return response, fmt.Errorf("custom object schema read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "custom object schema read", fmt.Sprintf("schema %s", id))
+
+	return response, err
+}
+
+// UpdateSchema updates a schema.  Fields and relationships can be added but not removed, fields missing from the
+// request are left unchanged.
+func (s *CustomObjectAPI) UpdateSchema(ctx context.Context, id string, schema CustomObjectSchema) (response ResponseCustomObjectSchemaRead, err error) {
+	r, body, err := s.client.put(ctx, "/api/3/customObjects/schemas/"+url.PathEscape(id), map[string]interface{}{"schema": schemaRequest(schema)})
+	if err != nil {
+		return response, fmt.Errorf("custom object schema update failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "custom object schema update", fmt.Sprintf("schema %s", id))
+
+	return response, err
+}
+
+// DeleteRecord deletes a record by it's ID.
+func (s *CustomObjectAPI) DeleteRecord(ctx context.Context, schemaID string, id string) error {
+	r, body, err := s.client.delete(ctx, recordPath(schemaID, id))
+	if err != nil {
+		return fmt.Errorf("custom object record delete failed, HTTP error: %s", err)
+	}
+
+	return s.client.result(r, body, nil, "custom object record delete", fmt.Sprintf("record %s", id))
+}
+
+// DeleteRecordByExternalID deletes a record by it's external ID.
+func (s *CustomObjectAPI) DeleteRecordByExternalID(ctx context.Context, schemaID string, externalID string) error {
+	r, body, err := s.client.delete(ctx, recordPath(schemaID, "external", externalID))
+	if err != nil {
+		return fmt.Errorf("custom object record delete failed, HTTP error: %s", err)
+	}
+
+	return s.client.result(r, body, nil, "custom object record delete", fmt.Sprintf("record with external ID %s", externalID))
+}
+
+// ListRecords lists the records of a schema.
+func (s *CustomObjectAPI) ListRecords(ctx context.Context, schemaID string, limit int, offset int) (response ResponseCustomObjectRecordList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, recordPath(schemaID)+"?"+qs.Encode())
+	if err != nil {
+		return response, fmt.Errorf("custom object record list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "custom object record list", fmt.Sprintf("schema %s", schemaID))
+
+	return response, err
+}
+
+// ReadRecord reads a record by it's ID.
+func (s *CustomObjectAPI) ReadRecord(ctx context.Context, schemaID string, id string) (response ResponseCustomObjectRecordRead, err error) {
+	r, body, err := s.client.get(ctx, recordPath(schemaID, id))
+	if err != nil {
+		return response, fmt.Errorf("custom object record read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "custom object record read", fmt.Sprintf("record %s", id))
+
+	return response, err
+}
+
+// ReadRecordByExternalID reads a record by it's external ID.
+func (s *CustomObjectAPI) ReadRecordByExternalID(ctx context.Context, schemaID string, externalID string) (response ResponseCustomObjectRecordRead, err error) {
+	r, body, err := s.client.get(ctx, recordPath(schemaID, "external", externalID))
+	if err != nil {
+		return response, fmt.Errorf("custom object record read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "custom object record read", fmt.Sprintf("record with external ID %s", externalID))
+
+	return response, err
+}
+
+// UpsertRecord creates a record, or updates the record with the same ID or external ID.  Fields missing from the
+// request are left unchanged.
+func (s *CustomObjectAPI) UpsertRecord(ctx context.Context, schemaID string, record CustomObjectRecord) (response ResponseCustomObjectRecordRead, err error) {
+	// Setup.
+	var data = map[string]interface{}{
+		"record": struct {
+			ID            string                    `json:"id,omitempty"`
+			ExternalID    string                    `json:"externalId,omitempty"`
+			Fields        []CustomObjectRecordField `json:"fields"`
+			Relationships map[string][]Int64json    `json:"relationships,omitempty"`
+		}{record.ID, record.ExternalID, record.Fields, record.Relationships},
+	}
+
+	// Send POST request.
+	r, body, err := s.client.post(ctx, recordPath(schemaID), data)
+	if err != nil {
+		return response, fmt.Errorf("custom object record upsert failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "custom object record upsert", fmt.Sprintf("schema %s", schemaID))
+
+	return response, err
+}
+
+// Returns the path of the records of a schema, with escaped segments appended.
+func recordPath(schemaID string, segments ...string) string {
+	p := "/api/3/customObjects/records/" + url.PathEscape(schemaID)
+	for _, s := range segments {
+		p += "/" + url.PathEscape(s)
+	}
+
+	return p
+}
+
+// Returns the writable part of a schema (timestamps are set by the API).
+func schemaRequest(schema CustomObjectSchema) interface{} {
+	return struct {
+		Slug          string                     `json:"slug"`
+		Visibility    string                     `json:"visibility,omitempty"`
+		Labels        CustomObjectLabels         `json:"labels"`
+		Description   string                     `json:"description"`
+		Fields        []CustomObjectField        `json:"fields"`
+		Relationships []CustomObjectRelationship `json:"relationships,omitempty"`
+	}{strings.TrimSpace(schema.Slug), schema.Visibility, schema.Labels, schema.Description, schema.Fields, schema.Relationships}
+}
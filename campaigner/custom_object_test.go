@@ -0,0 +1,177 @@
+package campaigner
+
+import (
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Tests all custom object functionality as a group.  These tests run against a local test server.
+func TestCustomObjectSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestCustomObject_SuccessSchema)
+	runTestWithPackagePath(t, TestCustomObject_SuccessRecord)
+	runTestWithPackagePath(t, TestCustomObject_FailureRecord)
+	runTestWithPackagePath(t, TestCustomObject_RecordValues)
+}
+
+// A record type used by the tests.
+type testEntitlement struct {
+	Plan    string `json:"plan"`
+	Seats   int    `json:"seats"`
+	Expires ACTime `json:"expires"`
+}
+
+// Returns a schema used by the tests.
+func testEntitlementSchema() CustomObjectSchema {
+	return CustomObjectSchema{
+		Slug:        "entitlement",
+		Labels:      CustomObjectLabels{Singular: "Entitlement", Plural: "Entitlements"},
+		Description: "Product entitlements",
+		Fields: []CustomObjectField{
+			{ID: "plan", Labels: CustomObjectLabels{Singular: "Plan", Plural: "Plans"}, Type: CUSTOM_OBJECT_FIELD_DROPDOWN, IsRequired: true, Options: []CustomObjectFieldOption{{Value: "basic"}, {Value: "pro"}}},
+			{ID: "seats", Labels: CustomObjectLabels{Singular: "Seats", Plural: "Seats"}, Type: CUSTOM_OBJECT_FIELD_NUMBER},
+			{ID: "expires", Labels: CustomObjectLabels{Singular: "Expires", Plural: "Expires"}, Type: CUSTOM_OBJECT_FIELD_DATE},
+		},
+		Relationships: []CustomObjectRelationship{
+			{ID: CUSTOM_OBJECT_PRIMARY_CONTACT, Labels: CustomObjectLabels{Singular: "Contact", Plural: "Contacts"}, Namespace: "contacts", HasMany: false},
+		},
+	}
+}
+
+func TestCustomObject_SuccessSchema(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	created, err := c.CustomObjects.CreateSchema(ctx, testEntitlementSchema())
+	require.Nil(t, err)
+	id := created.Schema.ID
+	assert.NotEmpty(t, id)
+	require.Len(t, created.Schema.Fields, 3)
+	assert.Len(t, created.Schema.Fields[0].Options, 2)
+
+	// Fields are added, not replaced.
+	update := CustomObjectSchema{Fields: []CustomObjectField{{ID: "renews", Labels: CustomObjectLabels{Singular: "Renews", Plural: "Renews"}, Type: CUSTOM_OBJECT_FIELD_DATETIME}}}
+	updated, err := c.CustomObjects.UpdateSchema(ctx, id, update)
+	require.Nil(t, err)
+	assert.Len(t, updated.Schema.Fields, 4)
+	assert.Equal(t, "entitlement", updated.Schema.Slug)
+
+	read, err := c.CustomObjects.ReadSchema(ctx, id)
+	require.Nil(t, err)
+	f, ok := read.Schema.Field("renews")
+	require.True(t, ok)
+	assert.Equal(t, CUSTOM_OBJECT_FIELD_DATETIME, f.Type)
+	require.Len(t, read.Schema.Relationships, 1)
+	assert.Equal(t, "contacts", read.Schema.Relationships[0].Namespace)
+
+	list, err := c.CustomObjects.ListSchemas(ctx, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, list.Schemas, 1)
+	assert.Equal(t, int64(1), list.Meta.Total.Int64())
+
+	_, err = c.CustomObjects.CreateSchema(ctx, testEntitlementSchema())
+	assert.IsType(t, ActiveCampaignError{}, err)
+
+	require.Nil(t, c.CustomObjects.DeleteSchema(ctx, id))
+	_, err = c.CustomObjects.ReadSchema(ctx, id)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestCustomObject_SuccessRecord(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	contact := s.AddContact(actest.Contact{EmailAddress: "records@example.com"})
+
+	schema, err := c.CustomObjects.CreateSchema(ctx, testEntitlementSchema())
+	require.Nil(t, err)
+	schemaID := schema.Schema.ID
+
+	// Create from a struct.
+	record, err := NewCustomObjectRecord("sub-1", testEntitlement{Plan: "basic", Seats: 5, Expires: NewACTime(time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC))})
+	require.Nil(t, err)
+	record.Relationships = map[string][]Int64json{CUSTOM_OBJECT_PRIMARY_CONTACT: {Int64json(contact)}}
+
+	created, err := c.CustomObjects.UpsertRecord(ctx, schemaID, record)
+	require.Nil(t, err)
+	id := created.Record.ID
+	assert.NotEmpty(t, id)
+	assert.Equal(t, []Int64json{Int64json(contact)}, created.Record.Relationships[CUSTOM_OBJECT_PRIMARY_CONTACT])
+
+	// Upsert by external ID from a map, other fields are kept.
+	record, err = NewCustomObjectRecord("sub-1", map[string]interface{}{"plan": "pro"})
+	require.Nil(t, err)
+	updated, err := c.CustomObjects.UpsertRecord(ctx, schemaID, record)
+	require.Nil(t, err)
+	assert.Equal(t, id, updated.Record.ID)
+
+	read, err := c.CustomObjects.ReadRecordByExternalID(ctx, schemaID, "sub-1")
+	require.Nil(t, err)
+	var e testEntitlement
+	require.Nil(t, read.Record.Decode(&e))
+	assert.Equal(t, "pro", e.Plan)
+	assert.Equal(t, 5, e.Seats)
+	assert.Equal(t, 2030, e.Expires.Year())
+
+	read, err = c.CustomObjects.ReadRecord(ctx, schemaID, id)
+	require.Nil(t, err)
+	assert.Equal(t, "pro", read.Record.Values()["plan"])
+
+	list, err := c.CustomObjects.ListRecords(ctx, schemaID, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, list.Records, 1)
+
+	require.Nil(t, c.CustomObjects.DeleteRecordByExternalID(ctx, schemaID, "sub-1"))
+	_, err = c.CustomObjects.ReadRecord(ctx, schemaID, id)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+	assert.IsType(t, CustomErrorNotFound{}, c.CustomObjects.DeleteRecord(ctx, schemaID, id))
+}
+
+func TestCustomObject_FailureRecord(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	schema, err := c.CustomObjects.CreateSchema(ctx, testEntitlementSchema())
+	require.Nil(t, err)
+	schemaID := schema.Schema.ID
+
+	for _, values := range []map[string]interface{}{
+		{"seats": 1},                    // Required field missing.
+		{"plan": "gold"},                // Not an option.
+		{"plan": "basic", "seats": "x"}, // Not a number.
+		{"plan": "basic", "color": "x"}, // Unknown field.
+	} {
+		record, err := NewCustomObjectRecord("sub-1", values)
+		require.Nil(t, err)
+		_, err = c.CustomObjects.UpsertRecord(ctx, schemaID, record)
+		assert.IsType(t, ActiveCampaignError{}, err, "%v", values)
+	}
+
+	// Missing contact.
+	record, err := NewCustomObjectRecord("sub-1", map[string]interface{}{"plan": "basic"})
+	require.Nil(t, err)
+	record.Relationships = map[string][]Int64json{CUSTOM_OBJECT_PRIMARY_CONTACT: {99}}
+	_, err = c.CustomObjects.UpsertRecord(ctx, schemaID, record)
+	assert.IsType(t, ActiveCampaignError{}, err)
+
+	_, err = c.CustomObjects.ListRecords(ctx, "missing", 20, 0)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+
+	_, err = NewCustomObjectRecord("sub-1", []string{"not", "an", "object"})
+	assert.NotNil(t, err)
+}
+
+func TestCustomObject_RecordValues(t *testing.T) {
+	record, err := NewCustomObjectRecord("x", map[string]interface{}{"b": 2, "a": "one"})
+	require.Nil(t, err)
+	require.Len(t, record.Fields, 2)
+	assert.Equal(t, "a", record.Fields[0].ID)
+	assert.Equal(t, "b", record.Fields[1].ID)
+
+	var v struct {
+		A string    `json:"a"`
+		B Int64json `json:"b"`
+	}
+	require.Nil(t, record.Decode(&v))
+	assert.Equal(t, "one", v.A)
+	assert.Equal(t, Int64json(2), v.B)
+}
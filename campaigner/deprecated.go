@@ -0,0 +1,237 @@
+package campaigner
+
+import (
+	"context"
+	"time"
+)
+
+// Flat client methods kept for compatibility.  Each wraps the matching service method (see Campaigner.Contacts,
+// Campaigner.Tags, ...) with context.Background().
+
+// ContactList lists contacts.
+//
+// Deprecated: Use Contacts.List.
+func (c *Campaigner) ContactList(limit int, offset int) (ResponseContactList, error) {
+	return c.contactAPI().List(context.Background(), limit, offset)
+}
+
+// ContactCreate creates a contact.
+//
+// Deprecated: Use Contacts.Create.
+func (c *Campaigner) ContactCreate(contact Contact) (ResponseContactCreate, error) {
+	return c.contactAPI().Create(context.Background(), contact)
+}
+
+// ContactFind searches for a contact by email.
+//
+// Deprecated: Use Contacts.Find.
+func (c *Campaigner) ContactFind(email string) (ResponseContactList, error) {
+	return c.contactAPI().Find(context.Background(), email)
+}
+
+// ContactRead reads a contact.
+//
+// Deprecated: Use Contacts.Read.
+func (c *Campaigner) ContactRead(id int64) (ResponseContactRead, error) {
+	return c.contactAPI().Read(context.Background(), id)
+}
+
+// ContactReadDetailed reads a contact along with its tags, lists, field values and automations.
+//
+// Deprecated: Use Contacts.ReadDetailed.
+func (c *Campaigner) ContactReadDetailed(id int64) (ContactDetails, error) {
+	return c.contactAPI().ReadDetailed(context.Background(), id)
+}
+
+// ContactUpdate updates a contact.
+//
+// Deprecated: Use Contacts.Update.
+func (c *Campaigner) ContactUpdate(id int64, request RequestContactUpdate) (ResponseContactUpdate, error) {
+	return c.contactAPI().Update(context.Background(), id, request)
+}
+
+// ContactDelete deletes a contact.
+//
+// Deprecated: Use Contacts.Delete.
+func (c *Campaigner) ContactDelete(id int64) error {
+	return c.contactAPI().Delete(context.Background(), id)
+}
+
+// ContactFieldDeleteByFieldValueID deletes a contact field value by it's ID.  Note that this is different from the Field::ID.
+//
+// Deprecated: Use Contacts.DeleteFieldValue.
+func (c *Campaigner) ContactFieldDeleteByFieldValueID(id int64) error {
+	return c.contactAPI().DeleteFieldValue(context.Background(), id)
+}
+
+// ContactFieldUpdate updates a custom field for a contact.
+//
+// Deprecated: Use Contacts.UpdateField.
+func (c *Campaigner) ContactFieldUpdate(contactID int64, fieldID int64, value string) (ResponseContactFieldUpdate, error) {
+	return c.contactAPI().UpdateField(context.Background(), contactID, fieldID, value)
+}
+
+// ContactTagCreate links a tag to a contact.
+//
+// Deprecated: Use Contacts.AddTag.
+func (c *Campaigner) ContactTagCreate(request RequestContactTagCreate) (ResponseContactTagCreate, error) {
+	return c.contactAPI().AddTag(context.Background(), request)
+}
+
+// ContactTagDelete removes a tag from a contact.  This removes the "link" and not the tag itself.
+//
+// Deprecated: Use Contacts.RemoveTag.
+func (c *Campaigner) ContactTagDelete(id int64) error {
+	return c.contactAPI().RemoveTag(context.Background(), id)
+}
+
+// ContactTagReadByContactID reads assigned tags for a contact by it's ID.
+//
+// Deprecated: Use Contacts.Tags.
+func (c *Campaigner) ContactTagReadByContactID(id int64) (ResponseContactTagRead, error) {
+	return c.contactAPI().Tags(context.Background(), id)
+}
+
+// TagCreate creates a tag.
+//
+// Deprecated: Use Tags.Create.
+func (c *Campaigner) TagCreate(tag Tag) (ResponseTagCreate, error) {
+	return c.tagAPI().Create(context.Background(), tag)
+}
+
+// TagDelete deletes a tag.
+//
+// Deprecated: Use Tags.Delete.
+func (c *Campaigner) TagDelete(id int64) error {
+	return c.tagAPI().Delete(context.Background(), id)
+}
+
+// TagFind searches for a tag by name.  The list of all available filters is not complete.
+//
+// Deprecated: Use Tags.Find.
+func (c *Campaigner) TagFind(n string) (ResponseTagList, error) {
+	return c.tagAPI().Find(context.Background(), n)
+}
+
+// TagList lists all tags.
+//
+// Deprecated: Use Tags.List.
+func (c *Campaigner) TagList() (ResponseTagList, error) {
+	return c.tagAPI().List(context.Background())
+}
+
+// TagRead reads a tag by it's ID.
+//
+// Deprecated: Use Tags.Read.
+func (c *Campaigner) TagRead(id int64) (ResponseTagRead, error) {
+	return c.tagAPI().Read(context.Background(), id)
+}
+
+// ListContactAdd adds a contact to a list.
+//
+// Deprecated: Use Lists.AddContact.
+func (c *Campaigner) ListContactAdd(listID int64, contactID int64) (ResponseListContactAdd, error) {
+	return c.listAPI().AddContact(context.Background(), listID, contactID)
+}
+
+// ListList lists available contact lists.
+//
+// Deprecated: Use Lists.List.
+func (c *Campaigner) ListList() (ResponseListList, error) {
+	return c.listAPI().List(context.Background())
+}
+
+// ListRead reads a contact list.
+//
+// Deprecated: Use Lists.Read.
+func (c *Campaigner) ListRead(id int64) (ResponseListRead, error) {
+	return c.listAPI().Read(context.Background(), id)
+}
+
+// FieldList lists custom fields.
+//
+// Deprecated: Use Fields.List.
+func (c *Campaigner) FieldList() (ResponseFieldList, error) {
+	return c.fieldAPI().List(context.Background())
+}
+
+// FieldRead reads a custom field.
+//
+// Deprecated: Use Fields.Read.
+func (c *Campaigner) FieldRead(id int64) (ResponseFieldRead, error) {
+	return c.fieldAPI().Read(context.Background(), id)
+}
+
+// OrganizationCreate creates an organization.
+//
+// Deprecated: Use Organizations.Create.
+func (c *Campaigner) OrganizationCreate(org Organization) (ResponseOrganizationCreate, error) {
+	return c.organizationAPI().Create(context.Background(), org)
+}
+
+// OrganizationDelete deletes an organization by it's ID.
+//
+// Deprecated: Use Organizations.Delete.
+func (c *Campaigner) OrganizationDelete(id int64) error {
+	return c.organizationAPI().Delete(context.Background(), id)
+}
+
+// OrganizationFind finds an organization by it's name.  If there are no matches the response contains a list with zero length.
+//
+// Deprecated: Use Organizations.Find.
+func (c *Campaigner) OrganizationFind(n string) (ResponseOrganizationList, error) {
+	return c.organizationAPI().Find(context.Background(), n)
+}
+
+// OrganizationList lists all organizations.
+//
+// Deprecated: Use Organizations.List.
+func (c *Campaigner) OrganizationList(limit int, offset int) (ResponseOrganizationList, error) {
+	return c.organizationAPI().List(context.Background(), limit, offset)
+}
+
+// OrganizationRead reads an organization by it's ID.
+//
+// Deprecated: Use Organizations.Read.
+func (c *Campaigner) OrganizationRead(id int64) (ResponseOrganizationRead, error) {
+	return c.organizationAPI().Read(context.Background(), id)
+}
+
+// OrganizationUpdate updates an organization.
+//
+// Deprecated: Use Organizations.Update.
+func (c *Campaigner) OrganizationUpdate(id int64, request RequestOrganizationUpdate) (ResponseOrganizationUpdate, error) {
+	return c.organizationAPI().Update(context.Background(), id, request)
+}
+
+// BulkImportCreate imports contacts in bulk.
+//
+// Deprecated: Use BulkImports.Create.
+func (c *Campaigner) BulkImportCreate(request RequestBulkImport) (BulkImportResult, error) {
+	return c.bulkImportAPI().Create(context.Background(), request)
+}
+
+// BulkImportInfo reads the status of a bulk import batch.
+//
+// Deprecated: Use BulkImports.Info.
+func (c *Campaigner) BulkImportInfo(batchID string) (ResponseBulkImportInfo, error) {
+	return c.bulkImportAPI().Info(context.Background(), batchID)
+}
+
+// BulkImportList lists outstanding and recently completed bulk import batches.
+//
+// Deprecated: Use BulkImports.List.
+func (c *Campaigner) BulkImportList() (ResponseBulkImportList, error) {
+	return c.bulkImportAPI().List(context.Background())
+}
+
+// BulkImportWait polls the status of a bulk import batch every interval until it has completed or the timeout has
+// passed.
+//
+// Deprecated: Use BulkImports.Wait with a context that times out.
+func (c *Campaigner) BulkImportWait(batchID string, interval time.Duration, timeout time.Duration) (ResponseBulkImportInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return c.bulkImportAPI().Wait(ctx, batchID, interval)
+}
@@ -0,0 +1,75 @@
+package campaigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Do sends a request to any API endpoint, for endpoints this package doesn't wrap yet.  The request goes through the
+// same path as every other call (authentication, middleware, rate limiting, retries, caching and strict mode).
+//
+// The path is relative to BaseURL (e.g. "/api/3/automations").  query may be nil.  body is sent as JSON unless it is
+// nil, a []byte or a json.RawMessage (sent as is).  A successful response is decoded into out unless out is nil.
+//
+// A 404 response returns a CustomErrorNotFound and a response listing errors returns an ActiveCampaignError.
+func (c *Campaigner) Do(ctx context.Context, method string, path string, query url.Values, body interface{}, out interface{}) error {
+	// Setup.
+	var (
+		target = path
+		data   []byte
+		err    error
+	)
+
+	if len(query) > 0 {
+		if strings.Contains(target, "?") {
+			target += "&" + query.Encode()
+		} else {
+			target += "?" + query.Encode()
+		}
+	}
+
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		data = b
+	case json.RawMessage:
+		data = b
+	default:
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("%s %s failed, could not marshall json for interface: %s", method, path, err)
+		}
+	}
+
+	// Send request.
+	r, b, err := c.send(ctx, method, target, data)
+	if err != nil {
+		return fmt.Errorf("%s %s failed, HTTP error: %s", method, path, err)
+	}
+
+	// Response check.
+	switch {
+	case r.StatusCode >= 200 && r.StatusCode < 300:
+		if out != nil && len(b) > 0 {
+			if err = c.decode(r, b, out); err != nil {
+				return fmt.Errorf("%s %s failed, JSON error: %s", method, path, err)
+			}
+		}
+
+		return nil
+
+	case r.StatusCode == http.StatusNotFound:
+		return CustomErrorNotFound{CustomError{Message: fmt.Sprintf("%s %s failed, not found", method, path)}}
+
+	default:
+		var apiError ActiveCampaignError
+		if err = json.Unmarshal(b, &apiError); err == nil && len(apiError.Errors) > 0 {
+			return apiError
+		}
+
+		return fmt.Errorf("%s %s failed, unspecified error (%d): %s", method, path, r.StatusCode, string(b))
+	}
+}
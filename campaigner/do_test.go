@@ -0,0 +1,84 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// Tests all raw request functionality as a group.  These tests run against a local test server.
+func TestDoSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestDo_Success)
+	runTestWithPackagePath(t, TestDo_FailureNotFound)
+	runTestWithPackagePath(t, TestDo_FailureAPIError)
+	runTestWithPackagePath(t, TestDo_FailureUnspecified)
+}
+
+func TestDo_Success(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	var (
+		ctx = context.Background()
+		c   = New(s.APIToken, s.URL)
+	)
+
+	// POST with a struct body, decoded into a caller supplied struct.
+	var created struct {
+		Tag struct {
+			ID   Int64json `json:"id"`
+			Name string    `json:"tag"`
+		} `json:"tag"`
+	}
+	body := map[string]interface{}{"tag": map[string]string{"tag": "Raw", "tagType": "contact", "description": "Raw"}}
+	require.Nil(t, c.Do(ctx, http.MethodPost, "/api/3/tags", nil, body, &created))
+	assert.Equal(t, "Raw", created.Tag.Name)
+
+	// GET with query values.
+	var list ResponseTagList
+	require.Nil(t, c.Do(ctx, http.MethodGet, "/api/3/tags", url.Values{"filters[tag]": {"Raw"}}, nil, &list))
+	require.Len(t, list.Tags, 1)
+	assert.Equal(t, created.Tag.ID, list.Tags[0].ID)
+
+	// Raw JSON body, no output.
+	contact := s.AddContact(actest.Contact{EmailAddress: "do@example.com"})
+	raw := []byte(fmt.Sprintf(`{"contactTag":{"contact":%d,"tag":%d}}`, contact, created.Tag.ID))
+	require.Nil(t, c.Do(ctx, http.MethodPost, "/api/3/contactTags", nil, raw, nil))
+	assert.Len(t, s.ContactTags(contact), 1)
+}
+
+func TestDo_FailureNotFound(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	c := New(s.APIToken, s.URL)
+	err := c.Do(context.Background(), http.MethodGet, "/api/3/tags/999", nil, nil, nil)
+	require.NotNil(t, err)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestDo_FailureAPIError(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	c := New(s.APIToken, s.URL)
+	err := c.Do(context.Background(), http.MethodPost, "/api/3/organizations", nil, map[string]interface{}{"organization": map[string]string{"name": ""}}, nil)
+	require.NotNil(t, err)
+	require.IsType(t, ActiveCampaignError{}, err)
+	assert.NotEmpty(t, err.(ActiveCampaignError).Errors)
+}
+
+func TestDo_FailureUnspecified(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	c := New("wrong", s.URL)
+	err := c.Do(context.Background(), http.MethodGet, "/api/3/tags", nil, nil, nil)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GET /api/3/tags failed, unspecified error (403)")
+}
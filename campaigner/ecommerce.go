@@ -0,0 +1,420 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Order sources.  Only real time orders trigger automations.
+const (
+	ECOM_SOURCE_HISTORICAL = 0
+	ECOM_SOURCE_REAL_TIME  = 1
+)
+
+// Money holds an amount in the smallest unit of a currency (cents) and an ISO 4217 currency code, e.g. "USD".
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+// String returns the amount with two decimals followed by the currency code, e.g. "12.34 USD".
+func (m Money) String() string {
+	var (
+		sign  string
+		cents = m.Cents
+	)
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d %s", sign, cents/100, cents%100, m.Currency)
+}
+
+// EcomConnection holds a JSON compatible e-commerce connection (a store) as it exists in the API.  Customers and orders
+// belong to a connection.
+type EcomConnection struct {
+	ID          Int64json         `json:"id,omitempty"`
+	Service     string            `json:"service"`
+	ExternalID  string            `json:"externalid"`
+	Name        string            `json:"name"`
+	LogoURL     string            `json:"logoUrl"`
+	LinkURL     string            `json:"linkUrl"`
+	Status      Int64json         `json:"status,omitempty"`
+	SyncStatus  Int64json         `json:"syncStatus,omitempty"`
+	CreatedDate ACTime            `json:"cdate"`
+	UpdatedDate ACTime            `json:"udate"`
+	Links       map[string]string `json:"links,omitempty"`
+}
+
+// EcomCustomer holds a JSON compatible e-commerce customer as it exists in the API.  ExternalID is the ID of the customer
+// in the store.  The totals are calculated by the API, amounts are in cents.
+type EcomCustomer struct {
+	ID                 Int64json         `json:"id,omitempty"`
+	ConnectionID       Int64json         `json:"connectionid"`
+	ExternalID         string            `json:"externalid"`
+	Email              string            `json:"email"`
+	AcceptsMarketing   Booljson          `json:"acceptsMarketing"`
+	TotalRevenue       Int64json         `json:"totalRevenue,omitempty"`
+	TotalOrders        Int64json         `json:"totalOrders,omitempty"`
+	TotalProducts      Int64json         `json:"totalProducts,omitempty"`
+	AvgRevenuePerOrder Int64json         `json:"avgRevenuePerOrder,omitempty"`
+	Links              map[string]string `json:"links,omitempty"`
+}
+
+// EcomOrder holds a JSON compatible e-commerce order as it exists in the API.  Amounts are in cents of Currency.
+//
+// An abandoned cart is an order with an ExternalCheckoutID and an AbandonedDate but no ExternalID.  Setting the
+// ExternalID of a cart (see UpdateOrder) turns it into an order.
+type EcomOrder struct {
+	ID                  Int64json          `json:"id,omitempty"`
+	ExternalID          string             `json:"externalid,omitempty"`
+	ExternalCheckoutID  string             `json:"externalcheckoutid,omitempty"`
+	Source              Int64json          `json:"source"`
+	Email               string             `json:"email"`
+	ConnectionID        Int64json          `json:"connectionid"`
+	CustomerID          Int64json          `json:"customerid"`
+	OrderNumber         string             `json:"orderNumber,omitempty"`
+	OrderURL            string             `json:"orderUrl,omitempty"`
+	ShippingMethod      string             `json:"shippingMethod,omitempty"`
+	Currency            string             `json:"currency"`
+	TotalPrice          Int64json          `json:"totalPrice"`
+	ShippingAmount      Int64json          `json:"shippingAmount"`
+	TaxAmount           Int64json          `json:"taxAmount"`
+	DiscountAmount      Int64json          `json:"discountAmount"`
+	ExternalCreatedDate ACTime             `json:"externalCreatedDate"`
+	ExternalUpdatedDate ACTime             `json:"externalUpdatedDate"`
+	AbandonedDate       ACTime             `json:"abandonedDate"`
+	OrderProducts       []EcomOrderProduct `json:"orderProducts,omitempty"`
+	CreatedDate         ACTime             `json:"cdate"`
+	UpdatedDate         ACTime             `json:"udate"`
+	Links               map[string]string  `json:"links,omitempty"`
+}
+
+// Total returns the total price of an order.
+func (o EcomOrder) Total() Money {
+	return Money{Cents: o.TotalPrice.Int64(), Currency: o.Currency}
+}
+
+// IsAbandonedCart returns true if the order is an abandoned cart that was never completed.
+func (o EcomOrder) IsAbandonedCart() bool {
+	return len(o.ExternalID) == 0 && !o.AbandonedDate.IsZero()
+}
+
+// EcomOrderProduct holds a JSON compatible line item of an order.  Price is the unit price in cents.
+type EcomOrderProduct struct {
+	ID          Int64json `json:"id,omitempty"`
+	ExternalID  string    `json:"externalid"`
+	Name        string    `json:"name"`
+	Price       Int64json `json:"price"`
+	Quantity    Int64json `json:"quantity"`
+	Category    string    `json:"category,omitempty"`
+	SKU         string    `json:"sku,omitempty"`
+	Description string    `json:"description,omitempty"`
+	ImageURL    string    `json:"imageUrl,omitempty"`
+	ProductURL  string    `json:"productUrl,omitempty"`
+}
+
+// ResponseEcomConnectionRead holds a JSON compatible response for reading, creating and updating connections.
+type ResponseEcomConnectionRead struct {
+	Connection EcomConnection `json:"connection"`
+}
+
+// ResponseEcomConnectionList holds a JSON compatible response for listing connections.
+type ResponseEcomConnectionList struct {
+	Connections []EcomConnection `json:"connections"`
+	Meta        struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// ResponseEcomCustomerRead holds a JSON compatible response for reading, creating and updating customers.
+type ResponseEcomCustomerRead struct {
+	Customer EcomCustomer `json:"ecomCustomer"`
+}
+
+// ResponseEcomCustomerList holds a JSON compatible response for listing customers.
+type ResponseEcomCustomerList struct {
+	Customers []EcomCustomer `json:"ecomCustomers"`
+	Meta      struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// ResponseEcomOrderRead holds a JSON compatible response for reading, creating and updating orders.
+type ResponseEcomOrderRead struct {
+	Order EcomOrder `json:"ecomOrder"`
+}
+
+// ResponseEcomOrderList holds a JSON compatible response for listing orders.
+type ResponseEcomOrderList struct {
+	Orders []EcomOrder `json:"ecomOrders"`
+	Meta   struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// ResponseEcomOrderProductList holds a JSON compatible response for listing the line items of an order.
+type ResponseEcomOrderProductList struct {
+	OrderProducts []EcomOrderProduct `json:"ecomOrderProducts"`
+	Meta          struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// CreateConnection creates a connection.
+func (s *EcommerceAPI) CreateConnection(ctx context.Context, connection EcomConnection) (response ResponseEcomConnectionRead, err error) {
+	r, body, err := s.client.post(ctx, "/api/3/connections", map[string]interface{}{"connection": connection})
+	if err != nil {
+		return response, fmt.Errorf("connection creation failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "connection creation", "connection")
+
+	return response, err
+}
+
+// DeleteConnection deletes a connection with it's customers and orders.
+func (s *EcommerceAPI) DeleteConnection(ctx context.Context, id int64) error {
+	r, body, err := s.client.delete(ctx, fmt.Sprintf("/api/3/connections/%d", id))
+	if err != nil {
+		return fmt.Errorf("connection delete failed, HTTP error: %s", err)
+	}
+
+	return s.client.result(r, body, nil, "connection delete", fmt.Sprintf("connection %d", id))
+}
+
+// ListConnections lists connections.
+func (s *EcommerceAPI) ListConnections(ctx context.Context, limit int, offset int) (response ResponseEcomConnectionList, err error) {
+	r, body, err := s.client.get(ctx, ecomPath("connections", nil, limit, offset))
+	if err != nil {
+		return response, fmt.Errorf("connection list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "connection list", "connection")
+
+	return response, err
+}
+
+// ReadConnection reads a connection by it's ID.
+func (s *EcommerceAPI) ReadConnection(ctx context.Context, id int64) (response ResponseEcomConnectionRead, err error) {
+	r, body, err := s.client.get(ctx, fmt.Sprintf("/api/3/connections/%d", id))
+	if err != nil {
+		return response, fmt.Errorf("connection read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "connection read", fmt.Sprintf("connection %d", id))
+
+	return response, err
+}
+
+// UpdateConnection updates a connection.
+func (s *EcommerceAPI) UpdateConnection(ctx context.Context, id int64, connection EcomConnection) (response ResponseEcomConnectionRead, err error) {
+	r, body, err := s.client.put(ctx, fmt.Sprintf("/api/3/connections/%d", id), map[string]interface{}{"connection": connection})
+	if err != nil {
+		return response, fmt.Errorf("connection update failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "connection update", fmt.Sprintf("connection %d", id))
+
+	return response, err
+}
+
+// CreateCustomer creates a customer.
+func (s *EcommerceAPI) CreateCustomer(ctx context.Context, customer EcomCustomer) (response ResponseEcomCustomerRead, err error) {
+	r, body, err := s.client.post(ctx, "/api/3/ecomCustomers", map[string]interface{}{"ecomCustomer": customer})
+	if err != nil {
+		return response, fmt.Errorf("customer creation failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "customer creation", "customer")
+
+	return response, err
+}
+
+// DeleteCustomer deletes a customer.
+func (s *EcommerceAPI) DeleteCustomer(ctx context.Context, id int64) error {
+	r, body, err := s.client.delete(ctx, fmt.Sprintf("/api/3/ecomCustomers/%d", id))
+	if err != nil {
+		return fmt.Errorf("customer delete failed, HTTP error: %s", err)
+	}
+
+	return s.client.result(r, body, nil, "customer delete", fmt.Sprintf("customer %d", id))
+}
+
+// FindCustomer finds a customer by it's ID in the store.  Returns a CustomErrorNotFound if there is no match.
+func (s *EcommerceAPI) FindCustomer(ctx context.Context, connectionID int64, externalID string) (EcomCustomer, error) {
+	filters := url.Values{}
+	filters.Set("filters[connectionid]", strconv.FormatInt(connectionID, 10))
+	filters.Set("filters[externalid]", externalID)
+
+	var response ResponseEcomCustomerList
+	r, body, err := s.client.get(ctx, ecomPath("ecomCustomers", filters, 1, 0))
+	if err != nil {
+		return EcomCustomer{}, fmt.Errorf("customer find failed, HTTP error: %s", err)
+	}
+
+	if err = s.client.result(r, body, &response, "customer find", "customer"); err != nil {
+		return EcomCustomer{}, err
+	}
+	if len(response.Customers) == 0 {
+		return EcomCustomer{}, CustomErrorNotFound{CustomError{Message: fmt.Sprintf("customer find failed, external ID %s not found", externalID)}}
+	}
+
+	return response.Customers[0], nil
+}
+
+// ListCustomers lists customers.
+func (s *EcommerceAPI) ListCustomers(ctx context.Context, limit int, offset int) (response ResponseEcomCustomerList, err error) {
+	r, body, err := s.client.get(ctx, ecomPath("ecomCustomers", nil, limit, offset))
+	if err != nil {
+		return response, fmt.Errorf("customer list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "customer list", "customer")
+
+	return response, err
+}
+
+// ReadCustomer reads a customer by it's ID.
+func (s *EcommerceAPI) ReadCustomer(ctx context.Context, id int64) (response ResponseEcomCustomerRead, err error) {
+	r, body, err := s.client.get(ctx, fmt.Sprintf("/api/3/ecomCustomers/%d", id))
+	if err != nil {
+		return response, fmt.Errorf("customer read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "customer read", fmt.Sprintf("customer %d", id))
+
+	return response, err
+}
+
+// UpdateCustomer updates a customer.  Every field is sent, so start from the customer as read.
+func (s *EcommerceAPI) UpdateCustomer(ctx context.Context, id int64, customer EcomCustomer) (response ResponseEcomCustomerRead, err error) {
+	r, body, err := s.client.put(ctx, fmt.Sprintf("/api/3/ecomCustomers/%d", id), map[string]interface{}{"ecomCustomer": customer})
+	if err != nil {
+		return response, fmt.Errorf("customer update failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "customer update", fmt.Sprintf("customer %d", id))
+
+	return response, err
+}
+
+// CreateOrder creates an order, or an abandoned cart (see EcomOrder).
+func (s *EcommerceAPI) CreateOrder(ctx context.Context, order EcomOrder) (response ResponseEcomOrderRead, err error) {
+	r, body, err := s.client.post(ctx, "/api/3/ecomOrders", map[string]interface{}{"ecomOrder": order})
+	if err != nil {
+		return response, fmt.Errorf("order creation failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "order creation", "order")
+
+	return response, err
+}
+
+// DeleteOrder deletes an order.
+func (s *EcommerceAPI) DeleteOrder(ctx context.Context, id int64) error {
+	r, body, err := s.client.delete(ctx, fmt.Sprintf("/api/3/ecomOrders/%d", id))
+	if err != nil {
+		return fmt.Errorf("order delete failed, HTTP error: %s", err)
+	}
+
+	return s.client.result(r, body, nil, "order delete", fmt.Sprintf("order %d", id))
+}
+
+// FindAbandonedCart finds an abandoned cart by it's checkout ID in the store.  Returns a CustomErrorNotFound if there
+// is no match.
+func (s *EcommerceAPI) FindAbandonedCart(ctx context.Context, connectionID int64, externalCheckoutID string) (EcomOrder, error) {
+	return s.findOrder(ctx, connectionID, "externalcheckoutid", externalCheckoutID)
+}
+
+// FindOrder finds an order by it's ID in the store.  Returns a CustomErrorNotFound if there is no match.
+func (s *EcommerceAPI) FindOrder(ctx context.Context, connectionID int64, externalID string) (EcomOrder, error) {
+	return s.findOrder(ctx, connectionID, "externalid", externalID)
+}
+
+// ListOrders lists orders, including abandoned carts.
+func (s *EcommerceAPI) ListOrders(ctx context.Context, limit int, offset int) (response ResponseEcomOrderList, err error) {
+	r, body, err := s.client.get(ctx, ecomPath("ecomOrders", nil, limit, offset))
+	if err != nil {
+		return response, fmt.Errorf("order list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "order list", "order")
+
+	return response, err
+}
+
+// OrderProducts lists the line items of an order.
+func (s *EcommerceAPI) OrderProducts(ctx context.Context, orderID int64) (response ResponseEcomOrderProductList, err error) {
+	r, body, err := s.client.get(ctx, fmt.Sprintf("/api/3/ecomOrders/%d/orderProducts", orderID))
+	if err != nil {
+		return response, fmt.Errorf("order product list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "order product list", fmt.Sprintf("order %d", orderID))
+
+	return response, err
+}
+
+// ReadOrder reads an order by it's ID.
+func (s *EcommerceAPI) ReadOrder(ctx context.Context, id int64) (response ResponseEcomOrderRead, err error) {
+	r, body, err := s.client.get(ctx, fmt.Sprintf("/api/3/ecomOrders/%d", id))
+	if err != nil {
+		return response, fmt.Errorf("order read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "order read", fmt.Sprintf("order %d", id))
+
+	return response, err
+}
+
+// UpdateOrder updates an order.  Every field is sent, so start from the order as read.  Line items replace the
+// existing ones if any are sent.
+func (s *EcommerceAPI) UpdateOrder(ctx context.Context, id int64, order EcomOrder) (response ResponseEcomOrderRead, err error) {
+	r, body, err := s.client.put(ctx, fmt.Sprintf("/api/3/ecomOrders/%d", id), map[string]interface{}{"ecomOrder": order})
+	if err != nil {
+		return response, fmt.Errorf("order update failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "order update", fmt.Sprintf("order %d", id))
+
+	return response, err
+}
+
+// Finds an order by a store ID (externalid or externalcheckoutid).
+func (s *EcommerceAPI) findOrder(ctx context.Context, connectionID int64, key string, value string) (EcomOrder, error) {
+	filters := url.Values{}
+	filters.Set("filters[connectionid]", strconv.FormatInt(connectionID, 10))
+	filters.Set(fmt.Sprintf("filters[%s]", key), value)
+
+	var response ResponseEcomOrderList
+	r, body, err := s.client.get(ctx, ecomPath("ecomOrders", filters, 1, 0))
+	if err != nil {
+		return EcomOrder{}, fmt.Errorf("order find failed, HTTP error: %s", err)
+	}
+
+	if err = s.client.result(r, body, &response, "order find", "order"); err != nil {
+		return EcomOrder{}, err
+	}
+	if len(response.Orders) == 0 {
+		return EcomOrder{}, CustomErrorNotFound{CustomError{Message: fmt.Sprintf("order find failed, %s %s not found", key, value)}}
+	}
+
+	return response.Orders[0], nil
+}
+
+// Returns the path of a paged e-commerce list.
+func ecomPath(resource string, filters url.Values, limit int, offset int) string {
+	qs := url.Values{}
+	for k, v := range filters {
+		qs[k] = v
+	}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+
+	u := url.URL{Path: "/api/3/" + resource, RawQuery: qs.Encode()}
+
+	return u.String()
+}
@@ -0,0 +1,194 @@
+package campaigner
+
+import (
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Tests all e-commerce functionality as a group.  These tests run against a local test server.
+func TestEcommerceSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestEcommerce_SuccessConnection)
+	runTestWithPackagePath(t, TestEcommerce_SuccessOrder)
+	runTestWithPackagePath(t, TestEcommerce_SuccessAbandonedCart)
+	runTestWithPackagePath(t, TestEcommerce_FailureOrder)
+	runTestWithPackagePath(t, TestEcommerce_Money)
+}
+
+func TestEcommerce_SuccessConnection(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	created, err := c.Ecommerce.CreateConnection(ctx, EcomConnection{Service: "shopify", ExternalID: "pizza.example.com", Name: "Pizza Shop"})
+	require.Nil(t, err)
+	id := created.Connection.ID.Int64()
+
+	conn := created.Connection
+	conn.Name = "Pasta Shop"
+	updated, err := c.Ecommerce.UpdateConnection(ctx, id, conn)
+	require.Nil(t, err)
+	assert.Equal(t, "Pasta Shop", updated.Connection.Name)
+
+	read, err := c.Ecommerce.ReadConnection(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, "shopify", read.Connection.Service)
+
+	list, err := c.Ecommerce.ListConnections(ctx, 20, 0)
+	require.Nil(t, err)
+	assert.Len(t, list.Connections, 1)
+
+	_, err = c.Ecommerce.CreateConnection(ctx, EcomConnection{Service: "shopify", ExternalID: "pizza.example.com", Name: "Again"})
+	assert.IsType(t, ActiveCampaignError{}, err)
+
+	require.Nil(t, c.Ecommerce.DeleteConnection(ctx, id))
+	_, err = c.Ecommerce.ReadConnection(ctx, id)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestEcommerce_SuccessOrder(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	conn := s.AddConnection(actest.Connection{Service: "shopify", ExternalID: "pizza.example.com", Name: "Pizza Shop"})
+
+	customer, err := c.Ecommerce.CreateCustomer(ctx, EcomCustomer{ConnectionID: Int64json(conn), ExternalID: "cust-1", Email: "buyer@example.com", AcceptsMarketing: true})
+	require.Nil(t, err)
+	customerID := customer.Customer.ID
+
+	found, err := c.Ecommerce.FindCustomer(ctx, conn, "cust-1")
+	require.Nil(t, err)
+	assert.Equal(t, customerID, found.ID)
+	assert.True(t, found.AcceptsMarketing.Bool())
+
+	order := EcomOrder{
+		ExternalID:          "order-1",
+		Source:              ECOM_SOURCE_REAL_TIME,
+		Email:               "buyer@example.com",
+		ConnectionID:        Int64json(conn),
+		CustomerID:          customerID,
+		Currency:            "USD",
+		TotalPrice:          2598,
+		ExternalCreatedDate: NewACTime(time.Now()),
+		OrderProducts: []EcomOrderProduct{
+			{ExternalID: "pizza", Name: "Pizza", Price: 1299, Quantity: 2, Category: "Food"},
+		},
+	}
+	created, err := c.Ecommerce.CreateOrder(ctx, order)
+	require.Nil(t, err)
+	id := created.Order.ID.Int64()
+	assert.Equal(t, "25.98 USD", created.Order.Total().String())
+	assert.False(t, created.Order.IsAbandonedCart())
+
+	found2, err := c.Ecommerce.FindOrder(ctx, conn, "order-1")
+	require.Nil(t, err)
+	assert.Equal(t, created.Order.ID, found2.ID)
+
+	products, err := c.Ecommerce.OrderProducts(ctx, id)
+	require.Nil(t, err)
+	require.Len(t, products.OrderProducts, 1)
+	assert.Equal(t, int64(1299), products.OrderProducts[0].Price.Int64())
+
+	// Customer totals follow the orders.
+	read, err := c.Ecommerce.ReadCustomer(ctx, customerID.Int64())
+	require.Nil(t, err)
+	assert.Equal(t, int64(2598), read.Customer.TotalRevenue.Int64())
+	assert.Equal(t, int64(2), read.Customer.TotalProducts.Int64())
+
+	updated := created.Order
+	updated.TotalPrice = 1299
+	updated.OrderProducts = []EcomOrderProduct{{ExternalID: "pizza", Name: "Pizza", Price: 1299, Quantity: 1}}
+	u, err := c.Ecommerce.UpdateOrder(ctx, id, updated)
+	require.Nil(t, err)
+	assert.Len(t, u.Order.OrderProducts, 1)
+
+	orders, err := c.Ecommerce.ListOrders(ctx, 20, 0)
+	require.Nil(t, err)
+	assert.Len(t, orders.Orders, 1)
+
+	customers, err := c.Ecommerce.ListCustomers(ctx, 20, 0)
+	require.Nil(t, err)
+	assert.Len(t, customers.Customers, 1)
+
+	require.Nil(t, c.Ecommerce.DeleteOrder(ctx, id))
+	_, err = c.Ecommerce.FindOrder(ctx, conn, "order-1")
+	assert.IsType(t, CustomErrorNotFound{}, err)
+
+	cust := read.Customer
+	cust.Email = "new@example.com"
+	_, err = c.Ecommerce.UpdateCustomer(ctx, customerID.Int64(), cust)
+	require.Nil(t, err)
+	require.Nil(t, c.Ecommerce.DeleteCustomer(ctx, customerID.Int64()))
+	_, err = c.Ecommerce.FindCustomer(ctx, conn, "cust-1")
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestEcommerce_SuccessAbandonedCart(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		conn     = s.AddConnection(actest.Connection{Service: "shopify", ExternalID: "pizza.example.com", Name: "Pizza Shop"})
+		customer = s.AddEcomCustomer(actest.EcomCustomer{ConnectionID: conn, ExternalID: "cust-1", EmailAddress: "buyer@example.com"})
+	)
+
+	cart, err := c.Ecommerce.CreateOrder(ctx, EcomOrder{
+		ExternalCheckoutID:  "checkout-1",
+		Source:              ECOM_SOURCE_REAL_TIME,
+		Email:               "buyer@example.com",
+		ConnectionID:        Int64json(conn),
+		CustomerID:          Int64json(customer),
+		Currency:            "EUR",
+		TotalPrice:          999,
+		ExternalCreatedDate: NewACTime(time.Now().Add(-time.Hour)),
+		AbandonedDate:       NewACTime(time.Now()),
+		OrderProducts:       []EcomOrderProduct{{ExternalID: "pasta", Name: "Pasta", Price: 999, Quantity: 1}},
+	})
+	require.Nil(t, err)
+
+	found, err := c.Ecommerce.FindAbandonedCart(ctx, conn, "checkout-1")
+	require.Nil(t, err)
+	assert.True(t, found.IsAbandonedCart())
+
+	// Completing the checkout turns the cart into an order.
+	found.ExternalID = "order-2"
+	order, err := c.Ecommerce.UpdateOrder(ctx, cart.Order.ID.Int64(), found)
+	require.Nil(t, err)
+	assert.False(t, order.Order.IsAbandonedCart())
+}
+
+func TestEcommerce_FailureOrder(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		conn     = s.AddConnection(actest.Connection{Service: "shopify", ExternalID: "pizza.example.com", Name: "Pizza Shop"})
+		customer = s.AddEcomCustomer(actest.EcomCustomer{ConnectionID: conn, ExternalID: "cust-1", EmailAddress: "buyer@example.com"})
+		valid    = EcomOrder{ExternalID: "order-1", Email: "buyer@example.com", ConnectionID: Int64json(conn), CustomerID: Int64json(customer), Currency: "USD", ExternalCreatedDate: NewACTime(time.Now())}
+	)
+
+	for _, change := range []func(o *EcomOrder){
+		func(o *EcomOrder) { o.Currency = "dollars" },
+		func(o *EcomOrder) { o.CustomerID = 99 },
+		func(o *EcomOrder) { o.ExternalID = "" }, // Neither an order nor a cart.
+		func(o *EcomOrder) { o.OrderProducts = []EcomOrderProduct{{Name: "Pizza", Price: 100}} },
+	} {
+		o := valid
+		change(&o)
+		_, err := c.Ecommerce.CreateOrder(ctx, o)
+		assert.IsType(t, ActiveCampaignError{}, err)
+	}
+
+	_, err := c.Ecommerce.CreateOrder(ctx, valid)
+	require.Nil(t, err)
+	_, err = c.Ecommerce.CreateOrder(ctx, valid)
+	assert.IsType(t, ActiveCampaignError{}, err)
+
+	_, err = c.Ecommerce.ReadOrder(ctx, 99)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+	_, err = c.Ecommerce.OrderProducts(ctx, 99)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestEcommerce_Money(t *testing.T) {
+	assert.Equal(t, "12.34 USD", Money{Cents: 1234, Currency: "USD"}.String())
+	assert.Equal(t, "0.05 EUR", Money{Cents: 5, Currency: "EUR"}.String())
+	assert.Equal(t, "-1.00 USD", Money{Cents: -100, Currency: "USD"}.String())
+}
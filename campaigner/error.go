@@ -78,7 +78,7 @@ func (e CustomError) WriteToLog() {
 
 	output = fmt.Sprintf("campaigner error: %s:\n%s", e.Message, strings.Join(list, "\n"))
 
-	log.Printf(output)
+	log.Print(output)
 }
 
 // CustomErrorNotFound is an error subtype that allows for a specific condition to be checked for.
@@ -0,0 +1,347 @@
+package campaigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// EVENT_TRACKING_URL is the endpoint custom events are sent to.
+const EVENT_TRACKING_URL = "https://trackcmp.net/event"
+
+// DEFAULT_EVENT_BUFFER is the number of events an EventTracker queues before Enqueue blocks.
+const DEFAULT_EVENT_BUFFER = 100
+
+// Event holds a custom event for a contact, e.g. "trial_started".  Data is optional.
+type Event struct {
+	Name  string
+	Email string
+	Data  string
+}
+
+// EventTrackingEvent holds a JSON compatible registered event name.
+type EventTrackingEvent struct {
+	Name string `json:"name"`
+}
+
+// ResponseEventTracking holds a JSON compatible response for reading and updating the event tracking status.
+type ResponseEventTracking struct {
+	EventTracking struct {
+		Enabled bool `json:"enabled"`
+	} `json:"eventTracking"`
+}
+
+// ResponseEventTrackingEventList holds a JSON compatible response for listing registered event names.
+type ResponseEventTrackingEventList struct {
+	EventTrackingEvents []EventTrackingEvent `json:"eventTrackingEvents"`
+	Meta                struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// EventTracker sends custom events that automations can start on.  Events go to EVENT_TRACKING_URL with the account
+// ID (actid) and event key found in the tracking settings of the account, not through the API, so they don't count
+// against the rate limit.  Event tracking settings and event names are managed through the client.
+//
+// Track sends an event and waits for the answer.  Enqueue queues an event that is sent in the background, Close sends
+// the queued events and stops the workers, so call it on shutdown.  It is safe for concurrent use.
+type EventTracker struct {
+	AccountID string
+	Key       string
+
+	// URL is the endpoint events are sent to, EVENT_TRACKING_URL if empty.
+	URL string
+
+	// Buffer is the number of queued events before Enqueue blocks, DEFAULT_EVENT_BUFFER if 0.  Workers is the number of
+	// events sent at the same time, 1 if 0.  Both must be set before the first call to Enqueue.
+	Buffer  int
+	Workers int
+
+	// OnError is called for every queued event that could not be sent.  If nil the error is logged as a warning.
+	OnError func(e Event, err error)
+
+	client   *Campaigner
+	start    sync.Once
+	stop     sync.Once
+	mu       sync.RWMutex
+	closed   bool
+	closing  chan struct{}
+	closeErr error
+	queue    chan Event
+	running  sync.WaitGroup
+
+	// Pending counts the events queued or being sent, drained is closed when it drops to 0.
+	pendingMu sync.Mutex
+	pending   int
+	drained   chan struct{}
+
+	// Workers send with ctx, Close cancels it.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewEventTracker returns an event tracker for the account of a client.
+func NewEventTracker(c *Campaigner, accountID string, key string) *EventTracker {
+	return &EventTracker{AccountID: accountID, Key: key, client: c}
+}
+
+// Enabled reads whether event tracking is enabled for the account.
+func (t *EventTracker) Enabled(ctx context.Context) (bool, error) {
+	var response ResponseEventTracking
+
+	r, body, err := t.client.get(ctx, "/api/3/eventTracking")
+	if err != nil {
+		return false, fmt.Errorf("event tracking read failed, HTTP error: %s", err)
+	}
+
+	err = t.client.result(r, body, &response, "event tracking read", "event tracking")
+
+	return response.EventTracking.Enabled, err
+}
+
+// SetEnabled enables or disables event tracking for the account.
+func (t *EventTracker) SetEnabled(ctx context.Context, enabled bool) error {
+	data := map[string]interface{}{"eventTracking": map[string]interface{}{"enabled": enabled}}
+
+	r, body, err := t.client.put(ctx, "/api/3/eventTracking", data)
+	if err != nil {
+		return fmt.Errorf("event tracking update failed, HTTP error: %s", err)
+	}
+
+	return t.client.result(r, body, nil, "event tracking update", "event tracking")
+}
+
+// EventNames lists the registered event names.
+func (t *EventTracker) EventNames(ctx context.Context) (response ResponseEventTrackingEventList, err error) {
+	r, body, err := t.client.get(ctx, "/api/3/eventTrackingEvents")
+	if err != nil {
+		return response, fmt.Errorf("event name list failed, HTTP error: %s", err)
+	}
+
+	err = t.client.result(r, body, &response, "event name list", "event names")
+
+	return response, err
+}
+
+// CreateEventName registers an event name.
+func (t *EventTracker) CreateEventName(ctx context.Context, name string) error {
+	if len(strings.TrimSpace(name)) == 0 {
+		return fmt.Errorf("event name creation failed, name is empty")
+	}
+
+	r, body, err := t.client.post(ctx, "/api/3/eventTrackingEvents", map[string]interface{}{"eventTrackingEvent": EventTrackingEvent{Name: name}})
+	if err != nil {
+		return fmt.Errorf("event name creation failed, HTTP error: %s", err)
+	}
+
+	return t.client.result(r, body, nil, "event name creation", fmt.Sprintf("event %s", name))
+}
+
+// DeleteEventName removes a registered event name.
+func (t *EventTracker) DeleteEventName(ctx context.Context, name string) error {
+	r, body, err := t.client.delete(ctx, "/api/3/eventTrackingEvents/"+url.PathEscape(name))
+	if err != nil {
+		return fmt.Errorf("event name delete failed, HTTP error: %s", err)
+	}
+
+	return t.client.result(r, body, nil, "event name delete", fmt.Sprintf("event %s", name))
+}
+
+// Track sends an event and waits for the answer.
+func (t *EventTracker) Track(ctx context.Context, e Event) error {
+	// Error check.
+	switch {
+	case len(t.AccountID) == 0 || len(t.Key) == 0:
+		return fmt.Errorf("event tracking failed, account ID or key not set")
+	case len(strings.TrimSpace(e.Name)) == 0:
+		return fmt.Errorf("event tracking failed, event name is empty")
+	case len(strings.TrimSpace(e.Email)) == 0:
+		return fmt.Errorf("event tracking failed, email is empty")
+	}
+
+	// Setup.
+	visit, err := json.Marshal(map[string]string{"email": e.Email})
+	if err != nil {
+		return fmt.Errorf("event tracking failed, JSON error: %s", err)
+	}
+
+	form := url.Values{}
+	form.Set("actid", t.AccountID)
+	form.Set("key", t.Key)
+	form.Set("event", e.Name)
+	form.Set("visit", string(visit))
+	if len(e.Data) > 0 {
+		form.Set("eventdata", e.Data)
+	}
+
+	target := t.URL
+	if len(target) == 0 {
+		target = EVENT_TRACKING_URL
+	}
+
+	// Send POST request.
+	r, body, err := t.client.postForm(ctx, target, form)
+	if err != nil {
+		return fmt.Errorf("event tracking failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	var response struct {
+		Success Booljson `json:"success"`
+		Message string   `json:"message"`
+	}
+	if r.StatusCode != http.StatusOK {
+		return fmt.Errorf("event tracking failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("event tracking failed, JSON error: %s", err)
+	}
+	if !response.Success.Bool() {
+		return fmt.Errorf("event tracking failed, %s", response.Message)
+	}
+
+	return nil
+}
+
+// Enqueue queues an event to be sent in the background.  Blocks while the queue is full, until ctx is done or the
+// tracker is closed.  Events that fail are passed to OnError.
+func (t *EventTracker) Enqueue(ctx context.Context, e Event) error {
+	t.start.Do(t.run)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed {
+		return fmt.Errorf("event tracking failed, tracker is closed")
+	}
+
+	t.addPending()
+	select {
+	case t.queue <- e:
+		return nil
+	case <-t.closing:
+		t.donePending()
+		return fmt.Errorf("event tracking failed, tracker is closed")
+	case <-ctx.Done():
+		t.donePending()
+		return fmt.Errorf("event tracking failed, %s", ctx.Err())
+	}
+}
+
+// Flush waits until every queued event was sent, or until ctx is done.  Events queued while waiting are waited for
+// too.
+func (t *EventTracker) Flush(ctx context.Context) error {
+	select {
+	case <-t.drainedChan():
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("event flush failed, %s", ctx.Err())
+	}
+}
+
+// Close stops accepting events, sends the queued ones and stops the workers.  If ctx is done first the events that are
+// still queued or being sent are dropped (and passed to OnError) and an error is returned.  Either way the workers have
+// stopped when Close returns.  Calling Close again returns the result of the first call.
+func (t *EventTracker) Close(ctx context.Context) error {
+	t.start.Do(t.run)
+
+	t.stop.Do(func() {
+		// Release Enqueue calls waiting for room in the queue before taking the lock they hold.
+		close(t.closing)
+		t.mu.Lock()
+		t.closed = true
+		t.mu.Unlock()
+
+		if err := t.Flush(ctx); err != nil {
+			t.closeErr = fmt.Errorf("event tracker close failed, queued events were dropped: %s", ctx.Err())
+		}
+
+		t.cancel()
+		close(t.queue)
+		t.running.Wait()
+	})
+
+	return t.closeErr
+}
+
+// Starts the background workers.
+func (t *EventTracker) run() {
+	size, workers := t.Buffer, t.Workers
+	if size <= 0 {
+		size = DEFAULT_EVENT_BUFFER
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	t.queue = make(chan Event, size)
+	t.closing = make(chan struct{})
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+
+	for x := 0; x < workers; x++ {
+		t.running.Add(1)
+		go func() {
+			defer t.running.Done()
+
+			for e := range t.queue {
+				if err := t.Track(t.ctx, e); err != nil {
+					t.report(e, err)
+				}
+				t.donePending()
+			}
+		}()
+	}
+}
+
+// Counts an event as pending.
+func (t *EventTracker) addPending() {
+	t.pendingMu.Lock()
+	defer t.pendingMu.Unlock()
+
+	if t.pending == 0 {
+		t.drained = make(chan struct{})
+	}
+	t.pending++
+}
+
+// Counts a pending event as done.
+func (t *EventTracker) donePending() {
+	t.pendingMu.Lock()
+	defer t.pendingMu.Unlock()
+
+	t.pending--
+	if t.pending == 0 {
+		close(t.drained)
+	}
+}
+
+// Returns a channel that is closed once no events are pending.
+func (t *EventTracker) drainedChan() <-chan struct{} {
+	t.pendingMu.Lock()
+	defer t.pendingMu.Unlock()
+
+	if t.pending == 0 {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+
+	return t.drained
+}
+
+// Calls the error hook, or logs a warning if no hook is set.
+func (t *EventTracker) report(e Event, err error) {
+	if t.OnError != nil {
+		t.OnError(e, err)
+		return
+	}
+
+	if t.client.Logger != nil {
+		// The email address is left out of logs.
+		t.client.Logger.Warn("event tracking failed", "event", e.Name, "error", err)
+	}
+}
@@ -0,0 +1,253 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Tests all event tracking functionality as a group.  These tests run against a local test server.
+func TestEventTrackerSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestEventTracker_SuccessSettings)
+	runTestWithPackagePath(t, TestEventTracker_SuccessTrack)
+	runTestWithPackagePath(t, TestEventTracker_FailureTrack)
+	runTestWithPackagePath(t, TestEventTracker_SuccessEnqueue)
+	runTestWithPackagePath(t, TestEventTracker_FailureEnqueue)
+	runTestWithPackagePath(t, TestEventTracker_SuccessEnqueueFlush)
+	runTestWithPackagePath(t, TestEventTracker_FailureCloseFullQueue)
+}
+
+// Starts a test server that is closed when the test ends and returns it with a tracker sending events to it.
+func startTestEventTracker(t *testing.T) (context.Context, *actest.Server, *EventTracker) {
+	ctx, s, c := startTestServer(t)
+
+	return ctx, s, testEventTracker(c, s)
+}
+
+// Returns a tracker sending events to a test server.
+func testEventTracker(c *Campaigner, s *actest.Server) *EventTracker {
+	tracker := NewEventTracker(c, s.TrackingAccountID, s.TrackingKey)
+	tracker.URL = s.EventURL
+
+	return tracker
+}
+
+func TestEventTracker_SuccessSettings(t *testing.T) {
+	ctx, _, tracker := startTestEventTracker(t)
+
+	enabled, err := tracker.Enabled(ctx)
+	require.Nil(t, err)
+	assert.False(t, enabled)
+
+	require.Nil(t, tracker.SetEnabled(ctx, true))
+	enabled, err = tracker.Enabled(ctx)
+	require.Nil(t, err)
+	assert.True(t, enabled)
+
+	require.Nil(t, tracker.CreateEventName(ctx, "trial started"))
+	assert.IsType(t, ActiveCampaignError{}, tracker.CreateEventName(ctx, "trial started"))
+	assert.NotNil(t, tracker.CreateEventName(ctx, " "))
+
+	list, err := tracker.EventNames(ctx)
+	require.Nil(t, err)
+	require.Len(t, list.EventTrackingEvents, 1)
+	assert.Equal(t, "trial started", list.EventTrackingEvents[0].Name)
+
+	require.Nil(t, tracker.DeleteEventName(ctx, "trial started"))
+	assert.IsType(t, CustomErrorNotFound{}, tracker.DeleteEventName(ctx, "trial started"))
+}
+
+func TestEventTracker_SuccessTrack(t *testing.T) {
+	ctx, s, tracker := startTestEventTracker(t)
+	s.EnableEventTracking(true)
+
+	require.Nil(t, tracker.Track(ctx, Event{Name: "trial_started", Email: "trial@example.com", Data: "plan=pro"}))
+
+	events := s.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "trial_started", events[0].Name)
+	assert.Equal(t, "trial@example.com", events[0].Email)
+	assert.Equal(t, "plan=pro", events[0].Data)
+
+	// Unknown event names are registered on first use.
+	list, err := tracker.EventNames(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), list.Meta.Total.Int64())
+}
+
+func TestEventTracker_FailureTrack(t *testing.T) {
+	ctx, s, tracker := startTestEventTracker(t)
+
+	e := Event{Name: "trial_started", Email: "trial@example.com"}
+
+	// Disabled.
+	assert.NotNil(t, tracker.Track(ctx, e))
+
+	s.EnableEventTracking(true)
+	assert.NotNil(t, tracker.Track(ctx, Event{Name: "trial_started"}))
+	assert.NotNil(t, tracker.Track(ctx, Event{Email: "trial@example.com"}))
+
+	wrong := NewEventTracker(tracker.client, s.TrackingAccountID, "wrong")
+	wrong.URL = s.EventURL
+	assert.NotNil(t, wrong.Track(ctx, e))
+	assert.NotNil(t, NewEventTracker(tracker.client, "", "").Track(ctx, e))
+
+	assert.Empty(t, s.Events())
+}
+
+func TestEventTracker_SuccessEnqueue(t *testing.T) {
+	ctx, s, tracker := startTestEventTracker(t)
+	s.EnableEventTracking(true)
+
+	tracker.Buffer = 5
+	tracker.Workers = 3
+	tracker.OnError = func(e Event, err error) { t.Errorf("event %s failed: %s", e.Email, err) }
+
+	for x := 0; x < 20; x++ {
+		require.Nil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: fmt.Sprintf("user%d@example.com", x)}))
+	}
+	require.Nil(t, tracker.Flush(ctx))
+	assert.Len(t, s.Events(), 20)
+
+	for x := 20; x < 30; x++ {
+		require.Nil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: fmt.Sprintf("user%d@example.com", x)}))
+	}
+	require.Nil(t, tracker.Close(ctx))
+	assert.Len(t, s.Events(), 30)
+
+	assert.NotNil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: "late@example.com"}))
+	require.Nil(t, tracker.Close(ctx))
+}
+
+func TestEventTracker_FailureEnqueue(t *testing.T) {
+	ctx, s, tracker := startTestEventTracker(t)
+
+	var (
+		mu     sync.Mutex
+		failed []Event
+	)
+	tracker.OnError = func(e Event, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed = append(failed, e)
+	}
+
+	// Tracking is disabled, so the event fails in the background.
+	require.Nil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: "user@example.com"}))
+	require.Nil(t, tracker.Close(ctx))
+
+	require.Len(t, failed, 1)
+	assert.Equal(t, "user@example.com", failed[0].Email)
+
+	// Without a hook the failure is logged, without the email address.
+	var l testLogger
+	tracker = testEventTracker(tracker.client, s)
+	tracker.client.Logger = &l
+	require.Nil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: "user@example.com"}))
+	require.Nil(t, tracker.Close(ctx))
+
+	require.Len(t, l.lines, 1)
+	assert.Contains(t, l.lines[0], "event=login")
+	assert.NotContains(t, l.lines[0], "user@example.com")
+}
+
+// Enqueue and Flush can be called at the same time, including while nothing is pending.
+func TestEventTracker_SuccessEnqueueFlush(t *testing.T) {
+	ctx, s, tracker := startTestEventTracker(t)
+	s.EnableEventTracking(true)
+
+	tracker.Buffer = 2
+	tracker.Workers = 2
+	tracker.OnError = func(e Event, err error) { t.Errorf("event %s failed: %s", e.Email, err) }
+
+	var wg sync.WaitGroup
+	for x := 0; x < 4; x++ {
+		wg.Add(2)
+		go func(x int) {
+			defer wg.Done()
+			for y := 0; y < 10; y++ {
+				assert.Nil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: fmt.Sprintf("user%d-%d@example.com", x, y)}))
+			}
+		}(x)
+		go func() {
+			defer wg.Done()
+			for y := 0; y < 10; y++ {
+				assert.Nil(t, tracker.Flush(ctx))
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Nil(t, tracker.Flush(ctx))
+	assert.Len(t, s.Events(), 40)
+	require.Nil(t, tracker.Close(ctx))
+}
+
+// Close gives up on a full queue when its context is done: queued events are dropped, blocked Enqueue calls return and
+// the workers stop.
+func TestEventTracker_FailureCloseFullQueue(t *testing.T) {
+	var (
+		started = make(chan struct{}, 10)
+		release = make(chan struct{})
+	)
+
+	// Events hang until released or abandoned.
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			fmt.Fprint(w, `{"success":1}`)
+		case <-r.Context().Done():
+		}
+	}))
+	defer s.Close()
+	defer close(release)
+
+	var (
+		ctx     = context.Background()
+		tracker = NewEventTracker(New("token", s.URL), "1", "key")
+		mu      sync.Mutex
+		dropped []string
+	)
+	tracker.URL = s.URL
+	tracker.Buffer = 2
+	tracker.OnError = func(e Event, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dropped = append(dropped, e.Email)
+	}
+
+	// One event in flight and two queued.
+	require.Nil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: "user0@example.com"}))
+	<-started
+	for x := 1; x < 3; x++ {
+		require.Nil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: fmt.Sprintf("user%d@example.com", x)}))
+	}
+
+	// The queue is full, so this one blocks until the tracker is closed.
+	blocked := make(chan error)
+	go func() { blocked <- tracker.Enqueue(ctx, Event{Name: "login", Email: "user3@example.com"}) }()
+
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err := tracker.Close(timeout)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "dropped")
+	assert.NotNil(t, <-blocked)
+
+	// The workers have stopped and every accepted event was reported.
+	mu.Lock()
+	assert.ElementsMatch(t, []string{"user0@example.com", "user1@example.com", "user2@example.com"}, dropped)
+	mu.Unlock()
+
+	// Closing again reports the same failure.
+	assert.Equal(t, err, tracker.Close(ctx))
+	assert.NotNil(t, tracker.Enqueue(ctx, Event{Name: "login", Email: "late@example.com"}))
+}
@@ -1,28 +1,30 @@
 package campaigner
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 // Field holds a JSON compatible custom contact field as it exists in the API.
 type Field struct {
-	ID           int64         `json:"id,string"`
+	ID           Int64json     `json:"id"`
 	Title        string        `json:"title"`
 	Description  string        `json:"descript"`
 	Type         string        `json:"type"`
-	IsRequired   string        `json:"isrequired"`
+	IsRequired   Booljson      `json:"isrequired"`
 	Perstag      string        `json:"perstag"`
 	DefaultValue string        `json:"defval"`
-	ShowInList   string        `json:"show_in_list"`
-	Rows         string        `json:"rows"`
-	Columns      string        `json:"cols"`
-	IsVisible    string        `json:"visible"`
+	ShowInList   Booljson      `json:"show_in_list"`
+	Rows         Int64json     `json:"rows"`
+	Columns      Int64json     `json:"cols"`
+	IsVisible    Booljson      `json:"visible"`
 	Service      string        `json:"service"`
-	OrderNumber  string        `json:"ordernum"`
-	DateCreated  string        `json:"cdate"`
-	DateUpdated  string        `json:"udate"`
+	OrderNumber  Int64json     `json:"ordernum"`
+	DateCreated  ACTime        `json:"cdate"`
+	DateUpdated  ACTime        `json:"udate"`
 	Options      []interface{} `json:"options"`
 	Relations    []string      `json:"relations"`
 	Links        struct {
@@ -37,7 +39,7 @@ type ResponseFieldList struct {
 	FieldRelationships []ResponseFieldRelationships `json:"fieldRels"`
 	Fields             []Field                      `json:"fields"`
 	Meta               struct {
-		Total string `json:"total"`
+		Total Int64json `json:"total"`
 	} `json:"meta"`
 }
 
@@ -50,21 +52,44 @@ type ResponseFieldRead struct {
 
 // ResponseFieldRelationships holds a JSON compatible response for reading field relationships.
 type ResponseFieldRelationships struct {
-	Field        string        `json:"field"`
-	RelationID   string        `json:"relid"`
-	DisplayOrder string        `json:"dorder"`
-	DateCreated  string        `json:"cdate"`
+	Field        Int64json     `json:"field"`
+	RelationID   Int64json     `json:"relid"`
+	DisplayOrder Int64json     `json:"dorder"`
+	DateCreated  ACTime        `json:"cdate"`
 	Links        []interface{} `json:"links"`
-	ID           string        `json:"id"`
+	ID           Int64json     `json:"id"`
 }
 
-// FieldList lists custom fields.
-func (c *Campaigner) FieldList() (response ResponseFieldList, err error) {
+// List lists all custom fields.  Fields are read MAX_LIST_LIMIT at a time until every page has been read.
+func (s *FieldAPI) List(ctx context.Context) (response ResponseFieldList, err error) {
+	for offset := 0; ; {
+		page, err := s.list(ctx, MAX_LIST_LIMIT, offset)
+		if err != nil {
+			return response, err
+		}
+
+		response.FieldOptions = append(response.FieldOptions, page.FieldOptions...)
+		response.FieldRelationships = append(response.FieldRelationships, page.FieldRelationships...)
+		response.Fields = append(response.Fields, page.Fields...)
+		response.Meta = page.Meta
+
+		offset += len(page.Fields)
+		if lastPage(len(page.Fields), offset, page.Meta.Total) {
+			return response, nil
+		}
+	}
+}
+
+// Lists a single page of custom fields.
+func (s *FieldAPI) list(ctx context.Context, limit int, offset int) (response ResponseFieldList, err error) {
 	// Setup.
-	u := "/api/3/fields"
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/fields", RawQuery: qs.Encode()}
 
 	// Send GET request.
-	r, body, err := c.get(u)
+	r, body, err := s.client.get(ctx, u.String())
 	if err != nil {
 		return response, fmt.Errorf("field list failed, HTTP error: %s", err)
 	}
@@ -72,26 +97,24 @@ func (c *Campaigner) FieldList() (response ResponseFieldList, err error) {
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		err := json.Unmarshal(body, &response)
+		err := s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("field list failed, JSON error: %s", err)
 		}
 
-		//logFormattedJSON("field list", response)
-
 		return response, nil
 	}
 
 	return response, fmt.Errorf("field list failed, unspecified error (%d): %s", r.StatusCode, string(body))
 }
 
-// FieldRead reads a custom field.
-func (c *Campaigner) FieldRead(id int64) (response ResponseFieldRead, err error) {
+// Read reads a custom field.
+func (s *FieldAPI) Read(ctx context.Context, id int64) (response ResponseFieldRead, err error) {
 	// Setup.
 	u := fmt.Sprintf("/api/3/fields/%d", id)
 
 	// Send GET request.
-	r, body, err := c.get(u)
+	r, body, err := s.client.get(ctx, u)
 	if err != nil {
 		return response, fmt.Errorf("field read failed, HTTP error: %s", err)
 	}
@@ -99,7 +122,7 @@ func (c *Campaigner) FieldRead(id int64) (response ResponseFieldRead, err error)
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		err := json.Unmarshal(body, &response)
+		err := s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("field read failed, JSON error: %s", err)
 		}
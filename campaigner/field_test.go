@@ -18,7 +18,7 @@ func TestFieldRead_Success(t *testing.T) {
 
 	f := r.Fields[0]
 
-	r2, err := C.FieldRead(f.ID)
+	r2, err := C.FieldRead(f.ID.Int64())
 	assert.Nil(t, err)
 	assert.NotEmpty(t, r2.Field.ID)
 }
\ No newline at end of file
@@ -0,0 +1,215 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FORM_ACTION_SUBSCRIBE is the type of form actions that subscribe the contact to a list.
+const FORM_ACTION_SUBSCRIBE = "subscribe-to-list"
+
+// Form holds a JSON compatible form as it exists in the API.  OptInID is the opt-in email sent before subscribing the
+// contact (0 if the form doesn't use double opt-in).  Embed is the HTML embed code of the form, Submit posts to its
+// action URL with its hidden inputs.
+//
+// TODO(api): The embed code is not in the documented form fields.
+type Form struct {
+	ID         Int64json         `json:"id"`
+	Name       string            `json:"name"`
+	Action     string            `json:"action"`
+	ActionData FormActionData    `json:"actiondata"`
+	URL        string            `json:"url"`
+	Embed      string            `json:"embed"`
+	OptInID    Int64json         `json:"optin_id"`
+	Created    ACTime            `json:"cdate"`
+	Updated    ACTime            `json:"udate"`
+	Links      map[string]string `json:"links"`
+}
+
+// FormActionData holds a JSON compatible list of form actions (nested structure, see Form).
+type FormActionData struct {
+	Actions []FormAction `json:"actions"`
+}
+
+// FormAction holds a JSON compatible form action, e.g. subscribing the contact to a list (nested structure, see Form).
+type FormAction struct {
+	Type  string    `json:"type"`
+	List  Int64json `json:"list,omitempty"`
+	Email string    `json:"email,omitempty"`
+}
+
+// FormSubmission holds the values a contact enters in a form.  Fields holds custom field values by field ID.
+type FormSubmission struct {
+	Email     string
+	FirstName string
+	LastName  string
+	Phone     string
+	Fields    map[int64]string
+}
+
+// ResponseFormRead holds a JSON compatible response for reading a form.
+type ResponseFormRead struct {
+	Form Form `json:"form"`
+}
+
+// ResponseFormList holds a JSON compatible response for listing forms.
+type ResponseFormList struct {
+	Forms []Form `json:"forms"`
+	Meta  struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// Lists returns the IDs of the lists a form subscribes contacts to.
+func (f Form) Lists() []int64 {
+	var l []int64
+
+	for _, a := range f.ActionData.Actions {
+		if a.Type == FORM_ACTION_SUBSCRIBE && a.List > 0 {
+			l = append(l, a.List.Int64())
+		}
+	}
+
+	return l
+}
+
+// DoubleOptIn returns whether contacts have to confirm their subscription.
+func (f Form) DoubleOptIn() bool {
+	return f.OptInID > 0
+}
+
+// Returns the action URL and the hidden inputs of the embed code.  The action and the u input are required.
+func (f Form) embedInputs() (string, url.Values, error) {
+	m := formAction.FindStringSubmatch(f.Embed)
+	if m == nil {
+		return "", nil, fmt.Errorf("embed code of form %d has no action URL", f.ID)
+	}
+	action := html.UnescapeString(m[1] + m[2])
+
+	inputs := url.Values{}
+	for _, tag := range formInput.FindAllString(f.Embed, -1) {
+		attributes := map[string]string{}
+		for _, a := range formAttribute.FindAllStringSubmatch(tag, -1) {
+			attributes[strings.ToLower(a[1])] = html.UnescapeString(a[2] + a[3])
+		}
+
+		if strings.EqualFold(attributes["type"], "hidden") && len(attributes["name"]) > 0 {
+			inputs.Set(attributes["name"], attributes["value"])
+		}
+	}
+
+	if len(inputs.Get("u")) == 0 {
+		return "", nil, fmt.Errorf("embed code of form %d has no u input", f.ID)
+	}
+
+	return action, inputs, nil
+}
+
+// List lists forms.
+func (s *FormAPI) List(ctx context.Context, limit int, offset int) (response ResponseFormList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/forms", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("form list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "form list", "forms")
+
+	return response, err
+}
+
+// Read reads a form.
+func (s *FormAPI) Read(ctx context.Context, id int64) (response ResponseFormRead, err error) {
+	r, body, err := s.client.get(ctx, fmt.Sprintf("/api/3/forms/%d", id))
+	if err != nil {
+		return response, fmt.Errorf("form read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "form read", fmt.Sprintf("form %d", id))
+
+	return response, err
+}
+
+// Submit submits a contact through a form the way a signup page does, so the form's opt-in email, list subscriptions
+// and form based automations apply (unlike adding the contact to a list directly).  The form is read first, it is
+// posted to the action URL of its embed code (proc.php on the account's form host) with the embed code's hidden
+// inputs.
+func (s *FormAPI) Submit(ctx context.Context, id int64, submission FormSubmission) error {
+	// Error check.
+	if len(strings.TrimSpace(submission.Email)) == 0 {
+		return fmt.Errorf("form submission failed, email is empty")
+	}
+
+	read, err := s.Read(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Setup.  jsonp asks for the script that shows the result instead of a redirect to the thank you page.
+	action, form, err := read.Form.embedInputs()
+	if err != nil {
+		return fmt.Errorf("form submission failed, %s", err)
+	}
+
+	form.Set("jsonp", "true")
+	form.Set("email", submission.Email)
+	if len(submission.FirstName) > 0 {
+		form.Set("firstname", submission.FirstName)
+	}
+	if len(submission.LastName) > 0 {
+		form.Set("lastname", submission.LastName)
+	}
+	if len(submission.Phone) > 0 {
+		form.Set("phone", submission.Phone)
+	}
+	for fieldID, value := range submission.Fields {
+		form.Set(fmt.Sprintf("field[%d]", fieldID), value)
+	}
+
+	// Send POST request.
+	r, body, err := s.client.postForm(ctx, action, form)
+	if err != nil {
+		return fmt.Errorf("form submission failed, HTTP error: %s", err)
+	}
+
+	// Response check.  The result is a call to _show_thank_you or _show_error, anything else is an error.
+	if r.StatusCode != http.StatusOK {
+		return fmt.Errorf("form submission failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+
+	m := formCallback.FindSubmatch(body)
+	switch {
+	case m == nil:
+		return fmt.Errorf("form submission failed, unexpected response: %s", string(body))
+	case string(m[1]) == "error":
+		return fmt.Errorf("form submission failed, %s", formString.Replace(string(m[2])+string(m[3])))
+	}
+
+	return nil
+}
+
+// Matches the script proc.php answers JSONP submissions with, e.g. _show_error('12', 'Email address is invalid', ...).
+// The second argument is the message.
+var formCallback = regexp.MustCompile(`_show_(thank_you|error)\(\s*[^,]*,\s*(?:'((?:\\.|[^'\\])*)'|"((?:\\.|[^"\\])*)")`)
+
+// Unescapes a JavaScript string literal of a form callback.
+var formString = strings.NewReplacer(`\'`, `'`, `\"`, `"`, `\\`, `\`, `\n`, "\n", `\/`, `/`)
+
+// Match the action URL of an embed code, its input tags and their attributes.
+var (
+	formAction    = regexp.MustCompile(`(?is)<form\b[^>]*\saction\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	formInput     = regexp.MustCompile(`(?is)<input\b[^>]*>`)
+	formAttribute = regexp.MustCompile(`(?is)\s([a-z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
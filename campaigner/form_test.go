@@ -0,0 +1,156 @@
+package campaigner
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Tests all form functionality as a group.  These tests run against a local test server.
+func TestFormSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestForm_SuccessRead)
+	runTestWithPackagePath(t, TestForm_SuccessSubmit)
+	runTestWithPackagePath(t, TestForm_SuccessSubmitDoubleOptIn)
+	runTestWithPackagePath(t, TestForm_FailureSubmit)
+	runTestWithPackagePath(t, TestForm_FailureSubmitResponse)
+	runTestWithPackagePath(t, TestForm_EmbedInputs)
+}
+
+func TestForm_SuccessRead(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		list = s.AddList(actest.List{Name: "Newsletter"})
+		id   = s.AddForm(actest.Form{Name: "Landing page", ListIDs: []int64{list}, DoubleOptIn: true, Key: "5F3A"})
+	)
+	s.AddForm(actest.Form{Name: "Footer"})
+
+	read, err := c.Forms.Read(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, "Landing page", read.Form.Name)
+	assert.Equal(t, []int64{list}, read.Form.Lists())
+	assert.True(t, read.Form.DoubleOptIn())
+
+	action, inputs, err := read.Form.embedInputs()
+	require.Nil(t, err)
+	assert.Equal(t, s.URL+"/proc.php", action)
+	assert.Equal(t, "5F3A", inputs.Get("u"))
+	assert.Equal(t, "sub", inputs.Get("act"))
+
+	forms, err := c.Forms.List(ctx, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, forms.Forms, 2)
+	assert.False(t, forms.Forms[1].DoubleOptIn())
+	assert.Empty(t, forms.Forms[1].Lists())
+
+	_, err = c.Forms.Read(ctx, 99)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestForm_SuccessSubmit(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		list  = s.AddList(actest.List{Name: "Newsletter"})
+		field = s.AddField(actest.Field{Title: "Company"})
+		id    = s.AddForm(actest.Form{Name: "Landing page", ListIDs: []int64{list}})
+	)
+
+	err := c.Forms.Submit(ctx, id, FormSubmission{Email: "signup@example.com", FirstName: "Sign", LastName: "Up", Fields: map[int64]string{field: "Example Inc"}})
+	require.Nil(t, err)
+
+	found, err := c.Contacts.Find(ctx, "signup@example.com")
+	require.Nil(t, err)
+	require.Len(t, found.Contacts, 1)
+	contact := found.Contacts[0]
+	assert.Equal(t, "Sign", contact.FirstName)
+
+	lists := s.ContactLists(contact.ID.Int64())
+	require.Len(t, lists, 1)
+	assert.Equal(t, 1, lists[0].Status)
+	assert.Equal(t, id, lists[0].FormID)
+
+	value, ok := s.FieldValue(contact.ID.Int64(), field)
+	require.True(t, ok)
+	assert.Equal(t, "Example Inc", value)
+
+	// Submitting again updates the contact.
+	require.Nil(t, c.Forms.Submit(ctx, id, FormSubmission{Email: "signup@example.com", Phone: "555-0100"}))
+	read, err := c.Contacts.Read(ctx, contact.ID.Int64())
+	require.Nil(t, err)
+	assert.Equal(t, "Sign", read.Contact.FirstName)
+	assert.Equal(t, "555-0100", read.Contact.PhoneNumber)
+}
+
+func TestForm_SuccessSubmitDoubleOptIn(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		list    = s.AddList(actest.List{Name: "Newsletter"})
+		id      = s.AddForm(actest.Form{Name: "Landing page", ListIDs: []int64{list}, DoubleOptIn: true})
+		contact = s.AddContact(actest.Contact{EmailAddress: "optin@example.com"})
+	)
+
+	require.Nil(t, c.Forms.Submit(ctx, id, FormSubmission{Email: "optin@example.com"}))
+
+	lists := s.ContactLists(contact)
+	require.Len(t, lists, 1)
+	assert.Equal(t, 0, lists[0].Status)
+}
+
+func TestForm_FailureSubmit(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	id := s.AddForm(actest.Form{Name: "Landing page"})
+
+	err := c.Forms.Submit(ctx, 99, FormSubmission{Email: "signup@example.com"})
+	assert.IsType(t, CustomErrorNotFound{}, err)
+	assert.NotNil(t, c.Forms.Submit(ctx, id, FormSubmission{Email: "not an email"}))
+	assert.NotNil(t, c.Forms.Submit(ctx, id, FormSubmission{}))
+
+	found, err := c.Contacts.Find(ctx, "signup@example.com")
+	require.Nil(t, err)
+	assert.Empty(t, found.Contacts)
+}
+
+func TestForm_FailureSubmitResponse(t *testing.T) {
+	var s *httptest.Server
+	s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/proc.php" {
+			_, _ = w.Write([]byte("<html>Moved</html>"))
+			return
+		}
+
+		embed := `<form action="` + s.URL + `/proc.php"><input type="hidden" name="u" value="1" /></form>`
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"form": map[string]interface{}{"id": "1", "embed": embed}})
+	}))
+	defer s.Close()
+
+	err := New("token", s.URL).Forms.Submit(context.Background(), 1, FormSubmission{Email: "signup@example.com"})
+	assert.EqualError(t, err, "form submission failed, unexpected response: <html>Moved</html>")
+}
+
+func TestForm_EmbedInputs(t *testing.T) {
+	f := Form{ID: 3, Embed: `<FORM method='POST' action='https://account.activehosted.com/proc.php?a=1&amp;b=2'>
+		<input type="hidden" name="u" value="3A" /><input name="f" type="hidden" value="3"><input type="hidden" name="s" />
+		<input type="text" name="email" value="" /></FORM>`}
+
+	action, inputs, err := f.embedInputs()
+	require.Nil(t, err)
+	assert.Equal(t, "https://account.activehosted.com/proc.php?a=1&b=2", action)
+	assert.Equal(t, "3A", inputs.Get("u"))
+	assert.Equal(t, "3", inputs.Get("f"))
+	assert.Contains(t, inputs, "s")
+	assert.NotContains(t, inputs, "email")
+
+	// The action URL and u are not guessed.
+	_, _, err = Form{ID: 3, Embed: `<form action="/proc.php"><input type="hidden" name="f" value="3" /></form>`}.embedInputs()
+	assert.EqualError(t, err, "embed code of form 3 has no u input")
+	_, _, err = Form{ID: 3}.embedInputs()
+	assert.EqualError(t, err, "embed code of form 3 has no action URL")
+}
@@ -6,39 +6,13 @@ import (
 	"github.com/kr/pretty"
 	"io/ioutil"
 	"log"
-	"regexp"
-	"strconv"
 )
 
 const DEFAULT_LIST_LIMIT = 20
 const DEFAULT_LIST_OFFSET = 0
 
-// Int64json fixes issues caused by some ID numbers being returned as both strings and numbers in the JSON (from the same API calls).
-type Int64json int64
-
-// MarshalJSON returns a JSON encoded int64.
-func (i Int64json) MarshalJSON() ([]byte, error) {
-	return json.Marshal(int64(i))
-}
-
-// UnmarshalJSON loads an Int64json.
-func (i *Int64json) UnmarshalJSON(data []byte) error {
-	re := regexp.MustCompile("[^0-9]")
-	s := re.ReplaceAllString(string(data), "")
-
-	n, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		return err
-	}
-
-	*i = Int64json(n)
-	return nil
-}
-
-// Int64 casts itself as an int64.
-func (i Int64json) Int64() int64 {
-	return int64(i)
-}
+// MAX_LIST_LIMIT is the largest page (limit) the API returns.
+const MAX_LIST_LIMIT = 100
 
 type LimitOffset struct {
 	Limit int
@@ -62,7 +36,7 @@ func logFormattedJSON(message string, data interface{}) {
 	}
 
 	if len(message) > 0 {
-		log.Printf("\n\n" + message + "\n")
+		log.Print("\n\n" + message + "\n")
 	}
 	log.Printf("\n%s", string(tmp))
 }
@@ -81,3 +55,9 @@ func writeIndentedJSON(path string, data []byte) {
 		log.Printf("Could not write indented json file %s: %s", path, err)
 	}
 }
+
+// Checks whether a page was the last one.  read is the number of items on the page, offset the number read so far and
+// total the total reported by the API (0 if it didn't say).
+func lastPage(read int, offset int, total Int64json) bool {
+	return read < MAX_LIST_LIMIT || total > 0 && int64(offset) >= total.Int64()
+}
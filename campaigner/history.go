@@ -0,0 +1,188 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The types of email activities.
+const (
+	EMAIL_ACTIVITY_SEND  = "send"
+	EMAIL_ACTIVITY_OPEN  = "open"
+	EMAIL_ACTIVITY_CLICK = "click"
+)
+
+// The types of activities in a contact's activity feed, besides the email activity types.
+const (
+	ACTIVITY_BOUNCE      = "bounce"
+	ACTIVITY_PAGE_VISIT  = "page_visit"
+	ACTIVITY_SUBSCRIBE   = "subscribe"
+	ACTIVITY_UNSUBSCRIBE = "unsubscribe"
+)
+
+// BounceLog holds a JSON compatible bounce of an email sent to a contact.  Code is the SMTP status code, e.g. "5.1.1".
+type BounceLog struct {
+	ID         Int64json         `json:"id"`
+	ContactID  Int64json         `json:"contact"`
+	CampaignID Int64json         `json:"campaign"`
+	MessageID  Int64json         `json:"message"`
+	Email      string            `json:"email"`
+	Code       string            `json:"code"`
+	Error      string            `json:"error"`
+	Tstamp     ACTime            `json:"tstamp"`
+	Links      map[string]string `json:"links"`
+}
+
+// Hard returns whether a bounce is permanent (an SMTP 5.x.x code), e.g. the mailbox doesn't exist.
+func (b BounceLog) Hard() bool {
+	return strings.HasPrefix(b.Code, "5")
+}
+
+// EmailActivity holds a JSON compatible email activity of a contact: a campaign email sent, opened or clicked (see
+// EMAIL_ACTIVITY_*).  Link is the clicked URL.
+type EmailActivity struct {
+	ID         Int64json         `json:"id"`
+	ContactID  Int64json         `json:"subscriberid"`
+	CampaignID Int64json         `json:"campaignid"`
+	MessageID  Int64json         `json:"messageid"`
+	Type       string            `json:"type"`
+	Link       string            `json:"link"`
+	Tstamp     ACTime            `json:"tstamp"`
+	Links      map[string]string `json:"links"`
+}
+
+// EmailActivitySummary holds the number of sends, opens and clicks of a campaign.
+type EmailActivitySummary struct {
+	Sends  int
+	Opens  int
+	Clicks int
+}
+
+// Activity holds a JSON compatible entry of a contact's activity feed (see ACTIVITY_* and EMAIL_ACTIVITY_*).
+// ReferenceID is the ID of the campaign, list or tracking log the activity is about.
+type Activity struct {
+	ContactID   Int64json `json:"contact"`
+	Type        string    `json:"reference_type"`
+	ReferenceID Int64json `json:"reference_id"`
+	Description string    `json:"description"`
+	Tstamp      ACTime    `json:"tstamp"`
+}
+
+// ResponseBounceLogList holds a JSON compatible response for listing bounce logs.
+type ResponseBounceLogList struct {
+	BounceLogs []BounceLog `json:"bounceLogs"`
+	Meta       struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// ResponseEmailActivityList holds a JSON compatible response for listing email activities.
+type ResponseEmailActivityList struct {
+	EmailActivities []EmailActivity `json:"emailActivities"`
+	Meta            struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// ResponseActivityList holds a JSON compatible response for listing a contact's activity feed.
+type ResponseActivityList struct {
+	Activities []Activity `json:"activities"`
+	Meta       struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// Campaigns returns the number of sends, opens and clicks by campaign ID.
+func (r ResponseEmailActivityList) Campaigns() map[int64]EmailActivitySummary {
+	m := map[int64]EmailActivitySummary{}
+
+	for _, a := range r.EmailActivities {
+		summary := m[a.CampaignID.Int64()]
+		switch a.Type {
+		case EMAIL_ACTIVITY_SEND:
+			summary.Sends++
+		case EMAIL_ACTIVITY_OPEN:
+			summary.Opens++
+		case EMAIL_ACTIVITY_CLICK:
+			summary.Clicks++
+		}
+		m[a.CampaignID.Int64()] = summary
+	}
+
+	return m
+}
+
+// BounceLogs lists the bounces of a contact.  Each page is sorted oldest first, the API doesn't document an order
+// across pages.
+func (s *HistoryAPI) BounceLogs(ctx context.Context, contactID int64, limit int, offset int) (response ResponseBounceLogList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: fmt.Sprintf("/api/3/contacts/%d/bounceLogs", contactID), RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("bounce log list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "bounce log list", fmt.Sprintf("contact %d", contactID))
+	sort.SliceStable(response.BounceLogs, func(i, j int) bool {
+		return response.BounceLogs[i].Tstamp.Before(response.BounceLogs[j].Tstamp.Time)
+	})
+
+	return response, err
+}
+
+// EmailActivities lists the campaign emails sent to, opened and clicked by a contact.  Each page is sorted oldest
+// first, the API doesn't document an order across pages.
+func (s *HistoryAPI) EmailActivities(ctx context.Context, contactID int64, limit int, offset int) (response ResponseEmailActivityList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("filters[subscriberid]", strconv.FormatInt(contactID, 10))
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/emailActivities", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("email activity list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "email activity list", fmt.Sprintf("contact %d", contactID))
+	sort.SliceStable(response.EmailActivities, func(i, j int) bool {
+		return response.EmailActivities[i].Tstamp.Before(response.EmailActivities[j].Tstamp.Time)
+	})
+
+	return response, err
+}
+
+// Activities lists the activity feed of a contact (emails, bounces, page visits and subscriptions).  Each page is
+// sorted oldest first, the API doesn't document an order across pages.
+func (s *HistoryAPI) Activities(ctx context.Context, contactID int64, limit int, offset int) (response ResponseActivityList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("contact", strconv.FormatInt(contactID, 10))
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/activities", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("activity list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "activity list", fmt.Sprintf("contact %d", contactID))
+	sort.SliceStable(response.Activities, func(i, j int) bool {
+		return response.Activities[i].Tstamp.Before(response.Activities[j].Tstamp.Time)
+	})
+
+	return response, err
+}
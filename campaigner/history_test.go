@@ -0,0 +1,135 @@
+package campaigner
+
+import (
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Tests all contact history functionality as a group.  These tests run against a local test server.
+func TestHistorySuite(t *testing.T) {
+	runTestWithPackagePath(t, TestHistory_SuccessBounceLogs)
+	runTestWithPackagePath(t, TestHistory_SuccessEmailActivities)
+	runTestWithPackagePath(t, TestHistory_SuccessActivities)
+	runTestWithPackagePath(t, TestHistory_Failure)
+}
+
+func TestHistory_SuccessBounceLogs(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		contact = s.AddContact(actest.Contact{EmailAddress: "bounce@example.com"})
+		now     = time.Now()
+	)
+
+	// Added out of order.
+	s.AddBounceLog(actest.BounceLog{ContactID: contact, CampaignID: 2, MessageID: 2, Code: "5.1.1", Error: "Mailbox does not exist", Time: now})
+	s.AddBounceLog(actest.BounceLog{ContactID: contact, CampaignID: 1, MessageID: 1, Code: "4.2.2", Error: "Mailbox full", Time: now.Add(-48 * time.Hour)})
+
+	logs, err := c.History.BounceLogs(ctx, contact, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, logs.BounceLogs, 2)
+	assert.EqualValues(t, 2, logs.Meta.Total)
+	assert.Equal(t, int64(1), logs.BounceLogs[0].CampaignID.Int64())
+	assert.False(t, logs.BounceLogs[0].Hard())
+	assert.True(t, logs.BounceLogs[1].Hard())
+	assert.Equal(t, "bounce@example.com", logs.BounceLogs[1].Email)
+
+	second, err := c.History.BounceLogs(ctx, contact, 1, 1)
+	require.Nil(t, err)
+	require.Len(t, second.BounceLogs, 1)
+	assert.Equal(t, int64(1), second.BounceLogs[0].CampaignID.Int64())
+
+	read, err := c.Contacts.Read(ctx, contact)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), read.Contact.BouncedHard.Int64())
+	assert.Equal(t, int64(1), read.Contact.BouncedSoft.Int64())
+	assert.False(t, read.Contact.BouncedDate.IsZero())
+}
+
+func TestHistory_SuccessEmailActivities(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		contact = s.AddContact(actest.Contact{EmailAddress: "reader@example.com"})
+		other   = s.AddContact(actest.Contact{EmailAddress: "other@example.com"})
+		now     = time.Now()
+	)
+
+	s.AddEmailActivity(actest.EmailActivity{ContactID: contact, CampaignID: 1, Type: EMAIL_ACTIVITY_CLICK, Link: "https://example.com/sale", Time: now})
+	s.AddEmailActivity(actest.EmailActivity{ContactID: contact, CampaignID: 1, Type: EMAIL_ACTIVITY_SEND, Time: now.Add(-2 * time.Hour)})
+	s.AddEmailActivity(actest.EmailActivity{ContactID: contact, CampaignID: 1, Type: EMAIL_ACTIVITY_OPEN, Time: now.Add(-time.Hour)})
+	s.AddEmailActivity(actest.EmailActivity{ContactID: contact, CampaignID: 2, Type: EMAIL_ACTIVITY_SEND, Time: now})
+	s.AddEmailActivity(actest.EmailActivity{ContactID: other, CampaignID: 1, Type: EMAIL_ACTIVITY_SEND, Time: now})
+
+	activities, err := c.History.EmailActivities(ctx, contact, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, activities.EmailActivities, 4)
+	assert.Equal(t, EMAIL_ACTIVITY_SEND, activities.EmailActivities[0].Type)
+	assert.Equal(t, EMAIL_ACTIVITY_OPEN, activities.EmailActivities[1].Type)
+	assert.Equal(t, "https://example.com/sale", activities.EmailActivities[2].Link)
+
+	assert.Equal(t, map[int64]EmailActivitySummary{
+		1: {Sends: 1, Opens: 1, Clicks: 1},
+		2: {Sends: 1},
+	}, activities.Campaigns())
+
+	// Pages are sorted on their own, the server orders by ID.
+	first, err := c.History.EmailActivities(ctx, contact, 2, 0)
+	require.Nil(t, err)
+	second, err := c.History.EmailActivities(ctx, contact, 2, 2)
+	require.Nil(t, err)
+	require.Len(t, first.EmailActivities, 2)
+	require.Len(t, second.EmailActivities, 2)
+	assert.Equal(t, EMAIL_ACTIVITY_SEND, first.EmailActivities[0].Type)
+	assert.Equal(t, EMAIL_ACTIVITY_CLICK, first.EmailActivities[1].Type)
+	assert.Equal(t, EMAIL_ACTIVITY_OPEN, second.EmailActivities[0].Type)
+	assert.Equal(t, EMAIL_ACTIVITY_SEND, second.EmailActivities[1].Type)
+	assert.EqualValues(t, 4, second.Meta.Total)
+}
+
+func TestHistory_SuccessActivities(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		contact = s.AddContact(actest.Contact{EmailAddress: "feed@example.com"})
+		list    = s.AddList(actest.List{Name: "Newsletter"})
+		now     = time.Now()
+	)
+
+	_, err := c.Lists.AddContact(ctx, list, contact)
+	require.Nil(t, err)
+	s.AddEmailActivity(actest.EmailActivity{ContactID: contact, CampaignID: 1, Type: EMAIL_ACTIVITY_SEND, Time: now.Add(time.Hour)})
+	s.AddBounceLog(actest.BounceLog{ContactID: contact, CampaignID: 1, Code: "5.1.1", Error: "Mailbox does not exist", Time: now.Add(2 * time.Hour)})
+
+	feed, err := c.History.Activities(ctx, contact, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, feed.Activities, 3)
+	assert.EqualValues(t, 3, feed.Meta.Total)
+	assert.Equal(t, ACTIVITY_SUBSCRIBE, feed.Activities[0].Type)
+	assert.Equal(t, list, feed.Activities[0].ReferenceID.Int64())
+	assert.Equal(t, "Newsletter", feed.Activities[0].Description)
+	assert.Equal(t, EMAIL_ACTIVITY_SEND, feed.Activities[1].Type)
+	assert.Equal(t, ACTIVITY_BOUNCE, feed.Activities[2].Type)
+
+	// The feed is grouped by kind, bounces come first.
+	first, err := c.History.Activities(ctx, contact, 1, 0)
+	require.Nil(t, err)
+	require.Len(t, first.Activities, 1)
+	assert.Equal(t, ACTIVITY_BOUNCE, first.Activities[0].Type)
+}
+
+func TestHistory_Failure(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	_, err := c.History.BounceLogs(ctx, 99, 20, 0)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+	_, err = c.History.Activities(ctx, 99, 20, 0)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+
+	activities, err := c.History.EmailActivities(ctx, 99, 20, 0)
+	require.Nil(t, err)
+	assert.Empty(t, activities.EmailActivities)
+}
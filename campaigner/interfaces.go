@@ -0,0 +1,185 @@
+package campaigner
+
+import (
+	"context"
+	"time"
+)
+
+// Service interfaces, one per service of Campaigner (see Campaigner.Contacts etc.).  Code that depends on part of the
+// API should accept the narrowest interface it needs so that it can be tested with a fake (see the campaignermock
+// package).
+
+// ContactService manages contacts, their tags and their custom field values (see ContactAPI).
+type ContactService interface {
+	List(ctx context.Context, limit int, offset int) (ResponseContactList, error)
+	Create(ctx context.Context, contact Contact) (ResponseContactCreate, error)
+	Find(ctx context.Context, email string) (ResponseContactList, error)
+	Read(ctx context.Context, id int64) (ResponseContactRead, error)
+	ReadDetailed(ctx context.Context, id int64) (ContactDetails, error)
+	Update(ctx context.Context, id int64, request RequestContactUpdate) (ResponseContactUpdate, error)
+	Delete(ctx context.Context, id int64) error
+	UpdateField(ctx context.Context, contactID int64, fieldID int64, value string) (ResponseContactFieldUpdate, error)
+	DeleteFieldValue(ctx context.Context, id int64) error
+	AddTag(ctx context.Context, request RequestContactTagCreate) (ResponseContactTagCreate, error)
+	RemoveTag(ctx context.Context, id int64) error
+	Tags(ctx context.Context, id int64) (ResponseContactTagRead, error)
+}
+
+// TagService manages tags (see TagAPI).
+type TagService interface {
+	Create(ctx context.Context, tag Tag) (ResponseTagCreate, error)
+	Delete(ctx context.Context, id int64) error
+	Find(ctx context.Context, n string) (ResponseTagList, error)
+	List(ctx context.Context) (ResponseTagList, error)
+	Read(ctx context.Context, id int64) (ResponseTagRead, error)
+}
+
+// ListService manages contact lists (see ListAPI).
+type ListService interface {
+	AddContact(ctx context.Context, listID int64, contactID int64) (ResponseListContactAdd, error)
+	List(ctx context.Context) (ResponseListList, error)
+	Read(ctx context.Context, id int64) (ResponseListRead, error)
+}
+
+// FieldService reads custom fields (see FieldAPI).
+type FieldService interface {
+	List(ctx context.Context) (ResponseFieldList, error)
+	Read(ctx context.Context, id int64) (ResponseFieldRead, error)
+}
+
+// OrganizationService manages organizations (see OrganizationAPI).
+type OrganizationService interface {
+	Create(ctx context.Context, org Organization) (ResponseOrganizationCreate, error)
+	Delete(ctx context.Context, id int64) error
+	Find(ctx context.Context, n string) (ResponseOrganizationList, error)
+	List(ctx context.Context, limit int, offset int) (ResponseOrganizationList, error)
+	Read(ctx context.Context, id int64) (ResponseOrganizationRead, error)
+	Update(ctx context.Context, id int64, request RequestOrganizationUpdate) (ResponseOrganizationUpdate, error)
+}
+
+// BulkImportService imports contacts in bulk (see BulkImportAPI).
+type BulkImportService interface {
+	Create(ctx context.Context, request RequestBulkImport) (BulkImportResult, error)
+	Info(ctx context.Context, batchID string) (ResponseBulkImportInfo, error)
+	List(ctx context.Context) (ResponseBulkImportList, error)
+	Wait(ctx context.Context, batchID string, interval time.Duration) (ResponseBulkImportInfo, error)
+}
+
+// UserService manages users (see UserAPI).
+type UserService interface {
+	Create(ctx context.Context, user RequestUserCreate) (ResponseUserRead, error)
+	Delete(ctx context.Context, id int64) error
+	FindByEmail(ctx context.Context, email string) (ResponseUserRead, error)
+	FindByUsername(ctx context.Context, username string) (ResponseUserRead, error)
+	List(ctx context.Context, limit int, offset int) (ResponseUserList, error)
+	Me(ctx context.Context) (ResponseUserRead, error)
+	Read(ctx context.Context, id int64) (ResponseUserRead, error)
+	Update(ctx context.Context, id int64, user RequestUserUpdate) (ResponseUserRead, error)
+}
+
+// GroupService manages user groups (see GroupAPI).
+type GroupService interface {
+	Create(ctx context.Context, group Group) (ResponseGroupRead, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, limit int, offset int) (ResponseGroupList, error)
+	Read(ctx context.Context, id int64) (ResponseGroupRead, error)
+	Update(ctx context.Context, id int64, group Group) (ResponseGroupRead, error)
+}
+
+// CustomObjectService manages custom object schemas and records (see CustomObjectAPI).
+type CustomObjectService interface {
+	CreateSchema(ctx context.Context, schema CustomObjectSchema) (ResponseCustomObjectSchemaRead, error)
+	DeleteSchema(ctx context.Context, id string) error
+	ListSchemas(ctx context.Context, limit int, offset int) (ResponseCustomObjectSchemaList, error)
+	ReadSchema(ctx context.Context, id string) (ResponseCustomObjectSchemaRead, error)
+	UpdateSchema(ctx context.Context, id string, schema CustomObjectSchema) (ResponseCustomObjectSchemaRead, error)
+	DeleteRecord(ctx context.Context, schemaID string, id string) error
+	DeleteRecordByExternalID(ctx context.Context, schemaID string, externalID string) error
+	ListRecords(ctx context.Context, schemaID string, limit int, offset int) (ResponseCustomObjectRecordList, error)
+	ReadRecord(ctx context.Context, schemaID string, id string) (ResponseCustomObjectRecordRead, error)
+	ReadRecordByExternalID(ctx context.Context, schemaID string, externalID string) (ResponseCustomObjectRecordRead, error)
+	UpsertRecord(ctx context.Context, schemaID string, record CustomObjectRecord) (ResponseCustomObjectRecordRead, error)
+}
+
+// EcommerceService manages e-commerce connections, customers and orders (see EcommerceAPI).
+type EcommerceService interface {
+	CreateConnection(ctx context.Context, connection EcomConnection) (ResponseEcomConnectionRead, error)
+	DeleteConnection(ctx context.Context, id int64) error
+	ListConnections(ctx context.Context, limit int, offset int) (ResponseEcomConnectionList, error)
+	ReadConnection(ctx context.Context, id int64) (ResponseEcomConnectionRead, error)
+	UpdateConnection(ctx context.Context, id int64, connection EcomConnection) (ResponseEcomConnectionRead, error)
+	CreateCustomer(ctx context.Context, customer EcomCustomer) (ResponseEcomCustomerRead, error)
+	DeleteCustomer(ctx context.Context, id int64) error
+	FindCustomer(ctx context.Context, connectionID int64, externalID string) (EcomCustomer, error)
+	ListCustomers(ctx context.Context, limit int, offset int) (ResponseEcomCustomerList, error)
+	ReadCustomer(ctx context.Context, id int64) (ResponseEcomCustomerRead, error)
+	UpdateCustomer(ctx context.Context, id int64, customer EcomCustomer) (ResponseEcomCustomerRead, error)
+	CreateOrder(ctx context.Context, order EcomOrder) (ResponseEcomOrderRead, error)
+	DeleteOrder(ctx context.Context, id int64) error
+	FindAbandonedCart(ctx context.Context, connectionID int64, externalCheckoutID string) (EcomOrder, error)
+	FindOrder(ctx context.Context, connectionID int64, externalID string) (EcomOrder, error)
+	ListOrders(ctx context.Context, limit int, offset int) (ResponseEcomOrderList, error)
+	OrderProducts(ctx context.Context, orderID int64) (ResponseEcomOrderProductList, error)
+	ReadOrder(ctx context.Context, id int64) (ResponseEcomOrderRead, error)
+	UpdateOrder(ctx context.Context, id int64, order EcomOrder) (ResponseEcomOrderRead, error)
+}
+
+// SiteTrackingService manages site tracking and reads tracking logs (see SiteTrackingAPI).
+type SiteTrackingService interface {
+	Enabled(ctx context.Context) (bool, error)
+	SetEnabled(ctx context.Context, enabled bool) error
+	ListDomains(ctx context.Context, limit int, offset int) (ResponseSiteTrackingDomainList, error)
+	AddDomain(ctx context.Context, name string) (ResponseSiteTrackingDomain, error)
+	RemoveDomain(ctx context.Context, name string) error
+	ContactLogs(ctx context.Context, contactID int64) (ResponseTrackingLogList, error)
+	Logs(ctx context.Context, link string) (ResponseTrackingLogList, error)
+}
+
+// SegmentService reads segments and their contacts (see SegmentAPI).
+type SegmentService interface {
+	List(ctx context.Context, limit int, offset int) (ResponseSegmentList, error)
+	Read(ctx context.Context, id int64) (ResponseSegmentRead, error)
+	ListContacts(ctx context.Context, id int64, limit int, offset int) (ResponseContactList, error)
+	Contacts(ctx context.Context, id int64, pageSize int) *SegmentContactIterator
+}
+
+// FormService reads forms and submits them (see FormAPI).
+type FormService interface {
+	List(ctx context.Context, limit int, offset int) (ResponseFormList, error)
+	Read(ctx context.Context, id int64) (ResponseFormRead, error)
+	Submit(ctx context.Context, id int64, submission FormSubmission) error
+}
+
+// HistoryService reads the history of a contact (see HistoryAPI).
+type HistoryService interface {
+	BounceLogs(ctx context.Context, contactID int64, limit int, offset int) (ResponseBounceLogList, error)
+	EmailActivities(ctx context.Context, contactID int64, limit int, offset int) (ResponseEmailActivityList, error)
+	Activities(ctx context.Context, contactID int64, limit int, offset int) (ResponseActivityList, error)
+}
+
+// ScoreService reads scores and score values (see ScoreAPI).
+type ScoreService interface {
+	List(ctx context.Context, limit int, offset int) (ResponseScoreList, error)
+	Read(ctx context.Context, id int64) (ResponseScoreRead, error)
+	ContactValues(ctx context.Context, contactID int64) (ResponseScoreValueList, error)
+	DealValues(ctx context.Context, dealID int64) (ResponseScoreValueList, error)
+}
+
+// Compile time checks that the services satisfy their interfaces.
+var (
+	_ ContactService      = (*ContactAPI)(nil)
+	_ TagService          = (*TagAPI)(nil)
+	_ ListService         = (*ListAPI)(nil)
+	_ FieldService        = (*FieldAPI)(nil)
+	_ OrganizationService = (*OrganizationAPI)(nil)
+	_ BulkImportService   = (*BulkImportAPI)(nil)
+	_ UserService         = (*UserAPI)(nil)
+	_ GroupService        = (*GroupAPI)(nil)
+	_ CustomObjectService = (*CustomObjectAPI)(nil)
+	_ EcommerceService    = (*EcommerceAPI)(nil)
+	_ SiteTrackingService = (*SiteTrackingAPI)(nil)
+	_ SegmentService      = (*SegmentAPI)(nil)
+	_ FormService         = (*FormAPI)(nil)
+	_ HistoryService      = (*HistoryAPI)(nil)
+	_ ScoreService        = (*ScoreAPI)(nil)
+)
@@ -0,0 +1,268 @@
+package campaigner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The API is loose about JSON types: IDs and counts are sent as strings or numbers (sometimes both from the same call),
+// flags as "0" / "1" and missing values as null or "".  The types in this file load all of these variants and write
+// values back in a form the API accepts.
+
+// Int64json holds an ID or count sent as either a string or a number.  Null and "" load as 0, fractions are an error.
+type Int64json int64
+
+// MarshalJSON returns a JSON encoded int64.
+func (i Int64json) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(i))
+}
+
+// UnmarshalJSON loads an Int64json.
+func (i *Int64json) UnmarshalJSON(data []byte) error {
+	s, err := jsonScalar(data)
+	if err != nil {
+		return err
+	}
+
+	if len(s) == 0 {
+		*i = 0
+		return nil
+	}
+
+	n, err := parseJSONInt(s)
+	if err != nil {
+		return err
+	}
+
+	*i = Int64json(n)
+	return nil
+}
+
+// Int64 casts itself as an int64.
+func (i Int64json) Int64() int64 {
+	return int64(i)
+}
+
+// NullInt64json holds an ID or count that may be missing (null or "").  A missing value is marshalled as null.
+type NullInt64json struct {
+	Int64 int64
+	Valid bool // Valid is true if Int64 is set.
+}
+
+// NewNullInt64json returns a valid NullInt64json holding n.
+func NewNullInt64json(n int64) NullInt64json {
+	return NullInt64json{Int64: n, Valid: true}
+}
+
+// MarshalJSON returns a JSON encoded int64 or null.
+func (i NullInt64json) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(i.Int64)
+}
+
+// UnmarshalJSON loads a NullInt64json.
+func (i *NullInt64json) UnmarshalJSON(data []byte) error {
+	s, err := jsonScalar(data)
+	if err != nil {
+		return err
+	}
+
+	if len(s) == 0 {
+		*i = NullInt64json{}
+		return nil
+	}
+
+	n, err := parseJSONInt(s)
+	if err != nil {
+		return err
+	}
+
+	*i = NullInt64json{Int64: n, Valid: true}
+	return nil
+}
+
+// Decimaljson holds a decimal number (e.g. a price or a coordinate) sent as either a string or a number.  The value is
+// kept as text so no precision is lost; "" means the value is missing and is marshalled as null.
+type Decimaljson string
+
+// Matches a decimal number as sent by the API, e.g. "12.50", "-.5", "+3." or "1e3".
+var decimalRegexp = regexp.MustCompile(`^([+-]?)([0-9]*)(?:\.([0-9]*))?([eE][+-]?[0-9]+)?$`)
+
+// NewDecimaljson returns a Decimaljson holding f.
+func NewDecimaljson(f float64) Decimaljson {
+	return Decimaljson(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// MarshalJSON returns a JSON encoded number or null.
+func (d Decimaljson) MarshalJSON() ([]byte, error) {
+	if len(d) == 0 {
+		return []byte("null"), nil
+	}
+
+	s, err := normalizeDecimal(string(d))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(s), nil
+}
+
+// UnmarshalJSON loads a Decimaljson.
+func (d *Decimaljson) UnmarshalJSON(data []byte) error {
+	s, err := jsonScalar(data)
+	if err != nil {
+		return err
+	}
+
+	if len(s) == 0 {
+		*d = ""
+		return nil
+	}
+
+	s, err = normalizeDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Decimaljson(s)
+	return nil
+}
+
+// Float64 returns the value as a float64, 0 if it is missing.
+func (d Decimaljson) Float64() float64 {
+	f, _ := strconv.ParseFloat(string(d), 64)
+	return f
+}
+
+// String returns the value as text.
+func (d Decimaljson) String() string {
+	return string(d)
+}
+
+// Booljson holds a flag sent as "0" / "1", a number or a JSON boolean.  Null and "" load as false.  Flags are written
+// back as "0" / "1", which is how the API sends them.
+type Booljson bool
+
+// MarshalJSON returns "1" or "0".
+func (b Booljson) MarshalJSON() ([]byte, error) {
+	if b {
+		return []byte(`"1"`), nil
+	}
+
+	return []byte(`"0"`), nil
+}
+
+// UnmarshalJSON loads a Booljson.
+func (b *Booljson) UnmarshalJSON(data []byte) error {
+	s, err := jsonScalar(data)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(s) {
+	case "", "0", "false", "no", "off":
+		*b = false
+		return nil
+	case "1", "true", "yes", "on":
+		*b = true
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || !decimalRegexp.MatchString(s) {
+		return fmt.Errorf("invalid flag %q", s)
+	}
+
+	*b = f != 0
+	return nil
+}
+
+// Bool casts itself as a bool.
+func (b Booljson) Bool() bool {
+	return bool(b)
+}
+
+// Implemented by records that are sent either in full or as a bare ID (see unmarshalIDOrRecord).  Strict mode checks the
+// full records like any other struct.
+type idOrRecord interface {
+	idOrRecord()
+}
+
+// Loads a record sent as a bare ID into id, or in full into record.  record must be a pointer to a type without an
+// UnmarshalJSON method.
+func unmarshalIDOrRecord(data []byte, id *Int64json, record interface{}) error {
+	if t := bytes.TrimSpace(data); len(t) > 0 && t[0] == '{' {
+		return json.Unmarshal(data, record)
+	}
+
+	return id.UnmarshalJSON(data)
+}
+
+// Returns a JSON scalar (string, number or boolean) as trimmed text, null becomes "".  Objects and arrays are an error.
+func jsonScalar(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+
+	switch data[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(s), nil
+	case '{', '[':
+		return "", fmt.Errorf("invalid value %s, expected a string or number", string(data))
+	default:
+		return string(data), nil
+	}
+}
+
+// Parses an integer.  Numbers with a zero fraction (e.g. "12.0" or "1e3") are accepted, other fractions are an error.
+func parseJSONInt(s string) (int64, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+
+	if !decimalRegexp.MatchString(s) {
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+
+	return int64(f), nil
+}
+
+// Returns a decimal number in JSON number format, e.g. "+.50" becomes "0.50".
+func normalizeDecimal(s string) (string, error) {
+	m := decimalRegexp.FindStringSubmatch(s)
+	if m == nil || len(m[2])+len(m[3]) == 0 {
+		return "", fmt.Errorf("invalid decimal %q", s)
+	}
+
+	sign, whole, fraction, exponent := m[1], m[2], m[3], m[4]
+	if sign == "+" {
+		sign = ""
+	}
+	whole = strings.TrimLeft(whole, "0")
+	if len(whole) == 0 {
+		whole = "0"
+	}
+	if len(fraction) > 0 {
+		fraction = "." + fraction
+	}
+
+	return sign + whole + fraction + exponent, nil
+}
@@ -0,0 +1,223 @@
+package campaigner
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+// Tests all flexible JSON types as a group.
+func TestJSONTypesSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestInt64json_Unmarshal)
+	runTestWithPackagePath(t, TestInt64json_FailureInvalid)
+	runTestWithPackagePath(t, TestNullInt64json_Unmarshal)
+	runTestWithPackagePath(t, TestDecimaljson_Unmarshal)
+	runTestWithPackagePath(t, TestBooljson_Unmarshal)
+	runTestWithPackagePath(t, TestJSONTypes_Models)
+}
+
+func TestInt64json_Unmarshal(t *testing.T) {
+	tests := map[string]int64{
+		`12`: 12, `"12"`: 12, `" 12 "`: 12, `-7`: -7, `"-7"`: -7, `"+7"`: 7, `12.0`: 12, `"1e3"`: 1000,
+		`null`: 0, `""`: 0, `"9223372036854775807"`: 9223372036854775807,
+	}
+	for s, n := range tests {
+		var i Int64json
+		require.Nil(t, json.Unmarshal([]byte(s), &i), s)
+		assert.Equal(t, n, i.Int64(), s)
+	}
+
+	b, err := json.Marshal(Int64json(-7))
+	require.Nil(t, err)
+	assert.Equal(t, `-7`, string(b))
+}
+
+func TestInt64json_FailureInvalid(t *testing.T) {
+	for _, s := range []string{`"12.5"`, `12.5`, `"abc"`, `"http://x/api/3/fields/1"`, `true`, `{}`, `[1]`, `"9223372036854775808"`} {
+		var i Int64json
+		assert.NotNil(t, json.Unmarshal([]byte(s), &i), s)
+	}
+}
+
+func TestNullInt64json_Unmarshal(t *testing.T) {
+	var v struct {
+		A NullInt64json `json:"a"`
+		B NullInt64json `json:"b"`
+		C NullInt64json `json:"c"`
+		D NullInt64json `json:"d"`
+	}
+	require.Nil(t, json.Unmarshal([]byte(`{"a":null,"b":"","c":"0","d":42}`), &v))
+	assert.False(t, v.A.Valid)
+	assert.False(t, v.B.Valid)
+	assert.Equal(t, NewNullInt64json(0), v.C)
+	assert.Equal(t, NewNullInt64json(42), v.D)
+
+	b, err := json.Marshal(v)
+	require.Nil(t, err)
+	assert.Equal(t, `{"a":null,"b":null,"c":0,"d":42}`, string(b))
+}
+
+func TestDecimaljson_Unmarshal(t *testing.T) {
+	tests := map[string]string{
+		`"12.50"`: "12.50", `12.50`: "12.50", `"-.5"`: "-0.5", `"+3."`: "3", `"007"`: "7", `"1e3"`: "1e3",
+		`"-74.0060"`: "-74.0060", `null`: "", `""`: "",
+	}
+	for s, want := range tests {
+		var d Decimaljson
+		require.Nil(t, json.Unmarshal([]byte(s), &d), s)
+		assert.Equal(t, want, d.String(), s)
+	}
+
+	for _, s := range []string{`"abc"`, `"."`, `"1.2.3"`, `"NaN"`, `true`} {
+		var d Decimaljson
+		assert.NotNil(t, json.Unmarshal([]byte(s), &d), s)
+	}
+
+	b, err := json.Marshal([]Decimaljson{"12.50", "", NewDecimaljson(0.1)})
+	require.Nil(t, err)
+	assert.Equal(t, `[12.50,null,0.1]`, string(b))
+	assert.Equal(t, 12.5, Decimaljson("12.50").Float64())
+}
+
+func TestBooljson_Unmarshal(t *testing.T) {
+	tests := map[string]bool{
+		`"1"`: true, `"0"`: false, `1`: true, `0`: false, `true`: true, `false`: false, `"true"`: true, `"2"`: true,
+		`null`: false, `""`: false,
+	}
+	for s, want := range tests {
+		var b Booljson
+		require.Nil(t, json.Unmarshal([]byte(s), &b), s)
+		assert.Equal(t, want, b.Bool(), s)
+	}
+
+	var b Booljson
+	assert.NotNil(t, json.Unmarshal([]byte(`"maybe"`), &b))
+
+	out, err := json.Marshal([]Booljson{true, false})
+	require.Nil(t, err)
+	assert.Equal(t, `["1","0"]`, string(out))
+}
+
+func TestJSONTypes_Models(t *testing.T) {
+	body := `{
+		"contact":{"id":3,"email":"a@b.c","orgid":null,"deleted":"0","bounced_hard":"2","sentcnt":"","anonymized":"1","organization":null,"contactLists":["6"],"fieldValues":[11,"12"],"deals":[]},
+		"contactLists":[{"contact":"3","list":3,"status":"1","responder":"1","form":null,"id":"6"}],
+		"geoAddresses":[{"lat":"40.7128","lon":"-74.0060","id":"12"}]
+	}`
+
+	var r ResponseContactRead
+	require.Nil(t, json.Unmarshal([]byte(body), &r))
+	assert.Equal(t, int64(3), r.Contact.ID.Int64())
+	assert.Equal(t, Int64json(0), r.Contact.OrganizationID)
+	assert.False(t, r.Contact.IsDeleted.Bool())
+	assert.Equal(t, Int64json(2), r.Contact.BouncedHard)
+	assert.True(t, r.Contact.Anonymized.Bool())
+	assert.False(t, r.Contact.Organization.Valid)
+	assert.Equal(t, []ContactList{{ID: 6}}, r.Contact.ContactLists)
+	assert.Equal(t, []ContactFieldValue{{ID: 11}, {ID: 12}}, r.Contact.FieldValues)
+	assert.Equal(t, Int64json(3), r.ContactLists[0].List)
+	assert.False(t, r.ContactLists[0].Form.Valid)
+	assert.Equal(t, Decimaljson("-74.0060"), r.GeoAddresses[0].Lon)
+
+	var l ResponseContactList
+	require.Nil(t, json.Unmarshal([]byte(`{"contacts":[],"meta":{"total":"0","page_input":{"segmentid":"4","limit":20,"offset":"40","status":-1}}}`), &l))
+	assert.Equal(t, Int64json(4), l.Meta.PageInput.Segmentid)
+	assert.Equal(t, Int64json(20), l.Meta.PageInput.Limit)
+	assert.Equal(t, Int64json(40), l.Meta.PageInput.Offset)
+	assert.Equal(t, Int64json(-1), l.Meta.PageInput.Status)
+
+	var f Field
+	require.Nil(t, json.Unmarshal([]byte(`{"id":"2","isrequired":"1","visible":"0","show_in_list":"1","rows":"","cols":"","ordernum":"4"}`), &f))
+	assert.True(t, f.IsRequired.Bool())
+	assert.False(t, f.IsVisible.Bool())
+	assert.Equal(t, Int64json(4), f.OrderNumber)
+}
+
+// Loaded values must survive a marshal / unmarshal round trip.
+func FuzzInt64json(f *testing.F) {
+	for _, s := range []string{`12`, `"12"`, `-7`, `"+7"`, `null`, `""`, `"12.5"`, `1e3`, `"9223372036854775807"`, `"abc"`} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var i Int64json
+		if err := json.Unmarshal([]byte(s), &i); err != nil {
+			return
+		}
+
+		b, err := json.Marshal(i)
+		require.Nil(t, err)
+
+		var j Int64json
+		require.Nil(t, json.Unmarshal(b, &j), string(b))
+		assert.Equal(t, i, j)
+
+		// Plain integers keep their value.
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			assert.Equal(t, n, i.Int64())
+		}
+	})
+}
+
+func FuzzNullInt64json(f *testing.F) {
+	for _, s := range []string{`12`, `"12"`, `"0"`, `null`, `""`, `" "`, `"x"`} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var i NullInt64json
+		if err := json.Unmarshal([]byte(s), &i); err != nil {
+			return
+		}
+
+		b, err := json.Marshal(i)
+		require.Nil(t, err)
+
+		var j NullInt64json
+		require.Nil(t, json.Unmarshal(b, &j), string(b))
+		assert.Equal(t, i, j)
+	})
+}
+
+func FuzzDecimaljson(f *testing.F) {
+	for _, s := range []string{`12.50`, `"12.50"`, `"-.5"`, `"+3."`, `"007"`, `"1e3"`, `null`, `""`, `"."`, `"abc"`} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var d Decimaljson
+		if err := json.Unmarshal([]byte(s), &d); err != nil {
+			return
+		}
+
+		b, err := json.Marshal(d)
+		require.Nil(t, err)
+		require.True(t, json.Valid(b), string(b))
+
+		var e Decimaljson
+		require.Nil(t, json.Unmarshal(b, &e), string(b))
+		assert.Equal(t, d, e)
+	})
+}
+
+func FuzzBooljson(f *testing.F) {
+	for _, s := range []string{`"1"`, `"0"`, `1`, `0`, `true`, `false`, `null`, `""`, `"2"`, `"maybe"`} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var b Booljson
+		if err := json.Unmarshal([]byte(s), &b); err != nil {
+			return
+		}
+
+		out, err := json.Marshal(b)
+		require.Nil(t, err)
+
+		var c Booljson
+		require.Nil(t, json.Unmarshal(out, &c), string(out))
+		assert.Equal(t, b, c)
+	})
+}
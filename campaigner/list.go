@@ -1,19 +1,21 @@
 package campaigner
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
-// ListContactAdd adds a contact to a list.
-func (c *Campaigner) ListContactAdd(listID int64, contactID int64) (response ResponseListContactAdd, err error) {
+// AddContact adds a contact to a list.
+func (s *ListAPI) AddContact(ctx context.Context, listID int64, contactID int64) (response ResponseListContactAdd, err error) {
 	// Check that both the contact and list exist.
-	_, err = c.ContactRead(contactID)
+	_, err = s.client.contactAPI().Read(ctx, contactID)
 	if err != nil {
 		return response, fmt.Errorf("list contact addition failed, could not find contact: %s", err)
 	}
-	l, err := c.ListRead(listID)
+	l, err := s.Read(ctx, listID)
 	if err != nil {
 		return response, fmt.Errorf("list contact addition failed, could not find list: %s", err)
 	}
@@ -21,12 +23,15 @@ func (c *Campaigner) ListContactAdd(listID int64, contactID int64) (response Res
 	req := RequestListContactAdd{ListID: listID, ContactID: contactID, Status: true}
 
 	u := "/api/3/contactLists"
-	r, body, err := c.post(u, map[string]interface{}{"contactList": req})
+	r, body, err := s.client.post(ctx, u, map[string]interface{}{"contactList": req})
+	if err != nil {
+		return response, fmt.Errorf("list contact addition failed, HTTP error: %s", err)
+	}
 
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK, http.StatusCreated:
-		if err = json.Unmarshal(body, &response); err != nil {
+		if err = s.client.decode(r, body, &response); err != nil {
 			return response, fmt.Errorf("list contact addition failed, JSON error: %s (%s)", err, string(body))
 		}
 
@@ -38,16 +43,42 @@ func (c *Campaigner) ListContactAdd(listID int64, contactID int64) (response Res
 	}
 }
 
-// ListList lists available contact lists.
-func (c *Campaigner) ListList() (response ResponseListList, err error) {
+// List lists all available contact lists.  Lists are read MAX_LIST_LIMIT at a time until every page has been read.
+func (s *ListAPI) List(ctx context.Context) (response ResponseListList, err error) {
+	for offset := 0; ; {
+		page, err := s.list(ctx, MAX_LIST_LIMIT, offset)
+		if err != nil {
+			return response, err
+		}
+
+		response.Lists = append(response.Lists, page.Lists...)
+		response.Meta = page.Meta
+
+		offset += len(page.Lists)
+		if lastPage(len(page.Lists), offset, page.Meta.Total) {
+			return response, nil
+		}
+	}
+}
+
+// Lists a single page of contact lists.
+func (s *ListAPI) list(ctx context.Context, limit int, offset int) (response ResponseListList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/lists", RawQuery: qs.Encode()}
+
 	// Send GET request.
-	u := "/api/3/lists"
-	r, body, err := c.get(u)
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("list listing failed, HTTP error: %s", err)
+	}
 
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		if err = json.Unmarshal(body, &response); err != nil {
+		if err = s.client.decode(r, body, &response); err != nil {
 			return response, fmt.Errorf("list listing failed, JSON error: %s", err)
 		}
 
@@ -57,16 +88,19 @@ func (c *Campaigner) ListList() (response ResponseListList, err error) {
 	}
 }
 
-// ListRead reads a contact list.
-func (c *Campaigner) ListRead(id int64) (response ResponseListRead, err error) {
+// Read reads a contact list.
+func (s *ListAPI) Read(ctx context.Context, id int64) (response ResponseListRead, err error) {
 	// Send GET request.
 	u := fmt.Sprintf("/api/3/lists/%d", id)
-	r, body, err := c.get(u)
+	r, body, err := s.client.get(ctx, u)
+	if err != nil {
+		return response, fmt.Errorf("list read failed, HTTP error: %s", err)
+	}
 
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		if err = json.Unmarshal(body, &response); err != nil {
+		if err = s.client.decode(r, body, &response); err != nil {
 			return response, fmt.Errorf("list read failed, JSON error: %s", err)
 		}
 
@@ -78,14 +112,14 @@ func (c *Campaigner) ListRead(id int64) (response ResponseListRead, err error) {
 
 // List holds a JSON compatible list as it exists in the API.
 type List struct {
-	ID                   int64       `json:"id,string"`
+	ID                   Int64json   `json:"id"`
 	Name                 string      `json:"name"`
 	AnalyticsDomains     interface{} `json:"analytics_domains"`
 	AnalyticsSource      string      `json:"analytics_source"`
 	AnalyticsUa          string      `json:"analytics_ua"`
 	CarbonCopy           interface{} `json:"carboncopy"`
-	DateCreated          string      `json:"cdate"`
-	DateDeleted          interface{} `json:"deletestamp"`
+	DateCreated          ACTime      `json:"cdate"`
+	DateDeleted          ACTime      `json:"deletestamp"`
 	FacebookSession      interface{} `json:"facebook_session"`
 	FullAddress          string      `json:"fulladdress"`
 	GetUnsubscribeReason string      `json:"get_unsubscribe_reason"`
@@ -94,7 +128,7 @@ type List struct {
 		ContactGoalLists string `json:"contactGoalLists"`
 		User             string `json:"user"`
 	} `json:"links"`
-	OptInMessageID       string      `json:"optinmessageid"`
+	OptInMessageID       Int64json   `json:"optinmessageid"`
 	OptInOptOut          string      `json:"optinoptout"`
 	OptOutConfig         string      `json:"optoutconf"`
 	PEmbedImage          string      `json:"p_embed_image"`
@@ -104,9 +138,9 @@ type List struct {
 	PUseFacebook         string      `json:"p_use_facebook"`
 	PUseTracking         string      `json:"p_use_tracking"`
 	PUseTwitter          string      `json:"p_use_twitter"`
-	Private              string      `json:"private"`
-	RequireName          string      `json:"require_name"`
-	SendLastBroadcast    string      `json:"send_last_broadcast"`
+	Private              Booljson    `json:"private"`
+	RequireName          Booljson    `json:"require_name"`
+	SendLastBroadcast    Booljson    `json:"send_last_broadcast"`
 	SenderAddr1          string      `json:"sender_addr1"`
 	SenderAddr2          string      `json:"sender_addr2"`
 	SenderCity           string      `json:"sender_city"`
@@ -122,10 +156,10 @@ type List struct {
 	ToName               string      `json:"to_name"`
 	TwitterToken         string      `json:"twitter_token"`
 	TwitterTokenSecret   string      `json:"twitter_token_secret"`
-	DateUpdated          interface{} `json:"udate"`
+	DateUpdated          ACTime      `json:"udate"`
 	UnsubscriptionNotify interface{} `json:"unsubscription_notify"`
 	User                 string      `json:"user"`
-	UserID               string      `json:"userid"`
+	UserID               Int64json   `json:"userid"`
 }
 
 // RequestListContactAdd holds a JSON compatible request for adding contacts to lists.
@@ -141,17 +175,17 @@ type ResponseListContactAdd struct {
 		ListName string
 	}
 	ContactList struct {
-		Automation  interface{} `json:"automation"`
-		AutoSyncLog interface{} `json:"autosyncLog"`
-		Campaign    interface{} `json:"campaign"`
-		ContactID   Int64json   `json:"contact"`
-		FirstName   string      `json:"first_name"`
-		Form        interface{} `json:"form"`
-		ID          string      `json:"id"`
-		IP4Sub      string      `json:"ip4Sub"`
-		IP4Unsub    string      `json:"ip4Unsub"`
-		IP4Last     string      `json:"ip4_last"`
-		LastName    string      `json:"last_name"`
+		Automation  NullInt64json `json:"automation"`
+		AutoSyncLog interface{}   `json:"autosyncLog"`
+		Campaign    NullInt64json `json:"campaign"`
+		ContactID   Int64json     `json:"contact"`
+		FirstName   string        `json:"first_name"`
+		Form        NullInt64json `json:"form"`
+		ID          Int64json     `json:"id"`
+		IP4Sub      string        `json:"ip4Sub"`
+		IP4Unsub    string        `json:"ip4Unsub"`
+		IP4Last     string        `json:"ip4_last"`
+		LastName    string        `json:"last_name"`
 		Links       struct {
 			Automation            string `json:"automation"`
 			AutoSyncLog           string `json:"autosyncLog"`
@@ -162,16 +196,17 @@ type ResponseListContactAdd struct {
 			Message               string `json:"message"`
 			UnsubscribeAutomation string `json:"unsubscribeAutomation"`
 		} `json:"links"`
-		ListID                Int64json   `json:"list"`
-		Message               interface{} `json:"message"`
-		Responder             string      `json:"responder"`
-		DateSubscribed        string      `json:"sdate"`
-		SeriesID              Int64json   `json:"seriesid"`
-		SourceID              Int64json   `json:"sourceid"`
-		Status                int         `json:"status"`
-		Sync                  string      `json:"sync"`
-		UnsubscribeReason     string      `json:"unsubreason"`
-		UnsubscribeAutomation interface{} `json:"unsubscribeAutomation"`
+		ListID                Int64json     `json:"list"`
+		Message               NullInt64json `json:"message"`
+		Responder             string        `json:"responder"`
+		DateSubscribed        ACTime        `json:"sdate"`
+		DateUnsubscribed      ACTime        `json:"udate"`
+		SeriesID              Int64json     `json:"seriesid"`
+		SourceID              Int64json     `json:"sourceid"`
+		Status                Int64json     `json:"status"`
+		Sync                  string        `json:"sync"`
+		UnsubscribeReason     string        `json:"unsubreason"`
+		UnsubscribeAutomation interface{}   `json:"unsubscribeAutomation"`
 	} `json:"contactList"`
 	Contacts []Contact `json:"contacts"`
 }
@@ -180,7 +215,7 @@ type ResponseListContactAdd struct {
 type ResponseListList struct {
 	Lists []List `json:"lists"`
 	Meta  struct {
-		Total string `json:"total"`
+		Total Int64json `json:"total"`
 	} `json:"meta"`
 }
 
@@ -28,14 +28,14 @@ func TestListContactAdd_Success(t *testing.T) {
 	require.Nil(t, err)
 
 	// Test list addition for brand new contact.
-	_, err = C.ListContactAdd(testListID, c.Contact.ID)
+	_, err = C.ListContactAdd(testListID, c.Contact.ID.Int64())
 	assert.Nil(t, err)
 
 	// Test list addition for contact already in a list.
-	_, err = C.ListContactAdd(testListID, c.Contact.ID)
+	_, err = C.ListContactAdd(testListID, c.Contact.ID.Int64())
 	assert.Nil(t, err)
 
-	err = C.ContactDelete(c.Contact.ID)
+	err = C.ContactDelete(c.Contact.ID.Int64())
 	assert.Nil(t, err)
 }
 
@@ -0,0 +1,196 @@
+package campaigner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MASK replaces secrets (the API token, redacted values) in logs.
+const MASK = "********"
+
+// Logger receives log output from the library.  Arguments are alternating keys and values, *slog.Logger satisfies
+// this interface.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// RequestLog holds the details of a request about to be sent (see Campaigner.OnRequest).  The API token is masked in
+// Header.  Body is only set when LogBodies or DumpCurl is enabled.
+type RequestLog struct {
+	Method  string
+	URL     string
+	Header  http.Header
+	Body    []byte
+	Attempt int
+}
+
+// ResponseLog holds the details of a response (see Campaigner.OnResponse).  Err is set if no response was received.
+// Body is only set when LogBodies is enabled.
+type ResponseLog struct {
+	Request    RequestLog
+	StatusCode int
+	Latency    time.Duration
+	Body       []byte
+	Err        error
+}
+
+// Builds the log entry for a request, masking the token and redacting the body.
+func (c *Campaigner) requestLog(req *http.Request, body []byte, attempt int) RequestLog {
+	l := RequestLog{Method: req.Method, URL: req.URL.String(), Header: maskHeader(req.Header), Attempt: attempt}
+
+	if c.LogBodies || c.DumpCurl {
+		l.Body = c.redact(body)
+	}
+
+	return l
+}
+
+// Logs a request and calls the request hook.
+func (c *Campaigner) logRequest(l RequestLog) {
+	if c.Logger != nil {
+		args := []interface{}{"method", l.Method, "url", l.URL, "attempt", l.Attempt}
+		if c.LogBodies && len(l.Body) > 0 {
+			args = append(args, "body", string(l.Body))
+		}
+		c.Logger.Debug("campaigner request", args...)
+
+		if c.DumpCurl {
+			c.Logger.Debug("campaigner curl", "command", curlCommand(l))
+		}
+	}
+
+	if c.OnRequest != nil {
+		c.OnRequest(l)
+	}
+}
+
+// Logs a response and calls the response hook.
+func (c *Campaigner) logResponse(l ResponseLog) {
+	if c.Logger != nil {
+		switch {
+		case l.Err != nil:
+			c.Logger.Error("campaigner request failed", "method", l.Request.Method, "url", l.Request.URL, "latency", l.Latency, "error", l.Err)
+		case l.StatusCode == http.StatusTooManyRequests:
+			c.Logger.Warn("campaigner rate limited", "method", l.Request.Method, "url", l.Request.URL, "status", l.StatusCode, "latency", l.Latency, "attempt", l.Request.Attempt)
+		default:
+			args := []interface{}{"method", l.Request.Method, "url", l.Request.URL, "status", l.StatusCode, "latency", l.Latency}
+			if len(l.Body) > 0 {
+				args = append(args, "body", string(l.Body))
+			}
+			c.Logger.Debug("campaigner response", args...)
+		}
+	}
+
+	if c.OnResponse != nil {
+		c.OnResponse(l)
+	}
+}
+
+// Returns a redacted copy of a body for logging.
+func (c *Campaigner) redact(body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+
+	if c.RedactBody != nil {
+		return c.RedactBody(body)
+	}
+
+	return append([]byte{}, body...)
+}
+
+// RedactJSONFields returns a body redaction function (see Campaigner.RedactBody) that masks the values of the given
+// keys anywhere in a JSON document, e.g. RedactJSONFields("email", "phone").  Bodies that aren't JSON are masked
+// completely.
+func RedactJSONFields(keys ...string) func([]byte) []byte {
+	m := map[string]bool{}
+	for _, k := range keys {
+		m[strings.ToLower(k)] = true
+	}
+
+	return func(body []byte) []byte {
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			return []byte(MASK)
+		}
+
+		b, err := json.Marshal(redactValue(v, m))
+		if err != nil {
+			return []byte(MASK)
+		}
+
+		return b
+	}
+}
+
+// Masks matching keys in a decoded JSON value.
+func redactValue(v interface{}, keys map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, y := range t {
+			if keys[strings.ToLower(k)] {
+				t[k] = MASK
+			} else {
+				t[k] = redactValue(y, keys)
+			}
+		}
+	case []interface{}:
+		for x, y := range t {
+			t[x] = redactValue(y, keys)
+		}
+	}
+
+	return v
+}
+
+// Returns a copy of a header with the API token masked.
+func maskHeader(h http.Header) http.Header {
+	m := http.Header{}
+
+	for k, v := range h {
+		if http.CanonicalHeaderKey(k) == "Api-Token" {
+			m[k] = []string{MASK}
+			continue
+		}
+		m[k] = append([]string{}, v...)
+	}
+
+	return m
+}
+
+// Returns a curl command equivalent to a (masked) request.
+func curlCommand(l RequestLog) string {
+	var (
+		parts = []string{"curl", "-X", l.Method, shellQuote(l.URL)}
+		keys  []string
+	)
+
+	for k := range l.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range l.Header[k] {
+			parts = append(parts, "-H", shellQuote(fmt.Sprintf("%s: %s", k, v)))
+		}
+	}
+
+	if len(l.Body) > 0 {
+		parts = append(parts, "-d", shellQuote(string(l.Body)))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Quotes a string for use in a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
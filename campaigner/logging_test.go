@@ -0,0 +1,127 @@
+package campaigner
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// *slog.Logger must remain usable as a Logger.
+var _ Logger = slog.Default()
+
+// Tests all logging functionality as a group.  These tests run against a local test server.
+func TestLoggingSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestLogging_SuccessHooks)
+	runTestWithPackagePath(t, TestLogging_SuccessCurlRedacted)
+	runTestWithPackagePath(t, TestRedactJSONFields)
+}
+
+// Records log lines as "level msg key=value ...".
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) log(level string, msg string, args ...interface{}) {
+	line := level + " " + msg
+	for x := 0; x+1 < len(args); x += 2 {
+		line += fmt.Sprintf(" %v=%v", args[x], args[x+1])
+	}
+	l.lines = append(l.lines, line)
+}
+
+func (l *testLogger) Debug(msg string, args ...interface{}) { l.log("DEBUG", msg, args...) }
+func (l *testLogger) Info(msg string, args ...interface{})  { l.log("INFO", msg, args...) }
+func (l *testLogger) Warn(msg string, args ...interface{})  { l.log("WARN", msg, args...) }
+func (l *testLogger) Error(msg string, args ...interface{}) { l.log("ERROR", msg, args...) }
+
+func TestLogging_SuccessHooks(t *testing.T) {
+	var (
+		logger    testLogger
+		requests  []RequestLog
+		responses []ResponseLog
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag":{"id":"5","tag":"Test"}}`)
+	}))
+	defer s.Close()
+
+	c := Campaigner{
+		APIToken:   "secret-token",
+		BaseURL:    s.URL,
+		Logger:     &logger,
+		OnRequest:  func(l RequestLog) { requests = append(requests, l) },
+		OnResponse: func(l ResponseLog) { responses = append(responses, l) },
+	}
+
+	_, err := c.TagRead(5)
+	require.Nil(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, http.MethodGet, requests[0].Method)
+	assert.Equal(t, s.URL+"/api/3/tags/5", requests[0].URL)
+	assert.Equal(t, MASK, requests[0].Header.Get("Api-Token"))
+	assert.Empty(t, requests[0].Body)
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, http.StatusOK, responses[0].StatusCode)
+	assert.True(t, responses[0].Latency > 0)
+	assert.Empty(t, responses[0].Body)
+
+	require.Len(t, logger.lines, 2)
+	assert.True(t, strings.HasPrefix(logger.lines[0], "DEBUG campaigner request method=GET"), logger.lines[0])
+	assert.Contains(t, logger.lines[1], "status=200")
+	for _, l := range logger.lines {
+		assert.NotContains(t, l, "secret-token")
+	}
+}
+
+func TestLogging_SuccessCurlRedacted(t *testing.T) {
+	var (
+		logger    testLogger
+		responses []ResponseLog
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"contact":{"id":"7","email":"first.last@domain.com"}}`)
+	}))
+	defer s.Close()
+
+	c := Campaigner{
+		APIToken:   "secret-token",
+		BaseURL:    s.URL,
+		Logger:     &logger,
+		LogBodies:  true,
+		DumpCurl:   true,
+		RedactBody: RedactJSONFields("email"),
+		OnResponse: func(l ResponseLog) { responses = append(responses, l) },
+	}
+
+	_, err := c.ContactCreate(Contact{EmailAddress: "first.last@domain.com", FirstName: "First"})
+	require.Nil(t, err)
+
+	require.Len(t, logger.lines, 3)
+	assert.Contains(t, logger.lines[1], "command=curl -X POST '"+s.URL+"/api/3/contacts'")
+	assert.Contains(t, logger.lines[1], "-H 'Api-Token: "+MASK+"'")
+	assert.Contains(t, logger.lines[1], `"firstName":"First"`)
+	for _, l := range logger.lines {
+		assert.NotContains(t, l, "secret-token")
+		assert.NotContains(t, l, "first.last@domain.com")
+	}
+
+	require.Len(t, responses, 1)
+	assert.Contains(t, string(responses[0].Body), MASK)
+}
+
+func TestRedactJSONFields(t *testing.T) {
+	f := RedactJSONFields("Email", "phone")
+
+	assert.JSONEq(t, `{"contacts":[{"email":"`+MASK+`","phone":"`+MASK+`","firstName":"First"}]}`, string(f([]byte(`{"contacts":[{"email":"a@b.com","phone":"123","firstName":"First"}]}`))))
+	assert.Equal(t, MASK, string(f([]byte("not json"))))
+}
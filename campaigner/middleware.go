@@ -0,0 +1,181 @@
+package campaigner
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// APIRequest holds an API call as it passes through middleware.
+type APIRequest struct {
+	// Context is the context of the API call.  context.Background() is used if nil.
+	Context context.Context
+
+	Method string
+
+	// Path is the API path (and query) without the base URL, e.g. /api/3/contacts/12.
+	Path string
+
+	// Endpoint is the path with IDs replaced and the query removed, e.g. /api/3/contacts/{id}.  Suitable as a metric
+	// label or span name.
+	Endpoint string
+
+	// Header holds extra request headers, e.g. for conditional requests.
+	Header http.Header
+
+	Body []byte
+}
+
+// Returns the context of a request.
+func (r *APIRequest) context() context.Context {
+	if r.Context == nil {
+		return context.Background()
+	}
+
+	return r.Context
+}
+
+// APIResponse holds the outcome of an API call as it passes through middleware.
+type APIResponse struct {
+	HTTPResponse *http.Response
+	Body         []byte
+
+	// Retries is the number of times the request was retried after being rate limited.
+	Retries int
+}
+
+// StatusCode returns the HTTP status code or 0 if no response was received.
+func (r *APIResponse) StatusCode() int {
+	if r == nil || r.HTTPResponse == nil {
+		return 0
+	}
+
+	return r.HTTPResponse.StatusCode
+}
+
+// APIHandler sends an API call.
+type APIHandler func(request *APIRequest) (*APIResponse, error)
+
+// Middleware wraps an APIHandler, e.g. to instrument API calls.
+type Middleware func(next APIHandler) APIHandler
+
+// Matches path segments that are IDs (numbers or UUIDs).
+var endpointID = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// Placeholders for free-form path segments (emails, names, domains, ...) by the segment before them.  These would leak
+// personal data into span names and metric labels and make them unbounded.
+var endpointKeys = map[string]string{
+	"email":               "{email}",
+	"username":            "{username}",
+	"eventTrackingEvents": "{name}",
+	"siteTrackingDomains": "{domain}",
+	"schemas":             "{id}",
+	"records":             "{id}",
+	"external":            "{externalID}",
+}
+
+// EndpointTemplate returns the path of an API call with IDs and other keys replaced and the query removed, e.g.
+// /api/3/contacts/12/contactTags?limit=20 becomes /api/3/contacts/{id}/contactTags and /api/3/users/email/a@b.c becomes
+// /api/3/users/email/{email}.
+func EndpointTemplate(path string) string {
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+
+	// The API version (/api/3) is kept.
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	parts := append([]string{}, segments...)
+	for x, y := range segments {
+		switch {
+		case x > 0 && len(endpointKeys[segments[x-1]]) > 0:
+			parts[x] = endpointKeys[segments[x-1]]
+
+		// Custom object records are addressed as records/{schema}/{id} or records/{schema}/external/{externalID}.
+		case x > 1 && segments[x-2] == "records" && y != "external":
+			parts[x] = "{id}"
+
+		case endpointID.MatchString(y) && (x == 0 || segments[x-1] != "api"):
+			parts[x] = "{id}"
+		}
+	}
+
+	t := strings.Join(parts, "/")
+	if !strings.HasPrefix(t, "/") {
+		t = "/" + t
+	}
+
+	return t
+}
+
+// Tracer starts a span for every API call (see TracingMiddleware).  Adapters for tracing libraries implement this
+// outside of this package.
+type Tracer interface {
+	StartSpan(request *APIRequest) Span
+}
+
+// Span is ended once an API call has finished.  Response is nil if nothing was received.
+type Span interface {
+	End(response *APIResponse, err error)
+}
+
+// TracingMiddleware returns middleware that wraps every API call in a span.
+func TracingMiddleware(t Tracer) Middleware {
+	return func(next APIHandler) APIHandler {
+		return func(request *APIRequest) (*APIResponse, error) {
+			span := t.StartSpan(request)
+			response, err := next(request)
+			span.End(response, err)
+
+			return response, err
+		}
+	}
+}
+
+// RequestMetric holds the measurements of a single API call (see MetricsMiddleware).
+type RequestMetric struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Retries    int
+	Latency    time.Duration
+	Err        error
+}
+
+// MetricsRecorder records API call measurements, e.g. as a request counter and a latency histogram labelled by
+// method, endpoint and status.
+type MetricsRecorder interface {
+	RecordRequest(m RequestMetric)
+}
+
+// MetricsMiddleware returns middleware that records every API call.
+func MetricsMiddleware(r MetricsRecorder) Middleware {
+	return func(next APIHandler) APIHandler {
+		return func(request *APIRequest) (*APIResponse, error) {
+			start := time.Now()
+			response, err := next(request)
+
+			r.RecordRequest(RequestMetric{
+				Method:     request.Method,
+				Endpoint:   request.Endpoint,
+				StatusCode: response.StatusCode(),
+				Retries:    retries(response),
+				Latency:    time.Since(start),
+				Err:        err,
+			})
+
+			return response, err
+		}
+	}
+}
+
+// Returns the retry count of a possibly nil response.
+func retries(r *APIResponse) int {
+	if r == nil {
+		return 0
+	}
+
+	return r.Retries
+}
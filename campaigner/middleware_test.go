@@ -0,0 +1,137 @@
+package campaigner
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Tests all middleware functionality as a group.  These tests run against a local test server.
+func TestMiddlewareSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestEndpointTemplate)
+	runTestWithPackagePath(t, TestMiddleware_SuccessOrder)
+	runTestWithPackagePath(t, TestMiddleware_SuccessInstrumentation)
+}
+
+// Records spans as they are ended.
+type testTracer struct {
+	started []string
+	ended   []int
+}
+
+type testSpan struct {
+	t *testTracer
+}
+
+func (t *testTracer) StartSpan(request *APIRequest) Span {
+	t.started = append(t.started, request.Method+" "+request.Endpoint)
+	return testSpan{t: t}
+}
+
+func (s testSpan) End(response *APIResponse, err error) {
+	s.t.ended = append(s.t.ended, response.StatusCode())
+}
+
+type testRecorder []RequestMetric
+
+func (r *testRecorder) RecordRequest(m RequestMetric) {
+	*r = append(*r, m)
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	set := map[string]string{
+		"/api/3/contacts/12":                                                "/api/3/contacts/{id}",
+		"api/3/contacts/12/contactTags":                                     "/api/3/contacts/{id}/contactTags",
+		"/api/3/contacts?limit=20&offset=0":                                 "/api/3/contacts",
+		"/api/3/contacts/?filters%5Bemail%5D=a%40b.c":                       "/api/3/contacts",
+		"/api/3/customObjects/records/7b5e1e1c-6c1a-4b8c-9c44-6f5c8b1e2a3d": "/api/3/customObjects/records/{id}",
+		"/api/3/customObjects/records/orders/ext-1":                         "/api/3/customObjects/records/{id}/{id}",
+		"/api/3/customObjects/records/orders/external/Order%20%2312":        "/api/3/customObjects/records/{id}/external/{externalID}",
+		"/api/3/customObjects/schemas/orders?showFields=all":                "/api/3/customObjects/schemas/{id}",
+		"/api/3/users/email/jane%40example.com":                             "/api/3/users/email/{email}",
+		"/api/3/users/username/jane":                                        "/api/3/users/username/{username}",
+		"/api/3/eventTrackingEvents/trial%20started":                        "/api/3/eventTrackingEvents/{name}",
+		"/api/3/siteTrackingDomains/example.com":                            "/api/3/siteTrackingDomains/{domain}",
+		"/api/3/users/me":                                                   "/api/3/users/me",
+	}
+
+	for in, out := range set {
+		assert.Equal(t, out, EndpointTemplate(in), in)
+	}
+}
+
+func TestMiddleware_SuccessOrder(t *testing.T) {
+	var calls []string
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "server")
+		fmt.Fprint(w, `{}`)
+	}))
+	defer s.Close()
+
+	named := func(n string) Middleware {
+		return func(next APIHandler) APIHandler {
+			return func(request *APIRequest) (*APIResponse, error) {
+				calls = append(calls, n+" before")
+				response, err := next(request)
+				calls = append(calls, n+" after")
+				return response, err
+			}
+		}
+	}
+
+	c := Campaigner{APIToken: "token", BaseURL: s.URL, Middleware: []Middleware{named("outer"), named("inner")}}
+	require.Nil(t, c.ContactDelete(1))
+
+	assert.Equal(t, []string{"outer before", "inner before", "server", "inner after", "outer after"}, calls)
+}
+
+func TestMiddleware_SuccessInstrumentation(t *testing.T) {
+	var (
+		calls    int
+		tracer   testTracer
+		recorder testRecorder
+	)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"tag":{"id":"5"}}`)
+	}))
+	defer s.Close()
+
+	c := Campaigner{
+		APIToken:   "token",
+		BaseURL:    s.URL,
+		MaxRetries: 1,
+		Middleware: []Middleware{TracingMiddleware(&tracer), MetricsMiddleware(&recorder)},
+	}
+
+	_, err := c.TagRead(5)
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"GET /api/3/tags/{id}"}, tracer.started)
+	assert.Equal(t, []int{http.StatusOK}, tracer.ended)
+
+	require.Len(t, recorder, 1)
+	assert.Equal(t, "/api/3/tags/{id}", recorder[0].Endpoint)
+	assert.Equal(t, http.StatusOK, recorder[0].StatusCode)
+	assert.Equal(t, 1, recorder[0].Retries)
+	assert.Nil(t, recorder[0].Err)
+
+	// Transport failures are recorded without a status.
+	c = Campaigner{APIToken: "token", BaseURL: "http://127.0.0.1:1", Middleware: []Middleware{TracingMiddleware(&tracer), MetricsMiddleware(&recorder)}}
+	_, err = c.TagRead(5)
+	assert.NotNil(t, err)
+	require.Len(t, recorder, 2)
+	assert.Equal(t, 0, recorder[1].StatusCode)
+	assert.NotNil(t, recorder[1].Err)
+	assert.Equal(t, 0, tracer.ended[1])
+}
@@ -1,9 +1,9 @@
 package campaigner
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -15,9 +15,9 @@ type Organization struct {
 	// TODO(api): Contact and deal counts should probably not be strings.
 	Name         string                 `json:"name"`
 	Links        map[string]interface{} `json:"links"`
-	ID           int64                  `json:"id,string"`
-	ContactCount string                 `json:"contactCount"`
-	DealCount    string                 `json:"dealCount"`
+	ID           Int64json              `json:"id"`
+	ContactCount Int64json              `json:"contactCount"`
+	DealCount    Int64json              `json:"dealCount"`
 }
 
 // ResponseOrganizationCreate holds a JSON compatible response for creating organizations.
@@ -25,18 +25,18 @@ type ResponseOrganizationCreate struct {
 	Organization struct {
 		Name  string                 `json:"name"`
 		Links map[string]interface{} `json:"links"`
-		ID    int64                  `json:"id,string"`
+		ID    Int64json              `json:"id"`
 	} `json:"organization"`
 }
 
 // ResponseOrganizationUpdate holds a JSON compatible response for updating organizations.
 type ResponseOrganizationUpdate struct {
 	Organization struct {
-		ID               int64                  `json:"id,string"`
+		ID               Int64json              `json:"id"`
 		Name             string                 `json:"name"`
 		Links            map[string]interface{} `json:"links"`
-		CreatedTimestamp string                 `json:"created_timestamp"`
-		UpdatedTimestamp string                 `json:"updated_timestamp"`
+		CreatedTimestamp ACTimeUTC              `json:"created_timestamp"`
+		UpdatedTimestamp ACTimeUTC              `json:"updated_timestamp"`
 	} `json:"organization"`
 }
 
@@ -49,12 +49,12 @@ type ResponseOrganizationRead struct {
 type ResponseOrganizationList struct {
 	Organizations []Organization `json:"organizations"`
 	Meta          struct {
-		Total int `json:"total,string"`
+		Total Int64json `json:"total"`
 	} `json:"meta"`
 }
 
-// OrganizationCreate creates an organization.
-func (c *Campaigner) OrganizationCreate(org Organization) (result ResponseOrganizationCreate, err error) {
+// Create creates an organization.
+func (s *OrganizationAPI) Create(ctx context.Context, org Organization) (result ResponseOrganizationCreate, err error) {
 	var (
 		uri  = "/api/3/organizations"
 		data = map[string]interface{}{
@@ -62,7 +62,7 @@ func (c *Campaigner) OrganizationCreate(org Organization) (result ResponseOrgani
 		}
 	)
 
-	r, body, err := c.post(uri, data)
+	r, body, err := s.client.post(ctx, uri, data)
 	if err != nil {
 		return result, fmt.Errorf("organization creation failed, HTTP error: %s", err)
 	}
@@ -70,7 +70,7 @@ func (c *Campaigner) OrganizationCreate(org Organization) (result ResponseOrgani
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusCreated:
-		err = json.Unmarshal(body, &result)
+		err = s.client.decode(r, body, &result)
 		if err != nil {
 			return result, fmt.Errorf("organization creation failed, JSON error: %s", err)
 		}
@@ -89,17 +89,17 @@ func (c *Campaigner) OrganizationCreate(org Organization) (result ResponseOrgani
 	}
 }
 
-// OrganizationDelete deletes an organization by it's ID.
+// Delete deletes an organization by it's ID.
 //
 // TODO(error-checking): Are there other HTTP status codes to check for?
-func (c *Campaigner) OrganizationDelete(id int64) error {
+func (s *OrganizationAPI) Delete(ctx context.Context, id int64) error {
 	// Setup.
 	var (
 		uri = fmt.Sprintf("/api/3/organizations/%d", id)
 	)
 
 	// Send DELETE request.
-	r, b, err := c.delete(uri)
+	r, b, err := s.client.delete(ctx, uri)
 	if err != nil {
 		return fmt.Errorf("organization delete failed, HTTP failure: %s", err)
 	}
@@ -117,12 +117,12 @@ func (c *Campaigner) OrganizationDelete(id int64) error {
 	}
 }
 
-// OrganizationFind finds an organization by it's name.  If there are no matches the response contains a list with zero length.
+// Find finds an organization by it's name.  If there are no matches the response contains a list with zero length.
 //
 // Partial name searches are not supported by the API.
 //
 // TODO(API): Figure out if more than one name can be searched (wildcard?).
-func (c *Campaigner) OrganizationFind(n string) (ResponseOrganizationList, error) {
+func (s *OrganizationAPI) Find(ctx context.Context, n string) (ResponseOrganizationList, error) {
 	// Setup.
 	var (
 		qs       = fmt.Sprintf("%s=%s", url.QueryEscape("filters[name]"), url.QueryEscape(n))
@@ -136,7 +136,7 @@ func (c *Campaigner) OrganizationFind(n string) (ResponseOrganizationList, error
 	}
 
 	// Send GET request.
-	r, body, err := c.get(u)
+	r, body, err := s.client.get(ctx, u)
 	if err != nil {
 		return response, fmt.Errorf("organization find failed. HTTP failure: %s", err)
 	}
@@ -144,7 +144,7 @@ func (c *Campaigner) OrganizationFind(n string) (ResponseOrganizationList, error
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("organization list failed, JSON failure: %s", err)
 		}
@@ -155,8 +155,8 @@ func (c *Campaigner) OrganizationFind(n string) (ResponseOrganizationList, error
 	return response, fmt.Errorf("organization find failed, unspecified error (%d); %s", r.StatusCode, string(body))
 }
 
-// OrganizationList lists all organizations.
-func (c *Campaigner) OrganizationList(limit int, offset int) (response ResponseOrganizationList, err error) {
+// List lists all organizations.
+func (s *OrganizationAPI) List(ctx context.Context, limit int, offset int) (response ResponseOrganizationList, err error) {
 	// Setup.
 	qs := url.Values{}
 	qs.Set("limit", strconv.Itoa(limit))
@@ -164,7 +164,7 @@ func (c *Campaigner) OrganizationList(limit int, offset int) (response ResponseO
 	u := url.URL{Path: "/api/3/organizations", RawQuery: qs.Encode()}
 
 	// GET request.
-	r, body, err := c.get(u.String())
+	r, body, err := s.client.get(ctx, u.String())
 	if err != nil {
 		return response, fmt.Errorf("organization list failed, HTTP failure: %s", err)
 	}
@@ -172,7 +172,7 @@ func (c *Campaigner) OrganizationList(limit int, offset int) (response ResponseO
 	// Success.
 	// TODO(doc-mismatch): 200 != 201
 	if r.StatusCode == http.StatusOK {
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("organization list failed, JSON failure: %s", err)
 		}
@@ -184,16 +184,16 @@ func (c *Campaigner) OrganizationList(limit int, offset int) (response ResponseO
 	return response, fmt.Errorf("organization list failed, unspecified error (%d): %s", r.StatusCode, string(body))
 }
 
-// OrganizationRead reads an organization by it's ID.
+// Read reads an organization by it's ID.
 //
 // TODO(api): Possible bug in that contactCount and dealCount are not present in the JSON returned by read.
-func (c *Campaigner) OrganizationRead(id int64) (response ResponseOrganizationRead, err error) {
+func (s *OrganizationAPI) Read(ctx context.Context, id int64) (response ResponseOrganizationRead, err error) {
 	// TODO(error-checking): Should probably return a CustomErrorNotFound here.
 	// Setup.
 	u := fmt.Sprintf("/api/3/organizations/%d", id)
 
 	// Send GET request.
-	r, body, err := c.get(u)
+	r, body, err := s.client.get(ctx, u)
 	if err != nil {
 		return response, err
 	}
@@ -201,7 +201,7 @@ func (c *Campaigner) OrganizationRead(id int64) (response ResponseOrganizationRe
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("organization read failed, JSON failure: %s", err)
 		}
@@ -214,20 +214,19 @@ func (c *Campaigner) OrganizationRead(id int64) (response ResponseOrganizationRe
 	return response, nil
 }
 
-// OrganizationUpdate updates an organization.
-func (c *Campaigner) OrganizationUpdate(id int64, request RequestOrganizationUpdate) (response ResponseOrganizationUpdate, err error) {
+// Update updates an organization.
+func (s *OrganizationAPI) Update(ctx context.Context, id int64, request RequestOrganizationUpdate) (response ResponseOrganizationUpdate, err error) {
 	u := url.URL{Path: fmt.Sprintf("/api/3/organizations/%d", id)}
 	d := map[string]interface{}{"organization": request}
 
-	r, body, err := c.put(u.String(), d)
+	r, body, err := s.client.put(ctx, u.String(), d)
 	if err != nil {
 		return response, fmt.Errorf("organization updated failed, HTTP error: %s", err)
 	}
 
 	switch r.StatusCode {
 	case http.StatusOK:
-		log.Println(string(body))
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("organization update failed, JSON error: %s", err)
 		}
@@ -42,11 +42,11 @@ func TestOrganizationCreate_Success(t *testing.T) {
 
 	resp, err := c.OrganizationCreate(org)
 
-	testOrganizationID = resp.Organization.ID
+	testOrganizationID = resp.Organization.ID.Int64()
 
 	assert.Nil(t, err)
 	assert.NotNil(t, resp)
-	assert.IsType(t, int64(1), resp.Organization.ID)
+	assert.IsType(t, Int64json(1), resp.Organization.ID)
 }
 
 func TestOrganizationDelete_FailureNotFound(t *testing.T) {
@@ -76,7 +76,7 @@ func TestOrganizationDelete_Success(t *testing.T) {
 		if err != nil {
 			return
 		}
-		testOrganizationID = resp.Organization.ID
+		testOrganizationID = resp.Organization.ID.Int64()
 	}
 
 	err := c.OrganizationDelete(testOrganizationID)
@@ -104,7 +104,7 @@ func TestOrganizationFind_Success(t *testing.T) {
 
 	r, err := C.OrganizationFind(n)
 	assert.Nil(t, err)
-	assert.NotEqual(t, 0, r.Meta.Total)
+	assert.NotEqual(t, Int64json(0), r.Meta.Total)
 	assert.Equal(t, 1, len(r.Organizations))
 	assert.Equal(t, n, r.Organizations[0].Name)
 
@@ -131,7 +131,7 @@ func TestOrganizationRead_Failure(t *testing.T) {
 func TestOrganizationRead_Success(t *testing.T) {
 	r, err := C.OrganizationRead(testOrganizationID)
 	assert.Nil(t, err)
-	assert.Equal(t, testOrganizationID, r.Organization.ID)
+	assert.Equal(t, testOrganizationID, r.Organization.ID.Int64())
 	assert.NotEmpty(t, r.Organization.Name)
 }
 
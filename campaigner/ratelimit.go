@@ -0,0 +1,59 @@
+package campaigner
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DEFAULT_RATE_LIMIT is the number of requests per second allowed by the API (per account).
+const DEFAULT_RATE_LIMIT = 5
+
+// RateLimiter spaces requests out so that no more than a fixed number are sent per second.  It is safe for concurrent
+// use and is meant to be shared by everything talking to the same account.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter returns a rate limiter allowing perSecond requests per second.
+func NewRateLimiter(perSecond int) *RateLimiter {
+	if perSecond < 1 {
+		perSecond = 1
+	}
+
+	return &RateLimiter{interval: time.Second / time.Duration(perSecond)}
+}
+
+// Wait blocks until the next request may be sent.
+func (l *RateLimiter) Wait() {
+	_ = l.WaitContext(context.Background())
+}
+
+// WaitContext blocks until the next request may be sent or the context is done.  The reserved slot is not given back
+// when the context is done first.
+func (l *RateLimiter) WaitContext(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
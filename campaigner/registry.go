@@ -0,0 +1,279 @@
+package campaigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Account holds the settings of a named account (see Registry).
+type Account struct {
+	Name     string `json:"name"`
+	APIToken string `json:"api_token"`
+	BaseURL  string `json:"base_url"`
+
+	// RateLimit is the number of requests per second sent to the account, DEFAULT_RATE_LIMIT if 0.
+	RateLimit int `json:"rate_limit"`
+
+	// TimeZone is the IANA time zone of the account (e.g. America/Chicago), UTC if empty (see Campaigner.Location).
+	TimeZone string `json:"time_zone"`
+}
+
+// RegistryConfig holds a JSON compatible registry config file (see LoadRegistry).
+type RegistryConfig struct {
+	Accounts []Account `json:"accounts"`
+}
+
+// Registry holds named accounts and hands out one client per account.  Every client has its own rate limiter and
+// cache, so busy accounts don't slow each other down.  It is safe for concurrent use.
+type Registry struct {
+	// Setup is called once for every client the registry creates, e.g. to set a Logger or Middleware.
+	Setup func(name string, c *Campaigner)
+
+	// Concurrency is the maximum number of accounts FanOut calls at the same time, all of them if 0.
+	Concurrency int
+
+	mu       sync.Mutex
+	accounts map[string]Account
+	clients  map[string]*Campaigner
+}
+
+// AccountResult holds the outcome of a fan out call for one account.
+type AccountResult struct {
+	Account string
+	Value   interface{}
+	Err     error
+}
+
+// RegistryError holds the results of every account that failed during a fan out call.
+type RegistryError struct {
+	Failed []AccountResult
+}
+
+// Error satisfies the error interface.
+func (e RegistryError) Error() string {
+	var l []string
+
+	for _, y := range e.Failed {
+		l = append(l, fmt.Sprintf("%s: %s", y.Account, y.Err))
+	}
+
+	return fmt.Sprintf("fan out failed, %d account(s) with errors (%s)", len(e.Failed), strings.Join(l, ", "))
+}
+
+// NewRegistry returns a registry holding the given accounts.
+func NewRegistry(accounts ...Account) (*Registry, error) {
+	r := &Registry{accounts: map[string]Account{}, clients: map[string]*Campaigner{}}
+
+	for _, a := range accounts {
+		if err := r.Add(a); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// LoadRegistry returns a registry holding the accounts of a JSON config file (see RegistryConfig), e.g.
+//
+//	{"accounts": [{"name": "acme", "api_token": "...", "base_url": "https://acme.api-us1.com", "rate_limit": 2}]}
+func LoadRegistry(path string) (*Registry, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("registry load failed, could not read config: %s", err)
+	}
+
+	var config RegistryConfig
+	if err = json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("registry load failed, JSON error: %s", err)
+	}
+
+	return NewRegistry(config.Accounts...)
+}
+
+// LoadRegistryFromEnv returns a registry holding every account found in the environment.  An account is defined by
+// AC_<NAME>_API_TOKEN and AC_<NAME>_BASE_URL, optionally with AC_<NAME>_RATE_LIMIT.  Names are lower case, e.g.
+// AC_ACME_CORP_API_TOKEN defines the account "acme_corp".
+func LoadRegistryFromEnv() (*Registry, error) {
+	var accounts []Account
+
+	for _, e := range os.Environ() {
+		key := strings.SplitN(e, "=", 2)[0]
+		if !strings.HasPrefix(key, "AC_") || !strings.HasSuffix(key, "_API_TOKEN") || len(key) <= len("AC__API_TOKEN") {
+			continue
+		}
+
+		prefix := strings.TrimSuffix(key, "API_TOKEN")
+		a := Account{
+			Name:     strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(prefix, "AC_"), "_")),
+			APIToken: os.Getenv(key),
+			BaseURL:  os.Getenv(prefix + "BASE_URL"),
+			TimeZone: os.Getenv(prefix + "TIME_ZONE"),
+		}
+
+		if s := os.Getenv(prefix + "RATE_LIMIT"); len(s) > 0 {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, fmt.Errorf("registry load failed, %sRATE_LIMIT is not a number: %s", prefix, s)
+			}
+			a.RateLimit = n
+		}
+
+		accounts = append(accounts, a)
+	}
+
+	sort.Slice(accounts, func(x, y int) bool { return accounts[x].Name < accounts[y].Name })
+
+	return NewRegistry(accounts...)
+}
+
+// Add adds an account.  Names must be unique.
+func (r *Registry) Add(a Account) error {
+	if len(a.Name) == 0 {
+		return fmt.Errorf("account add failed, name is empty")
+	}
+	if len(a.APIToken) == 0 {
+		return fmt.Errorf("account add failed, API token of %s is empty", a.Name)
+	}
+	if len(a.BaseURL) == 0 {
+		return fmt.Errorf("account add failed, base URL of %s is empty", a.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.accounts[a.Name]; ok {
+		return fmt.Errorf("account add failed, %s already exists", a.Name)
+	}
+
+	r.accounts[a.Name] = a
+	return nil
+}
+
+// Names returns the account names in alphabetical order.
+func (r *Registry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.accounts))
+	for n := range r.accounts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Client returns the client of an account, creating it on first use.  The same client is returned on every call.
+func (r *Registry) Client(name string) (*Campaigner, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if c, ok := r.clients[name]; ok {
+		return c, nil
+	}
+
+	a, ok := r.accounts[name]
+	if !ok {
+		return nil, CustomErrorNotFound{CustomError{Message: fmt.Sprintf("account %s not found", name)}}
+	}
+
+	rate := a.RateLimit
+	if rate == 0 {
+		rate = DEFAULT_RATE_LIMIT
+	}
+
+	loc, err := time.LoadLocation(a.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("account %s has an invalid time zone: %s", name, err)
+	}
+
+	c := New(a.APIToken, a.BaseURL)
+	c.Location = loc
+	c.RateLimiter = NewRateLimiter(rate)
+	c.MaxRetries = DEFAULT_MAX_RETRIES
+	c.Cache = NewCache()
+	if r.Setup != nil {
+		r.Setup(name, c)
+	}
+
+	r.clients[name] = c
+	return c, nil
+}
+
+// FanOut calls fn for every account concurrently and returns the results in account name order.  If any call failed
+// the error is a RegistryError.
+func (r *Registry) FanOut(ctx context.Context, fn func(ctx context.Context, name string, c *Campaigner) (interface{}, error)) ([]AccountResult, error) {
+	var (
+		names   = r.Names()
+		results = make([]AccountResult, len(names))
+		slots   = len(names)
+		wg      sync.WaitGroup
+	)
+
+	if r.Concurrency > 0 && r.Concurrency < slots {
+		slots = r.Concurrency
+	}
+	sem := make(chan struct{}, slots)
+
+	for x, name := range names {
+		wg.Add(1)
+		go func(x int, name string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[x] = AccountResult{Account: name}
+
+			c, err := r.Client(name)
+			if err == nil {
+				err = ctx.Err()
+			}
+			if err != nil {
+				results[x].Err = err
+				return
+			}
+
+			results[x].Value, results[x].Err = fn(ctx, name, c)
+		}(x, name)
+	}
+	wg.Wait()
+
+	var failed []AccountResult
+	for _, y := range results {
+		if y.Err != nil {
+			failed = append(failed, y)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, RegistryError{Failed: failed}
+	}
+
+	return results, nil
+}
+
+// TagList lists the tags of every account, by account name.  Accounts that failed are missing from the map and listed
+// in the RegistryError.
+func (r *Registry) TagList(ctx context.Context) (map[string]ResponseTagList, error) {
+	results, err := r.FanOut(ctx, func(ctx context.Context, name string, c *Campaigner) (interface{}, error) {
+		return c.Tags.List(ctx)
+	})
+
+	m := map[string]ResponseTagList{}
+	for _, y := range results {
+		if y.Err == nil {
+			m[y.Account] = y.Value.(ResponseTagList)
+		}
+	}
+
+	return m, err
+}
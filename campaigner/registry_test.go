@@ -0,0 +1,145 @@
+package campaigner
+
+import (
+	"context"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// Tests all registry functionality as a group.  These tests run against local test servers.
+func TestRegistrySuite(t *testing.T) {
+	runTestWithPackagePath(t, TestRegistry_SuccessClient)
+	runTestWithPackagePath(t, TestRegistry_SuccessLoad)
+	runTestWithPackagePath(t, TestRegistry_SuccessLoadFromEnv)
+	runTestWithPackagePath(t, TestRegistry_FailureAdd)
+	runTestWithPackagePath(t, TestRegistry_TagList)
+}
+
+func TestRegistry_SuccessClient(t *testing.T) {
+	var setup []string
+	r, err := NewRegistry(Account{Name: "b", APIToken: "t", BaseURL: "http://b"}, Account{Name: "a", APIToken: "t", BaseURL: "http://a", RateLimit: 2})
+	require.Nil(t, err)
+	r.Setup = func(name string, c *Campaigner) { setup = append(setup, name) }
+
+	assert.Equal(t, []string{"a", "b"}, r.Names())
+
+	a, err := r.Client("a")
+	require.Nil(t, err)
+	assert.Equal(t, "http://a", a.BaseURL)
+	assert.NotNil(t, a.Tags)
+
+	again, err := r.Client("a")
+	require.Nil(t, err)
+	assert.True(t, a == again)
+
+	// Accounts don't share limiters or caches.
+	b, err := r.Client("b")
+	require.Nil(t, err)
+	assert.False(t, a.RateLimiter == b.RateLimiter)
+	assert.False(t, a.Cache == b.Cache)
+	assert.Equal(t, []string{"a", "b"}, setup)
+
+	_, err = r.Client("c")
+	assert.IsType(t, CustomErrorNotFound{}, err)
+
+	// Accounts read timestamps in their own time zone.
+	require.Nil(t, r.Add(Account{Name: "chicago", APIToken: "t", BaseURL: "http://chicago", TimeZone: "America/Chicago"}))
+	require.Nil(t, r.Add(Account{Name: "mars", APIToken: "t", BaseURL: "http://mars", TimeZone: "Mars/Base"}))
+
+	chicago, err := r.Client("chicago")
+	require.Nil(t, err)
+	assert.Equal(t, "America/Chicago", chicago.Location.String())
+	assert.Equal(t, "UTC", a.Location.String())
+
+	_, err = r.Client("mars")
+	assert.NotNil(t, err)
+}
+
+func TestRegistry_SuccessLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{"accounts":[{"name":"acme","api_token":"t","base_url":"http://acme","rate_limit":2}]}`), 0644))
+
+	r, err := LoadRegistry(path)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"acme"}, r.Names())
+
+	_, err = LoadRegistry(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func TestRegistry_SuccessLoadFromEnv(t *testing.T) {
+	t.Setenv("AC_ACME_CORP_API_TOKEN", "acme-token")
+	t.Setenv("AC_ACME_CORP_BASE_URL", "http://acme")
+	t.Setenv("AC_ACME_CORP_RATE_LIMIT", "2")
+	t.Setenv("AC_ACME_CORP_TIME_ZONE", "America/Chicago")
+	t.Setenv("AC_PIZZA_API_TOKEN", "pizza-token")
+	t.Setenv("AC_PIZZA_BASE_URL", "http://pizza")
+
+	r, err := LoadRegistryFromEnv()
+	require.Nil(t, err)
+	assert.Contains(t, r.Names(), "acme_corp")
+	assert.Contains(t, r.Names(), "pizza")
+
+	c, err := r.Client("acme_corp")
+	require.Nil(t, err)
+	assert.Equal(t, "acme-token", c.APIToken)
+	assert.Equal(t, "http://acme", c.BaseURL)
+	assert.Equal(t, "America/Chicago", c.Location.String())
+
+	// Accounts without a base URL are rejected.
+	t.Setenv("AC_BROKEN_API_TOKEN", "broken-token")
+	_, err = LoadRegistryFromEnv()
+	assert.NotNil(t, err)
+}
+
+func TestRegistry_FailureAdd(t *testing.T) {
+	r, err := NewRegistry(Account{Name: "a", APIToken: "t", BaseURL: "http://a"})
+	require.Nil(t, err)
+
+	assert.NotNil(t, r.Add(Account{Name: "a", APIToken: "t", BaseURL: "http://a"}))
+	assert.NotNil(t, r.Add(Account{APIToken: "t", BaseURL: "http://a"}))
+	assert.NotNil(t, r.Add(Account{Name: "b", BaseURL: "http://b"}))
+	assert.NotNil(t, r.Add(Account{Name: "b", APIToken: "t"}))
+}
+
+func TestRegistry_TagList(t *testing.T) {
+	a := actest.NewServer()
+	defer a.Close()
+	a.AddTag(actest.Tag{Name: "Customer"})
+
+	b := actest.NewServer()
+	defer b.Close()
+	b.AddTag(actest.Tag{Name: "Lead"})
+	b.AddTag(actest.Tag{Name: "Customer"})
+
+	r, err := NewRegistry(
+		Account{Name: "a", APIToken: a.APIToken, BaseURL: a.URL},
+		Account{Name: "b", APIToken: b.APIToken, BaseURL: b.URL},
+		Account{Name: "c", APIToken: "wrong", BaseURL: b.URL},
+	)
+	require.Nil(t, err)
+
+	tags, err := r.TagList(context.Background())
+	require.NotNil(t, err)
+	require.IsType(t, RegistryError{}, err)
+	require.Len(t, err.(RegistryError).Failed, 1)
+	assert.Equal(t, "c", err.(RegistryError).Failed[0].Account)
+
+	require.Len(t, tags, 2)
+	assert.Len(t, tags["a"].Tags, 1)
+	assert.Len(t, tags["b"].Tags, 2)
+
+	// Canceled fan outs don't send requests.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := r.FanOut(ctx, func(ctx context.Context, name string, c *Campaigner) (interface{}, error) {
+		t.Errorf("called for %s", name)
+		return nil, nil
+	})
+	assert.NotNil(t, err)
+	assert.Len(t, results, 3)
+}
@@ -0,0 +1,125 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// The types of records a score applies to (Score.RelType).
+const (
+	SCORE_CONTACT = "contact"
+	SCORE_DEAL    = "deal"
+)
+
+// Score holds a JSON compatible score (lead or deal scoring rule set) as it exists in the API.
+type Score struct {
+	ID          Int64json         `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"descript"`
+	RelType     string            `json:"reltype"`
+	Status      Booljson          `json:"status"`
+	Created     ACTime            `json:"cdate"`
+	Updated     ACTime            `json:"mdate"`
+	Links       map[string]string `json:"links"`
+}
+
+// ScoreValue holds a JSON compatible score value: the points of a contact or a deal for a score.
+type ScoreValue struct {
+	ID        Int64json         `json:"id"`
+	ScoreID   Int64json         `json:"score"`
+	ContactID Int64json         `json:"contact"`
+	DealID    Int64json         `json:"deal"`
+	Value     Int64json         `json:"scoreValue"`
+	Created   ACTime            `json:"cdate"`
+	Updated   ACTime            `json:"mdate"`
+	Links     map[string]string `json:"links"`
+}
+
+// ResponseScoreRead holds a JSON compatible response for reading a score.
+type ResponseScoreRead struct {
+	Score Score `json:"score"`
+}
+
+// ResponseScoreList holds a JSON compatible response for listing scores.
+type ResponseScoreList struct {
+	Scores []Score `json:"scores"`
+	Meta   struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// ResponseScoreValueList holds a JSON compatible response for listing the score values of a contact or a deal.
+type ResponseScoreValueList struct {
+	ScoreValues []ScoreValue `json:"scoreValues"`
+}
+
+// Active returns whether a score is being kept up to date.
+func (s Score) Active() bool {
+	return s.Status.Bool()
+}
+
+// Value returns the points for a score, 0 if there are none.
+func (r ResponseScoreValueList) Value(scoreID int64) int64 {
+	for _, v := range r.ScoreValues {
+		if v.ScoreID.Int64() == scoreID {
+			return v.Value.Int64()
+		}
+	}
+
+	return 0
+}
+
+// List lists scores.
+func (s *ScoreAPI) List(ctx context.Context, limit int, offset int) (response ResponseScoreList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/scores", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("score list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "score list", "scores")
+
+	return response, err
+}
+
+// Read reads a score.
+func (s *ScoreAPI) Read(ctx context.Context, id int64) (response ResponseScoreRead, err error) {
+	r, body, err := s.client.get(ctx, fmt.Sprintf("/api/3/scores/%d", id))
+	if err != nil {
+		return response, fmt.Errorf("score read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "score read", fmt.Sprintf("score %d", id))
+
+	return response, err
+}
+
+// ContactValues lists the score values of a contact.
+func (s *ScoreAPI) ContactValues(ctx context.Context, contactID int64) (ResponseScoreValueList, error) {
+	return s.values(ctx, fmt.Sprintf("/api/3/contacts/%d/scoreValues", contactID), fmt.Sprintf("contact %d", contactID))
+}
+
+// DealValues lists the score values of a deal.
+func (s *ScoreAPI) DealValues(ctx context.Context, dealID int64) (ResponseScoreValueList, error) {
+	return s.values(ctx, fmt.Sprintf("/api/3/deals/%d/scoreValues", dealID), fmt.Sprintf("deal %d", dealID))
+}
+
+// Lists score values from an endpoint.
+func (s *ScoreAPI) values(ctx context.Context, uri string, what string) (response ResponseScoreValueList, err error) {
+	r, body, err := s.client.get(ctx, uri)
+	if err != nil {
+		return response, fmt.Errorf("score value list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "score value list", what)
+
+	return response, err
+}
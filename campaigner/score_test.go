@@ -0,0 +1,85 @@
+package campaigner
+
+import (
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// Tests all scoring functionality as a group.  These tests run against a local test server.
+func TestScoreSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestScore_SuccessRead)
+	runTestWithPackagePath(t, TestScore_SuccessContactValues)
+	runTestWithPackagePath(t, TestScore_SuccessDealValues)
+	runTestWithPackagePath(t, TestScore_FailureValues)
+}
+
+func TestScore_SuccessRead(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	id := s.AddScore(actest.Score{Name: "Lead score", Description: "Engagement"})
+	s.AddScore(actest.Score{Name: "Deal score", RelType: SCORE_DEAL, Inactive: true})
+
+	read, err := c.Scores.Read(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, "Lead score", read.Score.Name)
+	assert.Equal(t, SCORE_CONTACT, read.Score.RelType)
+	assert.True(t, read.Score.Active())
+
+	list, err := c.Scores.List(ctx, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, list.Scores, 2)
+	assert.Equal(t, SCORE_DEAL, list.Scores[1].RelType)
+	assert.False(t, list.Scores[1].Active())
+
+	_, err = c.Scores.Read(ctx, 99)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestScore_SuccessContactValues(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		score   = s.AddScore(actest.Score{Name: "Lead score"})
+		contact = s.AddContact(actest.Contact{EmailAddress: "lead@example.com"})
+	)
+	s.AddScoreValue(actest.ScoreValue{ScoreID: score, ContactID: contact, Value: 40})
+
+	values, err := c.Scores.ContactValues(ctx, contact)
+	require.Nil(t, err)
+	require.Len(t, values.ScoreValues, 1)
+	assert.Equal(t, int64(40), values.Value(score))
+	assert.Equal(t, int64(0), values.Value(99))
+
+	// Score values are sideloaded when listing contacts.
+	list, err := c.Contacts.List(ctx, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, list.ScoreValues, 1)
+	assert.Equal(t, contact, list.ScoreValues[0].ContactID.Int64())
+}
+
+func TestScore_SuccessDealValues(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	score := s.AddScore(actest.Score{Name: "Deal score", RelType: SCORE_DEAL})
+
+	values, err := c.Scores.DealValues(ctx, 7)
+	require.Nil(t, err)
+	assert.Empty(t, values.ScoreValues)
+
+	s.AddScoreValue(actest.ScoreValue{ScoreID: score, DealID: 7, Value: 25})
+
+	values, err = c.Scores.DealValues(ctx, 7)
+	require.Nil(t, err)
+	require.Len(t, values.ScoreValues, 1)
+	assert.Equal(t, int64(7), values.ScoreValues[0].DealID.Int64())
+	assert.Equal(t, int64(25), values.Value(score))
+}
+
+func TestScore_FailureValues(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	_, err := c.Scores.ContactValues(ctx, 99)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
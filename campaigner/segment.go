@@ -0,0 +1,185 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DEFAULT_SEGMENT_PAGE_SIZE is the number of contacts a SegmentContactIterator reads per request when no page size is
+// given.
+const DEFAULT_SEGMENT_PAGE_SIZE = 100
+
+// Segment holds a JSON compatible segment as it exists in the API.  Segments are saved contact searches built by
+// marketers, their conditions are not exposed by the API.
+type Segment struct {
+	ID        Int64json         `json:"id"`
+	Name      string            `json:"name"`
+	Logic     string            `json:"logic"`
+	Hidden    Booljson          `json:"hidden"`
+	SeriesID  Int64json         `json:"seriesid"`
+	CreatedBy Int64json         `json:"created_by"`
+	UpdatedBy Int64json         `json:"updated_by"`
+	Created   ACTime            `json:"created_timestamp"`
+	Updated   ACTime            `json:"updated_timestamp"`
+	Links     map[string]string `json:"links"`
+}
+
+// ResponseSegmentRead holds a JSON compatible response for reading a segment.
+type ResponseSegmentRead struct {
+	Segment Segment `json:"segment"`
+}
+
+// ResponseSegmentList holds a JSON compatible response for listing segments.
+type ResponseSegmentList struct {
+	Segments []Segment `json:"segments"`
+	Meta     struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// Names returns the names of the listed segments by ID.
+func (r ResponseSegmentList) Names() map[int64]string {
+	m := make(map[int64]string, len(r.Segments))
+
+	for _, s := range r.Segments {
+		m[s.ID.Int64()] = s.Name
+	}
+
+	return m
+}
+
+// List lists segments.
+func (s *SegmentAPI) List(ctx context.Context, limit int, offset int) (response ResponseSegmentList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/segments", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("segment list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "segment list", "segments")
+
+	return response, err
+}
+
+// Read reads a segment.
+func (s *SegmentAPI) Read(ctx context.Context, id int64) (response ResponseSegmentRead, err error) {
+	r, body, err := s.client.get(ctx, fmt.Sprintf("/api/3/segments/%d", id))
+	if err != nil {
+		return response, fmt.Errorf("segment read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "segment read", fmt.Sprintf("segment %d", id))
+
+	return response, err
+}
+
+// ListContacts lists a page of the contacts in a segment.
+func (s *SegmentAPI) ListContacts(ctx context.Context, id int64, limit int, offset int) (response ResponseContactList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("segmentid", strconv.FormatInt(id, 10))
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/contacts", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("segment contact list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "segment contact list", fmt.Sprintf("segment %d", id))
+
+	return response, err
+}
+
+// Contacts returns an iterator over the contacts in a segment reading pageSize contacts per request
+// (DEFAULT_SEGMENT_PAGE_SIZE if 0).
+//
+//	it := c.Segments.Contacts(ctx, id, 0)
+//	for it.Next() {
+//		log.Println(it.Contact().EmailAddress)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+func (s *SegmentAPI) Contacts(ctx context.Context, id int64, pageSize int) *SegmentContactIterator {
+	if pageSize <= 0 {
+		pageSize = DEFAULT_SEGMENT_PAGE_SIZE
+	}
+
+	return &SegmentContactIterator{api: s, ctx: ctx, id: id, limit: pageSize, index: -1}
+}
+
+// SegmentContactIterator pages through the contacts in a segment (see SegmentAPI.Contacts).  Contacts added to the
+// segment while iterating may be skipped or returned twice.
+type SegmentContactIterator struct {
+	api    *SegmentAPI
+	ctx    context.Context
+	id     int64
+	limit  int
+	offset int
+	total  int64
+	page   []Contact
+	index  int
+	done   bool
+	err    error
+}
+
+// Next moves to the next contact, reading the next page when needed.  Returns false when there are no more contacts
+// or an error occurred (see Err).
+func (it *SegmentContactIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.index+1 < len(it.page) {
+		it.index++
+		return true
+	}
+
+	if it.done {
+		return false
+	}
+
+	response, err := it.api.ListContacts(it.ctx, it.id, it.limit, it.offset)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page, it.index, it.total = response.Contacts, 0, response.Meta.Total.Int64()
+	it.offset += it.limit
+	if len(it.page) < it.limit || int64(it.offset) >= it.total {
+		it.done = true
+	}
+
+	return len(it.page) > 0
+}
+
+// Contact returns the current contact.
+func (it *SegmentContactIterator) Contact() Contact {
+	if it.index < 0 || it.index >= len(it.page) {
+		return Contact{}
+	}
+
+	return it.page[it.index]
+}
+
+// Total returns the number of contacts in the segment as of the last page read.
+func (it *SegmentContactIterator) Total() int64 {
+	return it.total
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *SegmentContactIterator) Err() error {
+	return it.err
+}
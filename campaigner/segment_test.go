@@ -0,0 +1,118 @@
+package campaigner
+
+import (
+	"fmt"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// Tests all segment functionality as a group.  These tests run against a local test server.
+func TestSegmentSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestSegment_SuccessRead)
+	runTestWithPackagePath(t, TestSegment_SuccessContacts)
+	runTestWithPackagePath(t, TestSegment_SuccessContactsPages)
+	runTestWithPackagePath(t, TestSegment_FailureContacts)
+}
+
+func TestSegment_SuccessRead(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	id := s.AddSegment(actest.Segment{Name: "Trial users", Logic: "or"})
+	s.AddSegment(actest.Segment{Name: "Churned", Hidden: true})
+
+	read, err := c.Segments.Read(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, "Trial users", read.Segment.Name)
+	assert.Equal(t, "or", read.Segment.Logic)
+	assert.False(t, read.Segment.Hidden.Bool())
+
+	list, err := c.Segments.List(ctx, 20, 0)
+	require.Nil(t, err)
+	assert.Equal(t, map[int64]string{1: "Trial users", 2: "Churned"}, list.Names())
+
+	_, err = c.Segments.Read(ctx, 99)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestSegment_SuccessContacts(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	id := s.AddSegment(actest.Segment{Name: "Customers", Match: func(c actest.Contact) bool { return strings.HasPrefix(c.EmailAddress, "customer") }})
+
+	for x := 0; x < 12; x++ {
+		s.AddContact(actest.Contact{EmailAddress: fmt.Sprintf("customer%d@example.com", x)})
+		s.AddContact(actest.Contact{EmailAddress: fmt.Sprintf("lead%d@example.com", x)})
+	}
+
+	page, err := c.Segments.ListContacts(ctx, id, 5, 10)
+	require.Nil(t, err)
+	assert.Len(t, page.Contacts, 2)
+	assert.Equal(t, int64(12), page.Meta.Total.Int64())
+	assert.Equal(t, id, page.Meta.PageInput.Segmentid.Int64())
+
+	empty := s.AddSegment(actest.Segment{Name: "Nobody"})
+	it := c.Segments.Contacts(ctx, empty, 0)
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Err())
+	assert.Equal(t, Contact{}, it.Contact())
+}
+
+// The iterator returns every contact once across page boundaries and stops after the last page without reading an
+// empty one, whether the last page is short or full.
+func TestSegment_SuccessContactsPages(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	var (
+		id    = s.AddSegment(actest.Segment{Name: "Everyone", Match: func(actest.Contact) bool { return true }})
+		want  []string
+		reads int
+	)
+	c.Middleware = []Middleware{func(next APIHandler) APIHandler {
+		return func(request *APIRequest) (*APIResponse, error) {
+			reads++
+			return next(request)
+		}
+	}}
+
+	for x := 0; x < 12; x++ {
+		want = append(want, fmt.Sprintf("contact%d@example.com", x))
+		s.AddContact(actest.Contact{EmailAddress: want[x]})
+	}
+
+	for _, tc := range []struct {
+		pageSize int
+		reads    int
+	}{
+		{5, 3},  // Short final page.
+		{6, 2},  // Even pages.
+		{12, 1}, // One full page.
+		{20, 1}, // One short page.
+	} {
+		var emails []string
+		reads = 0
+		it := c.Segments.Contacts(ctx, id, tc.pageSize)
+		for it.Next() {
+			emails = append(emails, it.Contact().EmailAddress)
+		}
+		require.Nil(t, it.Err())
+		assert.Equal(t, want, emails, "page size %d", tc.pageSize)
+		assert.Equal(t, tc.reads, reads, "page size %d", tc.pageSize)
+		assert.Equal(t, int64(12), it.Total())
+
+		// Exhausted iterators stay exhausted.
+		assert.False(t, it.Next())
+		assert.Equal(t, tc.reads, reads)
+	}
+}
+
+func TestSegment_FailureContacts(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	it := c.Segments.Contacts(ctx, 99, 0)
+	assert.False(t, it.Next())
+	assert.IsType(t, CustomErrorNotFound{}, it.Err())
+	assert.False(t, it.Next())
+}
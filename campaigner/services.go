@@ -0,0 +1,182 @@
+package campaigner
+
+// ContactAPI groups the contact endpoints (see Campaigner.Contacts).
+type ContactAPI struct {
+	client *Campaigner
+}
+
+// TagAPI groups the tag endpoints (see Campaigner.Tags).
+type TagAPI struct {
+	client *Campaigner
+}
+
+// ListAPI groups the contact list endpoints (see Campaigner.Lists).
+type ListAPI struct {
+	client *Campaigner
+}
+
+// FieldAPI groups the custom field endpoints (see Campaigner.Fields).
+type FieldAPI struct {
+	client *Campaigner
+}
+
+// OrganizationAPI groups the organization endpoints (see Campaigner.Organizations).
+type OrganizationAPI struct {
+	client *Campaigner
+}
+
+// BulkImportAPI groups the bulk contact import endpoints (see Campaigner.BulkImports).
+type BulkImportAPI struct {
+	client *Campaigner
+}
+
+// UserAPI groups the user endpoints (see Campaigner.Users).
+type UserAPI struct {
+	client *Campaigner
+}
+
+// GroupAPI groups the user group (permission) endpoints (see Campaigner.Groups).
+type GroupAPI struct {
+	client *Campaigner
+}
+
+// CustomObjectAPI groups the custom object schema and record endpoints (see Campaigner.CustomObjects).
+type CustomObjectAPI struct {
+	client *Campaigner
+}
+
+// EcommerceAPI groups the e-commerce (deep data) connection, customer and order endpoints (see Campaigner.Ecommerce).
+type EcommerceAPI struct {
+	client *Campaigner
+}
+
+// SiteTrackingAPI groups the site tracking settings, whitelisted domain and tracking log endpoints (see
+// Campaigner.SiteTracking).
+type SiteTrackingAPI struct {
+	client *Campaigner
+}
+
+// SegmentAPI groups the segment endpoints (see Campaigner.Segments).
+type SegmentAPI struct {
+	client *Campaigner
+}
+
+// FormAPI groups the form endpoints and form submissions (see Campaigner.Forms).
+type FormAPI struct {
+	client *Campaigner
+}
+
+// HistoryAPI groups the contact history endpoints: bounce logs, email activities and the activity feed (see
+// Campaigner.History).
+type HistoryAPI struct {
+	client *Campaigner
+}
+
+// ScoreAPI groups the contact and deal scoring endpoints (see Campaigner.Scores).
+type ScoreAPI struct {
+	client *Campaigner
+}
+
+// New returns a client with its services set up.
+func New(apiToken string, baseURL string) *Campaigner {
+	c := &Campaigner{APIToken: apiToken, BaseURL: baseURL}
+
+	return c.Init()
+}
+
+// Init sets up the services of a client built as a struct literal and returns it.  Services send requests through the
+// client they were set up for, so Init must be called again on copies.
+func (c *Campaigner) Init() *Campaigner {
+	c.Contacts = c.contactAPI()
+	c.Tags = c.tagAPI()
+	c.Lists = c.listAPI()
+	c.Fields = c.fieldAPI()
+	c.Organizations = c.organizationAPI()
+	c.BulkImports = c.bulkImportAPI()
+	c.Users = c.userAPI()
+	c.Groups = c.groupAPI()
+	c.CustomObjects = c.customObjectAPI()
+	c.Ecommerce = c.ecommerceAPI()
+	c.SiteTracking = c.siteTrackingAPI()
+	c.Segments = c.segmentAPI()
+	c.Forms = c.formAPI()
+	c.History = c.historyAPI()
+	c.Scores = c.scoreAPI()
+
+	return c
+}
+
+// Returns the contact service of a client (whether or not the client was set up with Init).
+func (c *Campaigner) contactAPI() *ContactAPI {
+	return &ContactAPI{client: c}
+}
+
+// Returns the tag service of a client.
+func (c *Campaigner) tagAPI() *TagAPI {
+	return &TagAPI{client: c}
+}
+
+// Returns the list service of a client.
+func (c *Campaigner) listAPI() *ListAPI {
+	return &ListAPI{client: c}
+}
+
+// Returns the field service of a client.
+func (c *Campaigner) fieldAPI() *FieldAPI {
+	return &FieldAPI{client: c}
+}
+
+// Returns the organization service of a client.
+func (c *Campaigner) organizationAPI() *OrganizationAPI {
+	return &OrganizationAPI{client: c}
+}
+
+// Returns the bulk import service of a client.
+func (c *Campaigner) bulkImportAPI() *BulkImportAPI {
+	return &BulkImportAPI{client: c}
+}
+
+// Returns the user service of a client.
+func (c *Campaigner) userAPI() *UserAPI {
+	return &UserAPI{client: c}
+}
+
+// Returns the group service of a client.
+func (c *Campaigner) groupAPI() *GroupAPI {
+	return &GroupAPI{client: c}
+}
+
+// Returns the custom object service of a client.
+func (c *Campaigner) customObjectAPI() *CustomObjectAPI {
+	return &CustomObjectAPI{client: c}
+}
+
+// Returns the e-commerce service of a client.
+func (c *Campaigner) ecommerceAPI() *EcommerceAPI {
+	return &EcommerceAPI{client: c}
+}
+
+// Returns the site tracking service of a client.
+func (c *Campaigner) siteTrackingAPI() *SiteTrackingAPI {
+	return &SiteTrackingAPI{client: c}
+}
+
+// Returns the segment service of a client.
+func (c *Campaigner) segmentAPI() *SegmentAPI {
+	return &SegmentAPI{client: c}
+}
+
+// Returns the form service of a client.
+func (c *Campaigner) formAPI() *FormAPI {
+	return &FormAPI{client: c}
+}
+
+// Returns the history service of a client.
+func (c *Campaigner) historyAPI() *HistoryAPI {
+	return &HistoryAPI{client: c}
+}
+
+// Returns the score service of a client.
+func (c *Campaigner) scoreAPI() *ScoreAPI {
+	return &ScoreAPI{client: c}
+}
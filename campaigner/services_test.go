@@ -0,0 +1,117 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Tests all service functionality as a group.  These tests run against a local test server.
+func TestServicesSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestServices_Success)
+	runTestWithPackagePath(t, TestServices_SuccessAllPages)
+	runTestWithPackagePath(t, TestServices_FailureContextCanceled)
+	runTestWithPackagePath(t, TestRateLimiter_WaitContext)
+}
+
+func TestServices_Success(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	var (
+		ctx    = context.Background()
+		c      = New(s.APIToken, s.URL)
+		listID = s.AddList(actest.List{Name: "Newsletter"})
+	)
+
+	tag, err := c.Tags.Create(ctx, Tag{Name: "Customer", Description: "Customer", Type: "contact"})
+	require.Nil(t, err)
+
+	contact, err := c.Contacts.Create(ctx, Contact{EmailAddress: "services@example.com"})
+	require.Nil(t, err)
+
+	_, err = c.Contacts.AddTag(ctx, RequestContactTagCreate{ContactID: contact.Contact.ID.Int64(), TagID: tag.Tag.ID.Int64()})
+	require.Nil(t, err)
+
+	tags, err := c.Contacts.Tags(ctx, contact.Contact.ID.Int64())
+	require.Nil(t, err)
+	require.Len(t, tags.ContactTags, 1)
+	assert.Equal(t, tag.Tag.ID, tags.ContactTags[0].TagID)
+
+	r, err := c.Lists.AddContact(ctx, listID, contact.Contact.ID.Int64())
+	require.Nil(t, err)
+	assert.Equal(t, "Newsletter", r.Custom.ListName)
+
+	// Flat methods and services share the client.
+	found, err := c.ContactFind("services@example.com")
+	require.Nil(t, err)
+	require.Len(t, found.Contacts, 1)
+
+	// Struct literals need Init.
+	literal := Campaigner{APIToken: s.APIToken, BaseURL: s.URL}
+	literal.Init()
+	_, err = literal.Contacts.Read(ctx, contact.Contact.ID.Int64())
+	assert.Nil(t, err)
+}
+
+// Fields and lists are read in pages of MAX_LIST_LIMIT.
+func TestServices_SuccessAllPages(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	var (
+		ctx = context.Background()
+		c   = New(s.APIToken, s.URL)
+	)
+
+	for x := 0; x < MAX_LIST_LIMIT*2+1; x++ {
+		s.AddField(actest.Field{Title: fmt.Sprintf("Field %d", x)})
+	}
+	for x := 0; x < MAX_LIST_LIMIT; x++ {
+		s.AddList(actest.List{Name: fmt.Sprintf("List %d", x)})
+	}
+
+	fields, err := c.Fields.List(ctx)
+	require.Nil(t, err)
+	require.Len(t, fields.Fields, MAX_LIST_LIMIT*2+1)
+	assert.Equal(t, "Field 200", fields.Fields[MAX_LIST_LIMIT*2].Title)
+
+	lists, err := c.Lists.List(ctx)
+	require.Nil(t, err)
+	assert.Len(t, lists.Lists, MAX_LIST_LIMIT)
+}
+
+func TestServices_FailureContextCanceled(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer s.Close()
+
+	c := New("token", s.URL)
+	c.MaxRetries = 3
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Tags.Read(ctx, 1)
+	assert.NotNil(t, err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
+
+func TestRateLimiter_WaitContext(t *testing.T) {
+	l := NewRateLimiter(1)
+	require.Nil(t, l.WaitContext(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, l.WaitContext(ctx))
+}
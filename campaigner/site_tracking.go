@@ -0,0 +1,151 @@
+package campaigner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SiteTrackingDomain holds a JSON compatible whitelisted site tracking domain.  Pages are only tracked on whitelisted
+// domains.
+type SiteTrackingDomain struct {
+	Name  string            `json:"name"`
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// TrackingLog holds a JSON compatible tracked page visit of a contact.
+type TrackingLog struct {
+	ID        Int64json         `json:"id"`
+	ContactID Int64json         `json:"contact"`
+	Type      string            `json:"type"`
+	Value     string            `json:"value"`
+	Hash      string            `json:"hash"`
+	Tstamp    ACTime            `json:"tstamp"`
+	Links     map[string]string `json:"links"`
+}
+
+// ResponseSiteTracking holds a JSON compatible response for reading and updating the site tracking status.
+type ResponseSiteTracking struct {
+	SiteTracking struct {
+		Enabled bool `json:"enabled"`
+	} `json:"siteTracking"`
+}
+
+// ResponseSiteTrackingDomain holds a JSON compatible response for adding a whitelisted domain.
+type ResponseSiteTrackingDomain struct {
+	Domain SiteTrackingDomain `json:"siteTrackingDomain"`
+}
+
+// ResponseSiteTrackingDomainList holds a JSON compatible response for listing whitelisted domains.
+type ResponseSiteTrackingDomainList struct {
+	Domains []SiteTrackingDomain `json:"siteTrackingDomains"`
+	Meta    struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// ResponseTrackingLogList holds a JSON compatible response for listing tracking logs.
+type ResponseTrackingLogList struct {
+	TrackingLogs []TrackingLog `json:"trackingLogs"`
+	Meta         struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// Names returns the names of the listed domains.
+func (r ResponseSiteTrackingDomainList) Names() []string {
+	l := make([]string, 0, len(r.Domains))
+	for _, d := range r.Domains {
+		l = append(l, d.Name)
+	}
+
+	return l
+}
+
+// Enabled reads whether site tracking is enabled for the account.
+func (s *SiteTrackingAPI) Enabled(ctx context.Context) (bool, error) {
+	var response ResponseSiteTracking
+
+	r, body, err := s.client.get(ctx, "/api/3/siteTracking")
+	if err != nil {
+		return false, fmt.Errorf("site tracking read failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "site tracking read", "site tracking")
+
+	return response.SiteTracking.Enabled, err
+}
+
+// SetEnabled enables or disables site tracking for the account.
+func (s *SiteTrackingAPI) SetEnabled(ctx context.Context, enabled bool) error {
+	data := map[string]interface{}{"siteTracking": map[string]interface{}{"enabled": enabled}}
+
+	r, body, err := s.client.put(ctx, "/api/3/siteTracking", data)
+	if err != nil {
+		return fmt.Errorf("site tracking update failed, HTTP error: %s", err)
+	}
+
+	return s.client.result(r, body, nil, "site tracking update", "site tracking")
+}
+
+// ListDomains lists the whitelisted domains.
+func (s *SiteTrackingAPI) ListDomains(ctx context.Context, limit int, offset int) (response ResponseSiteTrackingDomainList, err error) {
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/siteTrackingDomains", RawQuery: qs.Encode()}
+
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("site tracking domain list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "site tracking domain list", "site tracking domains")
+
+	return response, err
+}
+
+// AddDomain whitelists a domain, e.g. "example.com".
+func (s *SiteTrackingAPI) AddDomain(ctx context.Context, name string) (response ResponseSiteTrackingDomain, err error) {
+	if len(strings.TrimSpace(name)) == 0 {
+		return response, fmt.Errorf("site tracking domain creation failed, name is empty")
+	}
+
+	r, body, err := s.client.post(ctx, "/api/3/siteTrackingDomains", map[string]interface{}{"siteTrackingDomain": SiteTrackingDomain{Name: name}})
+	if err != nil {
+		return response, fmt.Errorf("site tracking domain creation failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "site tracking domain creation", fmt.Sprintf("domain %s", name))
+
+	return response, err
+}
+
+// RemoveDomain removes a domain from the whitelist.
+func (s *SiteTrackingAPI) RemoveDomain(ctx context.Context, name string) error {
+	r, body, err := s.client.delete(ctx, "/api/3/siteTrackingDomains/"+url.PathEscape(name))
+	if err != nil {
+		return fmt.Errorf("site tracking domain delete failed, HTTP error: %s", err)
+	}
+
+	return s.client.result(r, body, nil, "site tracking domain delete", fmt.Sprintf("domain %s", name))
+}
+
+// ContactLogs lists the tracked page visits of a contact.
+func (s *SiteTrackingAPI) ContactLogs(ctx context.Context, contactID int64) (ResponseTrackingLogList, error) {
+	return s.Logs(ctx, fmt.Sprintf("/api/3/contacts/%d/trackingLogs", contactID))
+}
+
+// Logs lists the tracked page visits a link points to, e.g. Contact.Links.TrackingLogs.
+func (s *SiteTrackingAPI) Logs(ctx context.Context, link string) (response ResponseTrackingLogList, err error) {
+	r, body, err := s.client.get(ctx, s.client.linkPath(link))
+	if err != nil {
+		return response, fmt.Errorf("tracking log list failed, HTTP error: %s", err)
+	}
+
+	err = s.client.result(r, body, &response, "tracking log list", "contact")
+
+	return response, err
+}
@@ -0,0 +1,105 @@
+package campaigner
+
+import (
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// Tests all site tracking functionality as a group.  These tests run against a local test server.
+func TestSiteTrackingSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestSiteTracking_SuccessSettings)
+	runTestWithPackagePath(t, TestSiteTracking_SuccessDomains)
+	runTestWithPackagePath(t, TestSiteTracking_FailureDomains)
+	runTestWithPackagePath(t, TestSiteTracking_SuccessLogs)
+	runTestWithPackagePath(t, TestSiteTracking_LinkPath)
+}
+
+func TestSiteTracking_SuccessSettings(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	enabled, err := c.SiteTracking.Enabled(ctx)
+	require.Nil(t, err)
+	assert.False(t, enabled)
+
+	require.Nil(t, c.SiteTracking.SetEnabled(ctx, true))
+	enabled, err = c.SiteTracking.Enabled(ctx)
+	require.Nil(t, err)
+	assert.True(t, enabled)
+}
+
+func TestSiteTracking_SuccessDomains(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	for _, d := range []string{"example.com", "shop.example.org", "Example.net"} {
+		_, err := c.SiteTracking.AddDomain(ctx, d)
+		require.Nil(t, err)
+	}
+
+	list, err := c.SiteTracking.ListDomains(ctx, 20, 0)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.com", "shop.example.org", "example.net"}, list.Names())
+	assert.Equal(t, int64(3), list.Meta.Total.Int64())
+
+	require.Nil(t, c.SiteTracking.RemoveDomain(ctx, "shop.example.org"))
+	list, err = c.SiteTracking.ListDomains(ctx, 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.net"}, list.Names())
+}
+
+func TestSiteTracking_FailureDomains(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	_, err := c.SiteTracking.AddDomain(ctx, "example.com")
+	require.Nil(t, err)
+
+	for _, d := range []string{"example.com", "localhost", "https://example.org"} {
+		_, err = c.SiteTracking.AddDomain(ctx, d)
+		assert.IsType(t, ActiveCampaignError{}, err, d)
+	}
+
+	_, err = c.SiteTracking.AddDomain(ctx, "")
+	assert.NotNil(t, err)
+	assert.IsType(t, CustomErrorNotFound{}, c.SiteTracking.RemoveDomain(ctx, "example.org"))
+}
+
+func TestSiteTracking_SuccessLogs(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	contact := s.AddContact(actest.Contact{EmailAddress: "visitor@example.com"})
+
+	// Nothing is tracked before the domain is whitelisted.
+	require.Nil(t, c.SiteTracking.SetEnabled(ctx, true))
+	assert.Zero(t, s.TrackVisit(contact, "https://www.example.com/pricing"))
+
+	_, err := c.SiteTracking.AddDomain(ctx, "example.com")
+	require.Nil(t, err)
+	assert.NotZero(t, s.TrackVisit(contact, "https://www.example.com/pricing"))
+	assert.NotZero(t, s.TrackVisit(contact, "https://example.com/signup"))
+	assert.Zero(t, s.TrackVisit(contact, "https://example.org/"))
+
+	read, err := c.Contacts.Read(ctx, contact)
+	require.Nil(t, err)
+
+	logs, err := c.SiteTracking.Logs(ctx, read.Contact.Links.TrackingLogs)
+	require.Nil(t, err)
+	require.Len(t, logs.TrackingLogs, 2)
+	assert.Equal(t, "https://www.example.com/pricing", logs.TrackingLogs[0].Value)
+	assert.Equal(t, contact, logs.TrackingLogs[1].ContactID.Int64())
+
+	logs, err = c.SiteTracking.ContactLogs(ctx, contact)
+	require.Nil(t, err)
+	assert.Len(t, logs.TrackingLogs, 2)
+
+	_, err = c.SiteTracking.ContactLogs(ctx, 99)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestSiteTracking_LinkPath(t *testing.T) {
+	c := New("token", "https://account.api-us1.com/")
+
+	assert.Equal(t, "/api/3/contacts/1/trackingLogs", c.linkPath("https://account.api-us1.com/api/3/contacts/1/trackingLogs"))
+	assert.Equal(t, "/api/3/contacts/1/trackingLogs?limit=5", c.linkPath("https://other.example.com/api/3/contacts/1/trackingLogs?limit=5"))
+	assert.Equal(t, "/api/3/contacts/1/trackingLogs", c.linkPath("/api/3/contacts/1/trackingLogs"))
+}
@@ -0,0 +1,315 @@
+package campaigner
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Schema drift kinds (see Drift).
+const (
+	DRIFT_UNKNOWN_FIELD = "unknown_field"
+	DRIFT_TYPE_MISMATCH = "type_mismatch"
+)
+
+// Drift describes a difference between a response and the model it was decoded into, reported in strict mode (see
+// Campaigner.Strict).  Paths use the JSON names, array elements are shown as [] and map values as *, e.g.
+// "contacts[].links.contactGoals".
+type Drift struct {
+	Method   string // HTTP method, e.g. GET.
+	Endpoint string // Endpoint template, e.g. /api/3/contacts/{id}.
+	Path     string // JSON path of the value.
+	Kind     string // DRIFT_UNKNOWN_FIELD or DRIFT_TYPE_MISMATCH.
+	Observed string // JSON type of the value: string, number, bool, null, object or array.
+	Expected string // Go type of the model field, empty for unknown fields.
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	idOrRecordType      = reflect.TypeOf((*idOrRecord)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Decodes a response body into v and moves timestamps sent without an offset into the client's time zone.  In strict
+// mode the body is compared with v first: unknown fields and values that don't fit their field are reported as drift,
+// and mismatched values are skipped instead of failing the call.
+//
+// Cached responses carry no request and are not checked again.
+func (c *Campaigner) decode(r *http.Response, body []byte, v interface{}) error {
+	if err := c.unmarshal(r, body, v); err != nil {
+		return err
+	}
+
+	localizeACTimes(reflect.ValueOf(v), c.Location)
+	return nil
+}
+
+// Decodes a response body into v, checking it first in strict mode (see decode).
+func (c *Campaigner) unmarshal(r *http.Response, body []byte, v interface{}) error {
+	if !c.Strict {
+		return json.Unmarshal(body, v)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+
+	var raw interface{}
+	if err := d.Decode(&raw); err != nil {
+		return err
+	}
+
+	var method, endpoint string
+	if r != nil && r.Request != nil {
+		method, endpoint = r.Request.Method, EndpointTemplate(r.Request.URL.Path)
+	}
+
+	w := driftWalker{seen: map[string]bool{}}
+	cleaned := w.walk("", raw, reflect.TypeOf(v).Elem(), "")
+	for _, drift := range w.drifts {
+		drift.Method, drift.Endpoint = method, endpoint
+		c.reportDrift(drift)
+	}
+
+	if !w.mismatched {
+		return json.Unmarshal(body, v)
+	}
+
+	b, err := json.Marshal(cleaned)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// Calls the drift hook, or logs a warning if no hook is set.
+func (c *Campaigner) reportDrift(d Drift) {
+	if c.OnDrift != nil {
+		c.OnDrift(d)
+		return
+	}
+
+	if c.Logger != nil {
+		c.Logger.Warn("API schema drift", "method", d.Method, "endpoint", d.Endpoint, "path", d.Path, "kind", d.Kind, "observed", d.Observed, "expected", d.Expected)
+	}
+}
+
+// Walks a decoded JSON value alongside the Go type it will be loaded into, collecting drift.
+type driftWalker struct {
+	drifts     []Drift
+	seen       map[string]bool
+	mismatched bool
+}
+
+// Checks v against t and returns v with mismatched values removed.  opts holds the options of the struct tag the value
+// belongs to (e.g. ",string").
+func (w *driftWalker) walk(path string, v interface{}, t reflect.Type, opts string) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Types that load themselves, and leaves.  Full records of types that are also sent as IDs are checked as structs.
+	_, object := v.(map[string]interface{})
+	record := object && t.Implements(idOrRecordType)
+	if t.Kind() == reflect.Interface || (implementsUnmarshaler(t) && !record) || !isContainer(t) {
+		if !fits(v, t, opts) {
+			w.add(Drift{Path: path, Kind: DRIFT_TYPE_MISMATCH, Observed: jsonKind(v), Expected: t.String()})
+			return nil
+		}
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		fields := jsonFields(t)
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			f, ok := fields[k]
+			if !ok {
+				f, ok = fields[strings.ToLower(k)]
+			}
+			if !ok {
+				w.add(Drift{Path: joinPath(path, k), Kind: DRIFT_UNKNOWN_FIELD, Observed: jsonKind(o[k])})
+				continue
+			}
+
+			if c := w.walk(joinPath(path, k), o[k], f.Type, f.opts); c == nil && o[k] != nil {
+				delete(o, k)
+			}
+		}
+		return o
+
+	case reflect.Slice, reflect.Array:
+		a, ok := v.([]interface{})
+		if !ok {
+			break
+		}
+
+		for x := range a {
+			a[x] = w.walk(path+"[]", a[x], t.Elem(), "")
+		}
+		return a
+
+	case reflect.Map:
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		for k := range o {
+			if c := w.walk(path+".*", o[k], t.Elem(), ""); c == nil && o[k] != nil {
+				delete(o, k)
+			}
+		}
+		return o
+	}
+
+	w.add(Drift{Path: path, Kind: DRIFT_TYPE_MISMATCH, Observed: jsonKind(v), Expected: t.String()})
+	return nil
+}
+
+// Records a drift once per path.
+func (w *driftWalker) add(d Drift) {
+	if d.Kind == DRIFT_TYPE_MISMATCH {
+		w.mismatched = true
+	}
+
+	key := d.Kind + " " + d.Path
+	if w.seen[key] {
+		return
+	}
+
+	w.seen[key] = true
+	w.drifts = append(w.drifts, d)
+}
+
+// A struct field as seen by encoding/json.
+type jsonField struct {
+	Type reflect.Type
+	opts string
+}
+
+// Returns the fields of a struct by JSON name, including the lower case name for case insensitive matching.  Fields
+// of embedded structs are promoted as encoding/json does.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	m := map[string]jsonField{}
+
+	for x := 0; x < t.NumField(); x++ {
+		f := t.Field(x)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i:]
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && len(name) == 0 && ft.Kind() == reflect.Struct && !implementsUnmarshaler(ft) {
+			for k, v := range jsonFields(ft) {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			continue
+		}
+
+		if len(f.PkgPath) > 0 {
+			continue // Unexported.
+		}
+
+		if len(name) == 0 {
+			name = f.Name
+		}
+
+		m[name] = jsonField{Type: f.Type, opts: opts}
+		if _, ok := m[strings.ToLower(name)]; !ok {
+			m[strings.ToLower(name)] = jsonField{Type: f.Type, opts: opts}
+		}
+	}
+
+	return m
+}
+
+// Returns true if a value can be loaded into t.
+func fits(v interface{}, t reflect.Type, opts string) bool {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+
+	if strings.Contains(opts, ",string") {
+		s := reflect.StructOf([]reflect.StructField{{Name: "V", Type: t, Tag: reflect.StructTag(`json:"v` + opts + `"`)}})
+		return json.Unmarshal(append(append([]byte(`{"v":`), b...), '}'), reflect.New(s).Interface()) == nil
+	}
+
+	return json.Unmarshal(b, reflect.New(t).Interface()) == nil
+}
+
+// Returns true if t loads itself from JSON.
+func implementsUnmarshaler(t reflect.Type) bool {
+	p := reflect.PtrTo(t)
+	return p.Implements(jsonUnmarshalerType) || p.Implements(textUnmarshalerType)
+}
+
+// Returns true for types that hold other JSON values.
+func isContainer(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return !(t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8) // []byte is a base64 string.
+	}
+
+	return false
+}
+
+// Returns the JSON type of a decoded value.
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// Appends a key to a JSON path.
+func joinPath(path string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+
+	return path + "." + key
+}
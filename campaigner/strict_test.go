@@ -0,0 +1,149 @@
+package campaigner
+
+import (
+	"context"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+// Tests all strict mode functionality as a group.  These tests run against a local test server.
+func TestStrictSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestStrict_SuccessNoDrift)
+	runTestWithPackagePath(t, TestStrict_UnknownField)
+	runTestWithPackagePath(t, TestStrict_TypeMismatch)
+	runTestWithPackagePath(t, TestStrict_IDOrRecord)
+	runTestWithPackagePath(t, TestStrict_LoggerFallback)
+}
+
+// The models should cover everything the test server sends.
+func TestStrict_SuccessNoDrift(t *testing.T) {
+	s := actest.NewServer()
+	defer s.Close()
+
+	var drifts []Drift
+	c := New(s.APIToken, s.URL)
+	c.Strict = true
+	c.OnDrift = func(d Drift) { drifts = append(drifts, d) }
+
+	var (
+		ctx    = context.Background()
+		listID = s.AddList(actest.List{Name: "Newsletter"})
+		field  = s.AddField(actest.Field{Title: "Size", PersTag: "SIZE"})
+	)
+
+	tag, err := c.Tags.Create(ctx, Tag{Name: "Customer", Description: "Customer", Type: "contact"})
+	require.Nil(t, err)
+	_, err = c.Tags.List(ctx)
+	require.Nil(t, err)
+
+	contact, err := c.Contacts.Create(ctx, Contact{EmailAddress: "strict@example.com"})
+	require.Nil(t, err)
+	id := contact.Contact.ID.Int64()
+
+	_, err = c.Contacts.AddTag(ctx, RequestContactTagCreate{ContactID: id, TagID: tag.Tag.ID.Int64()})
+	require.Nil(t, err)
+	_, err = c.Contacts.List(ctx, 20, 0)
+	require.Nil(t, err)
+	_, err = c.Lists.AddContact(ctx, listID, id)
+	require.Nil(t, err)
+	_, err = c.Contacts.UpdateField(ctx, id, field, "large")
+	require.Nil(t, err)
+	_, err = c.Contacts.Read(ctx, id)
+	require.Nil(t, err)
+
+	org, err := c.Organizations.Create(ctx, Organization{Name: "Strict Pizza"})
+	require.Nil(t, err)
+	_, err = c.Organizations.Update(ctx, org.Organization.ID.Int64(), RequestOrganizationUpdate{Name: "Strict Pasta"})
+	require.Nil(t, err)
+	_, err = c.Organizations.List(ctx, 20, 0)
+	require.Nil(t, err)
+
+	assert.Empty(t, drifts)
+}
+
+func TestStrict_UnknownField(t *testing.T) {
+	var drifts []Drift
+	c := strictReplayClient("/api/3/tags/1", `{"tag":{"id":"1","tag":"Customer","color":"red","links":{"contactGoalTags":"x","contactTags":"y"}}}`, &drifts)
+
+	r, err := c.TagRead(1)
+	require.Nil(t, err)
+	assert.Equal(t, "Customer", r.Tag.Name)
+
+	require.Len(t, drifts, 2)
+	assert.Equal(t, Drift{Method: http.MethodGet, Endpoint: "/api/3/tags/{id}", Path: "tag.color", Kind: DRIFT_UNKNOWN_FIELD, Observed: "string"}, drifts[0])
+	assert.Equal(t, "tag.links.contactTags", drifts[1].Path)
+}
+
+func TestStrict_TypeMismatch(t *testing.T) {
+	body := `{"tags":[{"id":"1","tag":"A","cdate":{"date":"2019"}},{"id":"2","tag":"B","cdate":["x"]},{"id":"3","tag":"C","cdate":"2019-01-02"}],"meta":{"total":3}}`
+
+	// Not strict: the call fails.
+	c := &Campaigner{APIToken: "token", BaseURL: "http://127.0.0.1:1", HTTPClient: &http.Client{Transport: replay(http.MethodGet, "/api/3/tags?limit=100", body)}}
+	_, err := c.TagList()
+	assert.NotNil(t, err)
+
+	// Strict: the value is skipped and reported once.
+	var drifts []Drift
+	c = strictReplayClient("/api/3/tags?limit=100", body, &drifts)
+	r, err := c.TagList()
+	require.Nil(t, err)
+	require.Len(t, r.Tags, 3)
+	assert.True(t, r.Tags[0].CreationDate.IsZero())
+	assert.Equal(t, "B", r.Tags[1].Name)
+	assert.False(t, r.Tags[2].CreationDate.IsZero())
+
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "tags[].cdate", drifts[0].Path)
+	assert.Equal(t, DRIFT_TYPE_MISMATCH, drifts[0].Kind)
+	assert.Equal(t, "object", drifts[0].Observed)
+	assert.Equal(t, "campaigner.ACTime", drifts[0].Expected)
+}
+
+// Records referenced by ID on the contact are fine, side-loaded records are still checked field by field.
+func TestStrict_IDOrRecord(t *testing.T) {
+	var drifts []Drift
+	body := `{"contact":{"id":"1","contactLists":["6"],"deals":[4]},"contactLists":[{"id":"6","list":"3","color":"red"}]}`
+	c := strictReplayClient("/api/3/contacts/1", body, &drifts)
+
+	r, err := c.contactAPI().Read(context.Background(), 1)
+	require.Nil(t, err)
+	assert.Equal(t, Int64json(6), r.Contact.ContactLists[0].ID)
+	assert.Equal(t, Int64json(4), r.Contact.Deals[0].ID)
+	assert.Equal(t, Int64json(3), r.ContactLists[0].List)
+
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "contactLists[].color", drifts[0].Path)
+	assert.Equal(t, DRIFT_UNKNOWN_FIELD, drifts[0].Kind)
+}
+
+func TestStrict_LoggerFallback(t *testing.T) {
+	var l testLogger
+	c := strictReplayClient("/api/3/tags/1", `{"tag":{"id":"1","tag":"Customer","color":"red"}}`, nil)
+	c.Logger = &l
+
+	_, err := c.TagRead(1)
+	require.Nil(t, err)
+	require.Len(t, l.lines, 3) // Request, response and drift.
+	assert.Contains(t, l.lines[2], "WARN API schema drift")
+	assert.Contains(t, l.lines[2], "path=tag.color")
+}
+
+// Returns a strict client that replays one GET response.  Drift is collected in drifts.
+func strictReplayClient(path string, body string, drifts *[]Drift) *Campaigner {
+	c := &Campaigner{APIToken: "token", BaseURL: "http://127.0.0.1:1", HTTPClient: &http.Client{Transport: replay(http.MethodGet, path, body)}, Strict: true}
+	if drifts != nil {
+		c.OnDrift = func(d Drift) { *drifts = append(*drifts, d) }
+	}
+
+	return c
+}
+
+// Returns a replayer holding one interaction.
+func replay(method string, path string, body string) *Replayer {
+	return NewCassetteReplayer(Cassette{Interactions: []CassetteInteraction{
+		{Request: CassetteRequest{Method: method, URL: "http://127.0.0.1:1" + path}, Response: CassetteResponse{StatusCode: http.StatusOK, Body: body}},
+	}})
+}
@@ -1,15 +1,15 @@
 package campaigner
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 )
 
-// TagCreate creates a tag.
-func (c *Campaigner) TagCreate(tag Tag) (ResponseTagCreate, error) {
+// Create creates a tag.
+func (s *TagAPI) Create(ctx context.Context, tag Tag) (ResponseTagCreate, error) {
 	// Setup.
 	var (
 		target   = "/api/3/tags"
@@ -38,14 +38,14 @@ func (c *Campaigner) TagCreate(tag Tag) (ResponseTagCreate, error) {
 	}
 
 	// POST request.
-	r, body, err := c.post(target, data)
+	r, body, err := s.client.post(ctx, target, data)
 	if err != nil {
 		return response, fmt.Errorf("tag creation failed, HTTP error: %s", err)
 	}
 
 	// Success.
 	if r.StatusCode == http.StatusCreated {
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("tag creation failed, JSON error: %s", err)
 		}
@@ -57,17 +57,17 @@ func (c *Campaigner) TagCreate(tag Tag) (ResponseTagCreate, error) {
 	return response, fmt.Errorf("tag creation failed, unspecified error (%d): %s", r.StatusCode, string(body))
 }
 
-// TagDelete deletes a tag.
+// Delete deletes a tag.
 //
 // TODO(api): This method is missing in the ActiveCampaign documentation.
-func (c *Campaigner) TagDelete(id int64) error {
+func (s *TagAPI) Delete(ctx context.Context, id int64) error {
 	// Setup.
 	var (
 		target = fmt.Sprintf("/api/3/tags/%d", id)
 	)
 
 	// Send DELETE request.
-	r, body, err := c.delete(target)
+	r, body, err := s.client.delete(ctx, target)
 	if err != nil {
 		return fmt.Errorf("tag deletion failed, HTTP error: %s", err)
 	}
@@ -81,12 +81,12 @@ func (c *Campaigner) TagDelete(id int64) error {
 	}
 }
 
-// TagFind searches for a tag by name.  The list of all available filters is not complete.
+// Find searches for a tag by name.  The list of all available filters is not complete.
 //
 // TODO(error-checking: Add HTTP status code checking.
 //
 // TODO(api): Query parameters aren't officially documented.
-func (c *Campaigner) TagFind(n string) (response ResponseTagList, err error) {
+func (s *TagAPI) Find(ctx context.Context, n string) (response ResponseTagList, err error) {
 	// Setup.
 	var (
 		qs       = fmt.Sprintf("%s=%s", url.QueryEscape("filters[tag]"), url.QueryEscape(n))
@@ -99,12 +99,12 @@ func (c *Campaigner) TagFind(n string) (response ResponseTagList, err error) {
 	}
 
 	// Send GET request.
-	_, b, err := c.get(target)
+	r, b, err := s.client.get(ctx, target)
 	if err != nil {
 		return response, fmt.Errorf("tag find failed, HTTP error: %s", err)
 	}
 
-	err = json.Unmarshal(b, &response)
+	err = s.client.decode(r, b, &response)
 	if err != nil {
 		return response, fmt.Errorf("tag list failed, JSON error: %s", err)
 	}
@@ -112,8 +112,8 @@ func (c *Campaigner) TagFind(n string) (response ResponseTagList, err error) {
 	return response, nil
 }
 
-// TagList lists all tags.
-func (c *Campaigner) TagList() (ResponseTagList, error) {
+// List lists all tags.
+func (s *TagAPI) List(ctx context.Context) (ResponseTagList, error) {
 	// Setup.
 	var (
 		target   = "/api/3/tags?limit=100"
@@ -121,14 +121,14 @@ func (c *Campaigner) TagList() (ResponseTagList, error) {
 	)
 
 	// GET request.
-	r, body, err := c.get(target)
+	r, body, err := s.client.get(ctx, target)
 	if err != nil {
 		return response, fmt.Errorf("tag list failed, HTTP error: %s", err)
 	}
 
 	// Success.
 	if r.StatusCode == http.StatusOK {
-		err = json.Unmarshal(body, &response)
+		err = s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("tag list failed, JSON error: %s", err)
 		}
@@ -140,15 +140,15 @@ func (c *Campaigner) TagList() (ResponseTagList, error) {
 	return response, fmt.Errorf("tag list failed, unspecified error: %s", string(body))
 }
 
-// TagRead reads a tag by it's ID.
+// Read reads a tag by it's ID.
 //
 // TODO(api): This endpoint is not documented.
-func (c *Campaigner) TagRead(id int64) (response ResponseTagRead, err error) {
+func (s *TagAPI) Read(ctx context.Context, id int64) (response ResponseTagRead, err error) {
 	// Setup.
 	var target = fmt.Sprintf("/api/3/tags/%d", id)
 
 	// Get request.
-	r, body, err := c.get(target)
+	r, body, err := s.client.get(ctx, target)
 	if err != nil {
 		return response, fmt.Errorf("tag read failed, HTTP error: %s", err)
 	}
@@ -156,7 +156,7 @@ func (c *Campaigner) TagRead(id int64) (response ResponseTagRead, err error) {
 	// Response check.
 	switch r.StatusCode {
 	case http.StatusOK:
-		err := json.Unmarshal(body, &response)
+		err := s.client.decode(r, body, &response)
 		if err != nil {
 			return response, fmt.Errorf("tag read failed, JSON error: %s", err)
 		}
@@ -175,13 +175,13 @@ func (c *Campaigner) TagRead(id int64) (response ResponseTagRead, err error) {
 
 // Tag holds a JSON compatible tag as it exists in the API.
 type Tag struct {
-	ID              int64    `json:"id,string"`
-	Type            string   `json:"tagType"`
-	Name            string   `json:"tag"`
-	Description     string   `json:"description"`
-	CreationDate    string   `json:"cdate"`
-	SubscriberCount int64    `json:"subscriber_count,string"`
-	Links           TagLinks `json:"links"`
+	ID              Int64json `json:"id"`
+	Type            string    `json:"tagType"`
+	Name            string    `json:"tag"`
+	Description     string    `json:"description"`
+	CreationDate    ACTime    `json:"cdate"`
+	SubscriberCount Int64json `json:"subscriber_count"`
+	Links           TagLinks  `json:"links"`
 }
 
 // TagLinks holds a JSON compatible list of links (nested structure).
@@ -198,7 +198,7 @@ type ResponseTagCreate struct {
 type ResponseTagList struct {
 	Tags []Tag `json:"tags"`
 	Meta struct {
-		Total string `json:"total"`
+		Total Int64json `json:"total"`
 	} `json:"meta"`
 }
 
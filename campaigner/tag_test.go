@@ -70,7 +70,7 @@ func TestTagFind_Success(t *testing.T) {
 
 	r, err := C.TagFind(n)
 	assert.Nil(t, err)
-	assert.NotEqual(t, 0, r.Meta.Total)
+	assert.NotEqual(t, Int64json(0), r.Meta.Total)
 	require.Equal(t, 1, len(r.Tags))
 	assert.Equal(t, n, r.Tags[0].Name)
 }
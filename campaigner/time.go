@@ -0,0 +1,220 @@
+package campaigner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timestamp layouts sent by the API, tried in order.  Layouts from acTimeFloating on have no offset.
+var acTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+const acTimeFloating = 3
+
+// ACTime holds a timestamp sent by the API.  The API mixes several formats (RFC3339 with offsets, "2006-01-02 15:04:05"
+// without one, plain dates and unix seconds) and sends missing values as null, "" or "0000-00-00", all of which load
+// as the zero time.  A zero ACTime is marshalled as null.
+//
+// Timestamps without an offset are in the time zone of the account.  They load as UTC and are moved into the client's
+// Location when a response is decoded (see Campaigner.Location).
+type ACTime struct {
+	time.Time
+
+	// Set for timestamps sent without an offset until they are moved into the client's time zone.
+	floating bool
+}
+
+// NewACTime returns an ACTime for t.
+func NewACTime(t time.Time) ACTime {
+	return ACTime{Time: t}
+}
+
+// MarshalJSON returns a JSON encoded RFC3339 timestamp or null for the zero time.
+func (t ACTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.Format(time.RFC3339))
+}
+
+// UnmarshalJSON loads an ACTime.  Timestamps without an offset are read as UTC.
+func (t *ACTime) UnmarshalJSON(data []byte) error {
+	v, floating, err := parseACTime(data)
+	if err != nil {
+		return err
+	}
+
+	t.Time, t.floating = v, floating
+	return nil
+}
+
+// String returns the timestamp in RFC3339 format or "" for the zero time.
+func (t ACTime) String() string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// ACTimeUTC holds a timestamp the API sends in UTC without saying so (e.g. created_utc_timestamp).  It behaves like
+// ACTime except that timestamps without an offset are always read as UTC.
+type ACTimeUTC struct {
+	ACTime
+}
+
+// UnmarshalJSON loads an ACTimeUTC.
+func (t *ACTimeUTC) UnmarshalJSON(data []byte) error {
+	v, _, err := parseACTime(data)
+	if err != nil {
+		return err
+	}
+
+	t.Time = v
+	return nil
+}
+
+// Parses a JSON encoded timestamp.  Timestamps without an offset are read as UTC and reported as floating.
+func parseACTime(data []byte) (time.Time, bool, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return time.Time{}, false, nil
+	}
+
+	// Unix seconds.
+	if data[0] != '"' {
+		n, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid timestamp %s", string(data))
+		}
+		if n == 0 {
+			return time.Time{}, false, nil
+		}
+
+		return time.Unix(n, 0).UTC(), false, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return time.Time{}, false, err
+	}
+
+	s = strings.TrimSpace(s)
+	if len(s) == 0 || strings.HasPrefix(s, "0000-00-00") {
+		return time.Time{}, false, nil
+	}
+
+	for x, layout := range acTimeLayouts {
+		if v, err := time.Parse(layout, s); err == nil {
+			return v, x >= acTimeFloating, nil
+		}
+	}
+
+	// Unix seconds sent as a string.
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n == 0 {
+			return time.Time{}, false, nil
+		}
+
+		return time.Unix(n, 0).UTC(), false, nil
+	}
+
+	return time.Time{}, false, fmt.Errorf("invalid timestamp %q", s)
+}
+
+var acTimeType = reflect.TypeOf(ACTime{})
+
+// Whether values of a type can hold an ACTime, by type.
+var acTimeHolders sync.Map
+
+// Moves the timestamps in v that were sent without an offset into loc (they stay in UTC if loc is nil).  v is the
+// pointer a response was decoded into.
+func localizeACTimes(v reflect.Value, loc *time.Location) {
+	if !holdsACTime(v.Type()) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			localizeACTimes(v.Elem(), loc)
+		}
+	case reflect.Struct:
+		if v.Type() == acTimeType {
+			if t := v.Addr().Interface().(*ACTime); t.floating {
+				if loc != nil {
+					t.Time = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+				}
+				t.floating = false
+			}
+			return
+		}
+		for x := 0; x < v.NumField(); x++ {
+			if f := v.Field(x); f.CanSet() {
+				localizeACTimes(f, loc)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for x := 0; x < v.Len(); x++ {
+			localizeACTimes(v.Index(x), loc)
+		}
+	case reflect.Map:
+		// Map values can't be changed in place.
+		for _, k := range v.MapKeys() {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			localizeACTimes(e, loc)
+			v.SetMapIndex(k, e)
+		}
+	}
+}
+
+// Returns whether values of a type can hold an ACTime.  Interfaces don't count: decoded JSON stored in an interface{}
+// holds no ACTime.
+func holdsACTime(t reflect.Type) bool {
+	if v, ok := acTimeHolders.Load(t); ok {
+		return v.(bool)
+	}
+
+	holds := typeHoldsACTime(t, map[reflect.Type]bool{})
+	acTimeHolders.Store(t, holds)
+
+	return holds
+}
+
+// Walks a type for holdsACTime, skipping types already being visited.
+func typeHoldsACTime(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return typeHoldsACTime(t.Elem(), visiting)
+	case reflect.Struct:
+		if t == acTimeType {
+			return true
+		}
+		for x := 0; x < t.NumField(); x++ {
+			if f := t.Field(x); (f.PkgPath == "" || f.Anonymous) && typeHoldsACTime(f.Type, visiting) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
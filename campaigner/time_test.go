@@ -0,0 +1,128 @@
+package campaigner
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Tests all timestamp functionality as a group.
+func TestACTimeSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestACTime_UnmarshalFormats)
+	runTestWithPackagePath(t, TestACTime_UnmarshalZero)
+	runTestWithPackagePath(t, TestACTime_UnmarshalLocation)
+	runTestWithPackagePath(t, TestACTime_FailureInvalid)
+	runTestWithPackagePath(t, TestACTime_Marshal)
+	runTestWithPackagePath(t, TestACTime_Contact)
+}
+
+func TestACTime_UnmarshalFormats(t *testing.T) {
+	want := time.Date(2019, 1, 2, 16, 4, 5, 0, time.UTC)
+
+	for _, s := range []string{
+		`"2019-01-02T10:04:05-06:00"`,
+		`"2019-01-02T10:04:05-0600"`,
+		`"2019-01-02T16:04:05Z"`,
+		`"2019-01-02T16:04:05.000Z"`,
+		`"2019-01-02 16:04:05"`,
+		`"2019-01-02T16:04:05"`,
+		`1546445045`,
+		`"1546445045"`,
+	} {
+		var v ACTime
+		require.Nil(t, json.Unmarshal([]byte(s), &v), s)
+		assert.True(t, want.Equal(v.Time), "%s: %s", s, v)
+	}
+
+	var v ACTime
+	require.Nil(t, json.Unmarshal([]byte(`"2019-01-02"`), &v))
+	assert.Equal(t, time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC), v.Time)
+}
+
+func TestACTime_UnmarshalZero(t *testing.T) {
+	for _, s := range []string{`null`, `""`, `" "`, `"0000-00-00"`, `"0000-00-00 00:00:00"`, `0`} {
+		v := NewACTime(time.Now())
+		require.Nil(t, json.Unmarshal([]byte(s), &v), s)
+		assert.True(t, v.IsZero(), s)
+		assert.Equal(t, "", v.String())
+	}
+}
+
+func TestACTime_UnmarshalLocation(t *testing.T) {
+	var (
+		chicago = time.FixedZone("CST", -6*60*60)
+		tokyo   = time.FixedZone("JST", 9*60*60)
+		body    = []byte(`{"local":"2019-01-02 10:00:00","utc":"2019-01-02 16:00:00","zoned":"2019-01-02T16:00:00Z","list":[{"local":"2019-01-02 10:00:00"}],"byID":{"1":{"local":"2019-01-02 10:00:00"}}}`)
+	)
+
+	type item struct {
+		Local ACTime `json:"local"`
+	}
+	type response struct {
+		Local ACTime          `json:"local"`
+		UTC   ACTimeUTC       `json:"utc"`
+		Zoned ACTime          `json:"zoned"`
+		List  []item          `json:"list"`
+		ByID  map[string]item `json:"byID"`
+	}
+
+	// Each client reads timestamps without an offset in its own time zone.
+	var v response
+	require.Nil(t, (&Campaigner{Location: chicago}).decode(nil, body, &v))
+
+	want := time.Date(2019, 1, 2, 10, 0, 0, 0, chicago)
+	assert.True(t, want.Equal(v.Local.Time), v.Local.String())
+	assert.True(t, want.Equal(v.UTC.Time), v.UTC.String())
+	assert.True(t, want.Equal(v.Zoned.Time), v.Zoned.String())
+	assert.True(t, want.Equal(v.List[0].Local.Time), v.List[0].Local.String())
+	assert.True(t, want.Equal(v.ByID["1"].Local.Time), v.ByID["1"].Local.String())
+
+	var w response
+	require.Nil(t, (&Campaigner{Location: tokyo}).decode(nil, body, &w))
+	assert.True(t, time.Date(2019, 1, 2, 10, 0, 0, 0, tokyo).Equal(w.Local.Time), w.Local.String())
+	assert.True(t, want.Equal(w.Zoned.Time), w.Zoned.String())
+
+	// Without a time zone they are read as UTC, and decoded values compare equal to new ones.
+	var u response
+	require.Nil(t, (&Campaigner{}).decode(nil, body, &u))
+	assert.Equal(t, NewACTime(time.Date(2019, 1, 2, 10, 0, 0, 0, time.UTC)), u.Local)
+}
+
+func TestACTime_FailureInvalid(t *testing.T) {
+	for _, s := range []string{`"yesterday"`, `"2019-13-45"`, `true`, `{}`} {
+		var v ACTime
+		assert.NotNil(t, json.Unmarshal([]byte(s), &v), s)
+	}
+}
+
+func TestACTime_Marshal(t *testing.T) {
+	b, err := json.Marshal(struct {
+		A ACTime    `json:"a"`
+		B ACTime    `json:"b"`
+		C ACTimeUTC `json:"c"`
+	}{A: NewACTime(time.Date(2019, 1, 2, 10, 0, 0, 0, time.FixedZone("", -6*60*60)))})
+	require.Nil(t, err)
+	assert.Equal(t, `{"a":"2019-01-02T10:00:00-06:00","b":null,"c":null}`, string(b))
+
+	// Round trip.
+	var v struct {
+		A ACTime `json:"a"`
+	}
+	require.Nil(t, json.Unmarshal(b, &v))
+	assert.Equal(t, "2019-01-02T10:00:00-06:00", v.A.String())
+}
+
+func TestACTime_Contact(t *testing.T) {
+	body := `{"id":"3","email":"a@b.c","orgid":"0","deleted":"0","cdate":"2019-01-02T10:00:00-06:00","udate":"2019-01-02T10:00:00-06:00",
+		"deleted_at":"0000-00-00 00:00:00","bounced_date":"0000-00-00","adate":null,"edate":"","created_utc_timestamp":"2019-01-02 16:00:00"}`
+
+	var c Contact
+	require.Nil(t, json.Unmarshal([]byte(body), &c))
+	assert.True(t, c.DateCreated.Equal(c.CreatedUtcTimestamp.Time))
+	assert.True(t, c.DateDeleted.IsZero())
+	assert.True(t, c.BouncedDate.IsZero())
+	assert.True(t, c.Adate.IsZero())
+	assert.True(t, c.Edate.IsZero())
+}
@@ -4,61 +4,225 @@ package campaigner
 
 // Contact holds a JSON compatible contact as it exists in the API.  This was generated from JSON returned by a read call.
 type Contact struct {
-	ID             int64     `json:"id,string"`
+	ID             Int64json `json:"id"`
 	EmailAddress   string    `json:"email"`
 	PhoneNumber    string    `json:"phone"`
 	FirstName      string    `json:"firstName"`
 	LastName       string    `json:"lastName"`
 	OrganizationID Int64json `json:"orgid"`
 
-	DateCreated string `json:"cdate,omitempty"`
-	DateUpdated string `json:"udate,omitempty"`
-	DateDeleted string `json:"deleted_at"`
-	IsDeleted   int    `json:"deleted,string"`
+	DateCreated ACTime   `json:"cdate"`
+	DateUpdated ACTime   `json:"udate"`
+	DateDeleted ACTime   `json:"deleted_at"`
+	IsDeleted   Booljson `json:"deleted"`
 
 	// These fields are still in progress.
-	SegmentioID         string        `json:"segmentio_id"`
-	BouncedHard         string        `json:"bounced_hard"`
-	BouncedSoft         string        `json:"bounced_soft"`
-	BouncedDate         interface{}   `json:"bounced_date"`
-	IP                  string        `json:"ip"`
-	Ua                  interface{}   `json:"ua"`
-	Hash                string        `json:"hash"`
-	SocialdataLastcheck interface{}   `json:"socialdata_lastcheck"`
-	EmailLocal          string        `json:"email_local"`
-	EmailDomain         string        `json:"email_domain"`
-	Sentcnt             string        `json:"sentcnt"`
-	RatingTstamp        interface{}   `json:"rating_tstamp"`
-	Gravatar            string        `json:"gravatar"`
-	Anonymized          string        `json:"anonymized"`
-	Adate               interface{}   `json:"adate"`
-	Edate               interface{}   `json:"edate"`
-	CreatedUtcTimestamp string        `json:"created_utc_timestamp"`
-	UpdatedUtcTimestamp string        `json:"updated_utc_timestamp"`
-	ContactAutomations  []interface{} `json:"contactAutomations"`
-	ContactLists        []interface{} `json:"contactLists"`
-	FieldValues         []interface{} `json:"fieldValues"`
-	GeoIps              []interface{} `json:"geoIps"`
-	Deals               []interface{} `json:"deals"`
-	Links               ContactLinks  `json:"links"`
-	Organization        interface{}   `json:"organization"`
+	SegmentioID         string              `json:"segmentio_id"`
+	BouncedHard         Int64json           `json:"bounced_hard"`
+	BouncedSoft         Int64json           `json:"bounced_soft"`
+	BouncedDate         ACTime              `json:"bounced_date"`
+	IP                  string              `json:"ip"`
+	Ua                  interface{}         `json:"ua"`
+	Hash                string              `json:"hash"`
+	SocialdataLastcheck ACTime              `json:"socialdata_lastcheck"`
+	EmailLocal          string              `json:"email_local"`
+	EmailDomain         string              `json:"email_domain"`
+	Sentcnt             Int64json           `json:"sentcnt"`
+	RatingTstamp        ACTime              `json:"rating_tstamp"`
+	Gravatar            Booljson            `json:"gravatar"`
+	Anonymized          Booljson            `json:"anonymized"`
+	Adate               ACTime              `json:"adate"`
+	Edate               ACTime              `json:"edate"`
+	CreatedUtcTimestamp ACTimeUTC           `json:"created_utc_timestamp"`
+	UpdatedUtcTimestamp ACTimeUTC           `json:"updated_utc_timestamp"`
+	ContactAutomations  []ContactAutomation `json:"contactAutomations"`
+	ContactLists        []ContactList       `json:"contactLists"`
+	FieldValues         []ContactFieldValue `json:"fieldValues"`
+	GeoIps              []ContactGeoIP      `json:"geoIps"`
+	Deals               []ContactDeal       `json:"deals"`
+	Links               ContactLinks        `json:"links"`
+	Organization        NullInt64json       `json:"organization"`
 }
 
-// ContactFieldValue holds a JSON compatible contact field value as it exists in the API.
+// ContactFieldValue holds a JSON compatible contact field value as it exists in the API.  Only the ID is set when the
+// value is referenced by a contact (see Contact.FieldValues).
 type ContactFieldValue struct {
 	ID          Int64json `json:"id"`
 	ContactID   Int64json `json:"contact"`
 	FieldID     Int64json `json:"field"`
 	OwnerID     Int64json `json:"owner"`
 	Value       string    `json:"value"`
-	DateCreated string    `json:"cdate"`
-	DateUpdated string    `json:"udate"`
+	DateCreated ACTime    `json:"cdate"`
+	DateUpdated ACTime    `json:"udate"`
 	Links       struct {
-		Owner Int64json `json:"owner"`
-		Field Int64json `json:"field"`
+		Owner string `json:"owner"`
+		Field string `json:"field"`
 	} `json:"links"`
 }
 
+// UnmarshalJSON loads a full field value or a bare ID.
+func (v *ContactFieldValue) UnmarshalJSON(b []byte) error {
+	type record ContactFieldValue
+	return unmarshalIDOrRecord(b, &v.ID, (*record)(v))
+}
+
+func (ContactFieldValue) idOrRecord() {}
+
+// ContactAutomation holds a JSON compatible automation the contact entered.  Contacts only reference these by ID (see
+// Contact.ContactAutomations), reads side-load them in full (see ResponseContactRead).
+type ContactAutomation struct {
+	ID                Int64json     `json:"id"`
+	Contact           Int64json     `json:"contact"`
+	Automation        Int64json     `json:"automation"`
+	Seriesid          Int64json     `json:"seriesid"`
+	Startid           Int64json     `json:"startid"`
+	Status            string        `json:"status"`
+	Adddate           ACTime        `json:"adddate"`
+	Remdate           ACTime        `json:"remdate"`
+	Timespan          NullInt64json `json:"timespan"`
+	Lastblock         Int64json     `json:"lastblock"`
+	Lastdate          ACTime        `json:"lastdate"`
+	CompletedElements Int64json     `json:"completedElements"`
+	TotalElements     Int64json     `json:"totalElements"`
+	Completed         Booljson      `json:"completed"`
+	CompleteValue     Int64json     `json:"completeValue"`
+	Links             struct {
+		Automation   string `json:"automation"`
+		Contact      string `json:"contact"`
+		ContactGoals string `json:"contactGoals"`
+	} `json:"links"`
+}
+
+// UnmarshalJSON loads a full automation or a bare ID.
+func (a *ContactAutomation) UnmarshalJSON(b []byte) error {
+	type record ContactAutomation
+	return unmarshalIDOrRecord(b, &a.ID, (*record)(a))
+}
+
+func (ContactAutomation) idOrRecord() {}
+
+// ContactList holds a JSON compatible list membership.  Contacts only reference these by ID (see Contact.ContactLists),
+// reads side-load them in full.
+type ContactList struct {
+	ID                    Int64json     `json:"id"`
+	Contact               Int64json     `json:"contact"`
+	List                  Int64json     `json:"list"`
+	Form                  NullInt64json `json:"form"`
+	Seriesid              Int64json     `json:"seriesid"`
+	Sdate                 ACTime        `json:"sdate"`
+	Udate                 ACTime        `json:"udate"`
+	Status                Int64json     `json:"status"`
+	Responder             Booljson      `json:"responder"`
+	Sync                  Booljson      `json:"sync"`
+	Unsubreason           interface{}   `json:"unsubreason"`
+	Campaign              NullInt64json `json:"campaign"`
+	Message               NullInt64json `json:"message"`
+	FirstName             string        `json:"first_name"`
+	LastName              string        `json:"last_name"`
+	IP4Sub                string        `json:"ip4Sub"`
+	Sourceid              Int64json     `json:"sourceid"`
+	AutosyncLog           interface{}   `json:"autosyncLog"`
+	IP4Last               string        `json:"ip4_last"`
+	IP4Unsub              string        `json:"ip4Unsub"`
+	UnsubscribeAutomation interface{}   `json:"unsubscribeAutomation"`
+	Automation            NullInt64json `json:"automation"`
+	Links                 struct {
+		Automation            string `json:"automation"`
+		List                  string `json:"list"`
+		Contact               string `json:"contact"`
+		Form                  string `json:"form"`
+		AutosyncLog           string `json:"autosyncLog"`
+		Campaign              string `json:"campaign"`
+		UnsubscribeAutomation string `json:"unsubscribeAutomation"`
+		Message               string `json:"message"`
+	} `json:"links"`
+}
+
+// UnmarshalJSON loads a full list membership or a bare ID.
+func (l *ContactList) UnmarshalJSON(b []byte) error {
+	type record ContactList
+	return unmarshalIDOrRecord(b, &l.ID, (*record)(l))
+}
+
+func (ContactList) idOrRecord() {}
+
+// ContactDeal holds a JSON compatible deal of a contact.  Contacts only reference these by ID (see Contact.Deals), reads
+// side-load them in full.
+type ContactDeal struct {
+	ID           Int64json     `json:"id"`
+	Owner        Int64json     `json:"owner"`
+	Contact      Int64json     `json:"contact"`
+	Organization NullInt64json `json:"organization"`
+	Group        NullInt64json `json:"group"`
+	Title        string        `json:"title"`
+	Nexttaskid   Int64json     `json:"nexttaskid"`
+	Currency     string        `json:"currency"`
+	Status       string        `json:"status"`
+	NextTask     NullInt64json `json:"nextTask"`
+	Links        struct {
+		Activities   string `json:"activities"`
+		Contact      string `json:"contact"`
+		ContactDeals string `json:"contactDeals"`
+		Group        string `json:"group"`
+		NextTask     string `json:"nextTask"`
+		Notes        string `json:"notes"`
+		Organization string `json:"organization"`
+		Owner        string `json:"owner"`
+		ScoreValues  string `json:"scoreValues"`
+		Stage        string `json:"stage"`
+		Tasks        string `json:"tasks"`
+	} `json:"links"`
+}
+
+// UnmarshalJSON loads a full deal or a bare ID.
+func (d *ContactDeal) UnmarshalJSON(b []byte) error {
+	type record ContactDeal
+	return unmarshalIDOrRecord(b, &d.ID, (*record)(d))
+}
+
+func (ContactDeal) idOrRecord() {}
+
+// ContactGeoIP holds a JSON compatible IP address the contact was seen at.  Contacts only reference these by ID (see
+// Contact.GeoIps), reads side-load them in full along with their addresses.
+type ContactGeoIP struct {
+	ID         Int64json `json:"id"`
+	Contact    Int64json `json:"contact"`
+	Campaignid Int64json `json:"campaignid"`
+	Messageid  Int64json `json:"messageid"`
+	Geoaddrid  Int64json `json:"geoaddrid"`
+	IP4        string    `json:"ip4"`
+	Tstamp     ACTime    `json:"tstamp"`
+	GeoAddress string    `json:"geoAddress"`
+	Links      struct {
+		GeoAddress string `json:"geoAddress"`
+	} `json:"links"`
+}
+
+// UnmarshalJSON loads a full IP address or a bare ID.
+func (g *ContactGeoIP) UnmarshalJSON(b []byte) error {
+	type record ContactGeoIP
+	return unmarshalIDOrRecord(b, &g.ID, (*record)(g))
+}
+
+func (ContactGeoIP) idOrRecord() {}
+
+// ContactGeoAddress holds a JSON compatible location of an IP address (see ContactGeoIP.Geoaddrid).
+type ContactGeoAddress struct {
+	ID       Int64json     `json:"id"`
+	IP4      string        `json:"ip4"`
+	Country2 string        `json:"country2"`
+	Country  string        `json:"country"`
+	State    string        `json:"state"`
+	City     string        `json:"city"`
+	Zip      string        `json:"zip"`
+	Area     string        `json:"area"`
+	Lat      Decimaljson   `json:"lat"`
+	Lon      Decimaljson   `json:"lon"`
+	Tz       string        `json:"tz"`
+	Tstamp   ACTime        `json:"tstamp"`
+	Links    []interface{} `json:"links"`
+}
+
 // ContactLinks holds a JSON compatible collection of links (nested structure, see Contact).  Not sure what these link to at this point (other than the obvious).
 type ContactLinks struct {
 	BounceLogs         string `json:"bounceLogs"`
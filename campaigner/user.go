@@ -0,0 +1,484 @@
+package campaigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// User holds a JSON compatible user (a login of the account, e.g. a sales rep) as it exists in the API.  Deal owners,
+// task assignees and list owners are user IDs.
+type User struct {
+	ID          Int64json         `json:"id"`
+	Username    string            `json:"username"`
+	FirstName   string            `json:"firstName"`
+	LastName    string            `json:"lastName"`
+	Email       string            `json:"email"`
+	Phone       string            `json:"phone"`
+	Signature   *string           `json:"signature"`
+	Lang        string            `json:"lang"`
+	LocalZoneID string            `json:"localZoneid"`
+	MFAEnabled  Booljson          `json:"mfaEnabled"`
+	CreatedDate ACTime            `json:"cdate"`
+	UpdatedDate ACTime            `json:"udate"`
+	Links       map[string]string `json:"links"`
+}
+
+// Name returns the full name of a user, or the username if the user has no name.
+func (u User) Name() string {
+	n := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if len(n) == 0 {
+		return u.Username
+	}
+
+	return n
+}
+
+// RequestUserCreate holds a JSON compatible request for creating users.  Group is the ID of the group (permissions)
+// the user belongs to.
+type RequestUserCreate struct {
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Group     int64  `json:"group"`
+	Password  string `json:"password"`
+}
+
+// RequestUserUpdate holds a JSON compatible request for updating users.  Empty fields are left unchanged.
+type RequestUserUpdate struct {
+	Username  string `json:"username,omitempty"`
+	Email     string `json:"email,omitempty"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Group     int64  `json:"group,omitempty"`
+	Password  string `json:"password,omitempty"`
+}
+
+// ResponseUserRead holds a JSON compatible response for reading, creating and updating users.
+type ResponseUserRead struct {
+	User User `json:"user"`
+}
+
+// ResponseUserList holds a JSON compatible response for listing users.
+type ResponseUserList struct {
+	Users []User `json:"users"`
+	Meta  struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// Names returns the full names of the listed users by ID (see User.Name), e.g. to show deal owners in reports.
+func (r ResponseUserList) Names() map[int64]string {
+	m := make(map[int64]string, len(r.Users))
+
+	for _, u := range r.Users {
+		m[u.ID.Int64()] = u.Name()
+	}
+
+	return m
+}
+
+// Group holds a JSON compatible group as it exists in the API.  Groups hold the permissions of their users, every
+// permission is a flag.
+type Group struct {
+	ID              Int64json         `json:"id,omitempty"`
+	Title           string            `json:"title"`
+	Description     string            `json:"descript"`
+	UnsubscribeLink Booljson          `json:"unsubscribelink"`
+	OptInConfirm    Booljson          `json:"optinconfirm"`
+	Links           map[string]string `json:"links,omitempty"`
+
+	ListAdd    Booljson `json:"pg_list_add"`
+	ListEdit   Booljson `json:"pg_list_edit"`
+	ListDelete Booljson `json:"pg_list_delete"`
+
+	MessageAdd    Booljson `json:"pg_message_add"`
+	MessageEdit   Booljson `json:"pg_message_edit"`
+	MessageDelete Booljson `json:"pg_message_delete"`
+	MessageSend   Booljson `json:"pg_message_send"`
+
+	ContactAdd    Booljson `json:"pg_subscriber_add"`
+	ContactEdit   Booljson `json:"pg_subscriber_edit"`
+	ContactDelete Booljson `json:"pg_subscriber_delete"`
+	ContactImport Booljson `json:"pg_subscriber_import"`
+	ContactExport Booljson `json:"pg_subscriber_export"`
+	ContactFields Booljson `json:"pg_subscriber_fields"`
+
+	UserAdd    Booljson `json:"pg_user_add"`
+	UserEdit   Booljson `json:"pg_user_edit"`
+	UserDelete Booljson `json:"pg_user_delete"`
+
+	GroupAdd    Booljson `json:"pg_group_add"`
+	GroupEdit   Booljson `json:"pg_group_edit"`
+	GroupDelete Booljson `json:"pg_group_delete"`
+
+	Reports Booljson `json:"pg_reports_campaign"`
+
+	DealAdd    Booljson `json:"pg_deal"`
+	DealDelete Booljson `json:"pg_deal_delete"`
+}
+
+// ResponseGroupRead holds a JSON compatible response for reading, creating and updating groups.
+type ResponseGroupRead struct {
+	Group Group `json:"group"`
+}
+
+// ResponseGroupList holds a JSON compatible response for listing groups.
+type ResponseGroupList struct {
+	Groups []Group `json:"groups"`
+	Meta   struct {
+		Total Int64json `json:"total"`
+	} `json:"meta"`
+}
+
+// Create creates a user.
+func (s *UserAPI) Create(ctx context.Context, user RequestUserCreate) (response ResponseUserRead, err error) {
+	// Setup.
+	var (
+		uri  = "/api/3/users"
+		data = map[string]interface{}{"user": user}
+	)
+
+	// Send POST request.
+	r, body, err := s.client.post(ctx, uri, data)
+	if err != nil {
+		return response, fmt.Errorf("user creation failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		err = s.client.decode(r, body, &response)
+		if err != nil {
+			return response, fmt.Errorf("user creation failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	case http.StatusUnprocessableEntity:
+		var apiError ActiveCampaignError
+		err = json.Unmarshal(body, &apiError)
+		if err != nil {
+			return response, fmt.Errorf("user creation failed, API error unmarshall error: %s", err)
+		}
+
+		return response, apiError
+
+	default:
+		return response, fmt.Errorf("user creation failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Delete deletes a user by it's ID.
+func (s *UserAPI) Delete(ctx context.Context, id int64) error {
+	// Setup.
+	var uri = fmt.Sprintf("/api/3/users/%d", id)
+
+	// Send DELETE request.
+	r, body, err := s.client.delete(ctx, uri)
+	if err != nil {
+		return fmt.Errorf("user delete failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		return nil
+
+	case http.StatusNotFound:
+		return CustomErrorNotFound{CustomError{Message: fmt.Sprintf("user delete failed, ID %d not found", id)}}
+
+	default:
+		return fmt.Errorf("user delete failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// FindByEmail reads a user by it's email address.
+func (s *UserAPI) FindByEmail(ctx context.Context, email string) (ResponseUserRead, error) {
+	if len(strings.TrimSpace(email)) == 0 {
+		return ResponseUserRead{}, fmt.Errorf("user find failed, email is empty")
+	}
+
+	return s.read(ctx, "/api/3/users/email/"+url.PathEscape(email), fmt.Sprintf("email %s", email))
+}
+
+// FindByUsername reads a user by it's username.
+func (s *UserAPI) FindByUsername(ctx context.Context, username string) (ResponseUserRead, error) {
+	if len(strings.TrimSpace(username)) == 0 {
+		return ResponseUserRead{}, fmt.Errorf("user find failed, username is empty")
+	}
+
+	return s.read(ctx, "/api/3/users/username/"+url.PathEscape(username), fmt.Sprintf("username %s", username))
+}
+
+// List lists users.
+func (s *UserAPI) List(ctx context.Context, limit int, offset int) (response ResponseUserList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/users", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("user list failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		err = s.client.decode(r, body, &response)
+		if err != nil {
+			return response, fmt.Errorf("user list failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	default:
+		return response, fmt.Errorf("user list failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Me reads the user the API token belongs to.
+func (s *UserAPI) Me(ctx context.Context) (ResponseUserRead, error) {
+	return s.read(ctx, "/api/3/users/me", "me")
+}
+
+// Read reads a user by it's ID.
+func (s *UserAPI) Read(ctx context.Context, id int64) (ResponseUserRead, error) {
+	return s.read(ctx, fmt.Sprintf("/api/3/users/%d", id), fmt.Sprintf("ID %d", id))
+}
+
+// Update updates a user.
+func (s *UserAPI) Update(ctx context.Context, id int64, user RequestUserUpdate) (response ResponseUserRead, err error) {
+	// Setup.
+	var (
+		uri  = fmt.Sprintf("/api/3/users/%d", id)
+		data = map[string]interface{}{"user": user}
+	)
+
+	// Send PUT request.
+	r, body, err := s.client.put(ctx, uri, data)
+	if err != nil {
+		return response, fmt.Errorf("user update failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		err = s.client.decode(r, body, &response)
+		if err != nil {
+			return response, fmt.Errorf("user update failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	case http.StatusNotFound:
+		return response, CustomErrorNotFound{CustomError{Message: fmt.Sprintf("user update failed, ID %d not found", id)}}
+
+	case http.StatusUnprocessableEntity:
+		var apiError ActiveCampaignError
+		err = json.Unmarshal(body, &apiError)
+		if err != nil {
+			return response, fmt.Errorf("user update failed, API error unmarshall error: %s", err)
+		}
+
+		return response, apiError
+
+	default:
+		return response, fmt.Errorf("user update failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Reads a single user.  key describes the user in errors.
+func (s *UserAPI) read(ctx context.Context, uri string, key string) (response ResponseUserRead, err error) {
+	// Send GET request.
+	r, body, err := s.client.get(ctx, uri)
+	if err != nil {
+		return response, fmt.Errorf("user read failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		err = s.client.decode(r, body, &response)
+		if err != nil {
+			return response, fmt.Errorf("user read failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	case http.StatusNotFound:
+		return response, CustomErrorNotFound{CustomError{Message: fmt.Sprintf("user with %s not found", key)}}
+
+	default:
+		return response, fmt.Errorf("user read failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Create creates a group.
+func (s *GroupAPI) Create(ctx context.Context, group Group) (response ResponseGroupRead, err error) {
+	// Setup.
+	var (
+		uri  = "/api/3/groups"
+		data = map[string]interface{}{"group": group}
+	)
+
+	// Send POST request.
+	r, body, err := s.client.post(ctx, uri, data)
+	if err != nil {
+		return response, fmt.Errorf("group creation failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		err = s.client.decode(r, body, &response)
+		if err != nil {
+			return response, fmt.Errorf("group creation failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	case http.StatusUnprocessableEntity:
+		var apiError ActiveCampaignError
+		err = json.Unmarshal(body, &apiError)
+		if err != nil {
+			return response, fmt.Errorf("group creation failed, API error unmarshall error: %s", err)
+		}
+
+		return response, apiError
+
+	default:
+		return response, fmt.Errorf("group creation failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Delete deletes a group by it's ID.
+func (s *GroupAPI) Delete(ctx context.Context, id int64) error {
+	// Setup.
+	var uri = fmt.Sprintf("/api/3/groups/%d", id)
+
+	// Send DELETE request.
+	r, body, err := s.client.delete(ctx, uri)
+	if err != nil {
+		return fmt.Errorf("group delete failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		return nil
+
+	case http.StatusNotFound:
+		return CustomErrorNotFound{CustomError{Message: fmt.Sprintf("group delete failed, ID %d not found", id)}}
+
+	default:
+		return fmt.Errorf("group delete failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// List lists groups.
+func (s *GroupAPI) List(ctx context.Context, limit int, offset int) (response ResponseGroupList, err error) {
+	// Setup.
+	qs := url.Values{}
+	qs.Set("limit", strconv.Itoa(limit))
+	qs.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: "/api/3/groups", RawQuery: qs.Encode()}
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, u.String())
+	if err != nil {
+		return response, fmt.Errorf("group list failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		err = s.client.decode(r, body, &response)
+		if err != nil {
+			return response, fmt.Errorf("group list failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	default:
+		return response, fmt.Errorf("group list failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Read reads a group by it's ID.
+func (s *GroupAPI) Read(ctx context.Context, id int64) (response ResponseGroupRead, err error) {
+	// Setup.
+	var uri = fmt.Sprintf("/api/3/groups/%d", id)
+
+	// Send GET request.
+	r, body, err := s.client.get(ctx, uri)
+	if err != nil {
+		return response, fmt.Errorf("group read failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		err = s.client.decode(r, body, &response)
+		if err != nil {
+			return response, fmt.Errorf("group read failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	case http.StatusNotFound:
+		return response, CustomErrorNotFound{CustomError{Message: fmt.Sprintf("group with id %d not found", id)}}
+
+	default:
+		return response, fmt.Errorf("group read failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
+
+// Update updates a group.  Every permission is sent, so start from the group as read.
+func (s *GroupAPI) Update(ctx context.Context, id int64, group Group) (response ResponseGroupRead, err error) {
+	// Setup.
+	var (
+		uri  = fmt.Sprintf("/api/3/groups/%d", id)
+		data = map[string]interface{}{"group": group}
+	)
+
+	// Send PUT request.
+	r, body, err := s.client.put(ctx, uri, data)
+	if err != nil {
+		return response, fmt.Errorf("group update failed, HTTP error: %s", err)
+	}
+
+	// Response check.
+	switch r.StatusCode {
+	case http.StatusOK:
+		err = s.client.decode(r, body, &response)
+		if err != nil {
+			return response, fmt.Errorf("group update failed, JSON error: %s", err)
+		}
+
+		return response, nil
+
+	case http.StatusNotFound:
+		return response, CustomErrorNotFound{CustomError{Message: fmt.Sprintf("group update failed, ID %d not found", id)}}
+
+	case http.StatusUnprocessableEntity:
+		var apiError ActiveCampaignError
+		err = json.Unmarshal(body, &apiError)
+		if err != nil {
+			return response, fmt.Errorf("group update failed, API error unmarshall error: %s", err)
+		}
+
+		return response, apiError
+
+	default:
+		return response, fmt.Errorf("group update failed, unspecified error (%d): %s", r.StatusCode, string(body))
+	}
+}
@@ -0,0 +1,145 @@
+package campaigner
+
+import (
+	"context"
+	"github.com/henrocdotnet/active-campaigner/campaigner/actest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// Tests all user and group functionality as a group.  These tests run against a local test server.
+func TestUserSuite(t *testing.T) {
+	runTestWithPackagePath(t, TestUser_Success)
+	runTestWithPackagePath(t, TestUser_SuccessLookup)
+	runTestWithPackagePath(t, TestUser_FailureCreate)
+	runTestWithPackagePath(t, TestUser_FailureNotFound)
+	runTestWithPackagePath(t, TestGroup_Success)
+}
+
+// Returns a strict client for a test server.  Drift fails the test.
+func strictTestClient(t *testing.T, s *actest.Server) *Campaigner {
+	c := New(s.APIToken, s.URL)
+	c.Strict = true
+	c.OnDrift = func(d Drift) { t.Errorf("unexpected drift: %+v", d) }
+
+	return c
+}
+
+// Starts a test server that is closed when the test ends and returns it with a strict client for it.
+func startTestServer(t *testing.T) (context.Context, *actest.Server, *Campaigner) {
+	s := actest.NewServer()
+	t.Cleanup(s.Close)
+
+	return context.Background(), s, strictTestClient(t, s)
+}
+
+func TestUser_Success(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	sales := s.AddGroup(actest.Group{Title: "Sales"})
+
+	created, err := c.Users.Create(ctx, RequestUserCreate{Username: "jdoe", Email: "jdoe@example.com", FirstName: "Jane", LastName: "Doe", Group: sales, Password: "secret"})
+	require.Nil(t, err)
+	id := created.User.ID.Int64()
+	assert.Equal(t, "Jane Doe", created.User.Name())
+	assert.Equal(t, sales, s.UserGroup(id))
+	assert.False(t, created.User.CreatedDate.IsZero())
+
+	updated, err := c.Users.Update(ctx, id, RequestUserUpdate{LastName: "Smith"})
+	require.Nil(t, err)
+	assert.Equal(t, "Jane Smith", updated.User.Name())
+	assert.Equal(t, "jdoe", updated.User.Username)
+
+	read, err := c.Users.Read(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, "jdoe@example.com", read.User.Email)
+
+	list, err := c.Users.List(ctx, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, list.Users, 2)
+	assert.Equal(t, int64(2), list.Meta.Total.Int64())
+	assert.Equal(t, "Jane Smith", list.Names()[id])
+
+	require.Nil(t, c.Users.Delete(ctx, id))
+	_, err = c.Users.Read(ctx, id)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+}
+
+func TestUser_SuccessLookup(t *testing.T) {
+	ctx, s, c := startTestServer(t)
+
+	s.AddUser(actest.User{Username: "jdoe", EmailAddress: "jdoe@example.com", GroupID: 1})
+
+	me, err := c.Users.Me(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, "admin", me.User.Username)
+
+	byEmail, err := c.Users.FindByEmail(ctx, "jdoe@example.com")
+	require.Nil(t, err)
+	assert.Equal(t, "jdoe", byEmail.User.Username)
+	assert.Equal(t, "jdoe", byEmail.User.Name())
+
+	byUsername, err := c.Users.FindByUsername(ctx, "jdoe")
+	require.Nil(t, err)
+	assert.Equal(t, byEmail.User.ID, byUsername.User.ID)
+
+	_, err = c.Users.FindByEmail(ctx, "nobody@example.com")
+	assert.IsType(t, CustomErrorNotFound{}, err)
+
+	_, err = c.Users.FindByUsername(ctx, " ")
+	assert.NotNil(t, err)
+}
+
+func TestUser_FailureCreate(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	_, err := c.Users.Create(ctx, RequestUserCreate{Username: "admin", Email: "other@example.com", Group: 1})
+	require.IsType(t, ActiveCampaignError{}, err)
+	assert.Equal(t, "duplicate", err.(ActiveCampaignError).Errors[0].Code)
+
+	_, err = c.Users.Create(ctx, RequestUserCreate{Username: "jdoe", Email: "jdoe@example.com", Group: 99})
+	assert.IsType(t, ActiveCampaignError{}, err)
+}
+
+func TestUser_FailureNotFound(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	_, err := c.Users.Update(ctx, 99, RequestUserUpdate{FirstName: "X"})
+	assert.IsType(t, CustomErrorNotFound{}, err)
+	assert.IsType(t, CustomErrorNotFound{}, c.Users.Delete(ctx, 99))
+	_, err = c.Groups.Read(ctx, 99)
+	assert.IsType(t, CustomErrorNotFound{}, err)
+	assert.IsType(t, CustomErrorNotFound{}, c.Groups.Delete(ctx, 99))
+}
+
+func TestGroup_Success(t *testing.T) {
+	ctx, _, c := startTestServer(t)
+
+	created, err := c.Groups.Create(ctx, Group{Title: "Sales", Description: "Sales reps", ContactAdd: true, ContactEdit: true})
+	require.Nil(t, err)
+	id := created.Group.ID.Int64()
+	assert.True(t, created.Group.ContactAdd.Bool())
+	assert.False(t, created.Group.UserAdd.Bool())
+
+	g := created.Group
+	g.DealAdd = true
+	updated, err := c.Groups.Update(ctx, id, g)
+	require.Nil(t, err)
+	assert.True(t, updated.Group.DealAdd.Bool())
+	assert.True(t, updated.Group.ContactEdit.Bool())
+
+	read, err := c.Groups.Read(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, "Sales reps", read.Group.Description)
+
+	list, err := c.Groups.List(ctx, 20, 0)
+	require.Nil(t, err)
+	require.Len(t, list.Groups, 2)
+	assert.True(t, list.Groups[0].GroupDelete.Bool()) // Admin.
+
+	require.Nil(t, c.Groups.Delete(ctx, id))
+
+	_, err = c.Groups.Create(ctx, Group{})
+	assert.IsType(t, ActiveCampaignError{}, err)
+}